@@ -1,8 +1,9 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 	
 	gsalary "github.com/difyz9/gsalary-sdk-go"
 )
@@ -19,57 +20,40 @@ func NewCardAPI(client *gsalary.GSalaryClient) *CardAPI {
 
 // ApplyCard 申请新卡片
 func (api *CardAPI) ApplyCard(req *CardApplyRequest) (*CardApplyResponse, error) {
+	return api.ApplyCardCtx(context.Background(), req)
+}
+
+// ApplyCardCtx 申请新卡片（支持context）
+func (api *CardAPI) ApplyCardCtx(ctx context.Context, req *CardApplyRequest) (*CardApplyResponse, error) {
 	// 创建请求
 	request := gsalary.NewRequest("POST", "/v1/card_applies")
 	
 	// 设置请求体
-	request.Body = map[string]interface{}{
-		"request_id":      req.RequestID,
-		"product_code":    req.ProductCode,
-		"currency":        req.Currency,
-		"card_holder_id":  req.CardHolderID,
-		"init_balance":    req.InitBalance,
-	}
-	
-	// 可选参数
-	if req.LimitPerDay > 0 {
-		request.Body["limit_per_day"] = req.LimitPerDay
-	}
-	if req.LimitPerMonth > 0 {
-		request.Body["limit_per_month"] = req.LimitPerMonth
-	}
-	if req.LimitPerTransaction > 0 {
-		request.Body["limit_per_transaction"] = req.LimitPerTransaction
+	if err := request.SetJSONBody(req); err != nil {
+		return nil, fmt.Errorf("apply card failed: %w", err)
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	cardResp, err := gsalary.DoRequest[CardApplyResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("apply card failed: %w", err)
 	}
 	
-	// 解析响应
-	var cardResp CardApplyResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &cardResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if cardResp.Result.Result != "S" {
-		return &cardResp, fmt.Errorf("apply card business error: [%s] %s", 
-			cardResp.Result.Code, cardResp.Result.Message)
+		return cardResp, bizError("apply card", request, cardResp.Result)
 	}
 	
-	return &cardResp, nil
+	return cardResp, nil
 }
 
 // GetAvailableQuotas 查询卡可用余额
 func (api *CardAPI) GetAvailableQuotas(req *CardAvailableQuotasRequest) (*CardAvailableQuotasResponse, error) {
+	return api.GetAvailableQuotasCtx(context.Background(), req)
+}
+
+// GetAvailableQuotasCtx 查询卡可用余额（支持context）
+func (api *CardAPI) GetAvailableQuotasCtx(ctx context.Context, req *CardAvailableQuotasRequest) (*CardAvailableQuotasResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", "/v1/cards/available_quotas")
 	
@@ -82,33 +66,26 @@ func (api *CardAPI) GetAvailableQuotas(req *CardAvailableQuotasRequest) (*CardAv
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	quotasResp, err := gsalary.DoRequest[CardAvailableQuotasResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get available quotas failed: %w", err)
 	}
 	
-	// 解析响应
-	var quotasResp CardAvailableQuotasResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &quotasResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if quotasResp.Result.Result != "S" {
-		return &quotasResp, fmt.Errorf("get available quotas business error: [%s] %s",
-			quotasResp.Result.Code, quotasResp.Result.Message)
+		return quotasResp, bizError("get available quotas", request, quotasResp.Result)
 	}
 	
-	return &quotasResp, nil
+	return quotasResp, nil
 }
 
 // GetProducts 查询可用的卡产品列表
 func (api *CardAPI) GetProducts(req *CardProductsRequest) (*CardProductsResponse, error) {
+	return api.GetProductsCtx(context.Background(), req)
+}
+
+// GetProductsCtx 查询可用的卡产品列表（支持context）
+func (api *CardAPI) GetProductsCtx(ctx context.Context, req *CardProductsRequest) (*CardProductsResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", "/v1/card_support/products")
 	
@@ -124,33 +101,26 @@ func (api *CardAPI) GetProducts(req *CardProductsRequest) (*CardProductsResponse
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	productsResp, err := gsalary.DoRequest[CardProductsResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get card products failed: %w", err)
 	}
 	
-	// 解析响应
-	var productsResp CardProductsResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &productsResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if productsResp.Result.Result != "S" {
-		return &productsResp, fmt.Errorf("get card products business error: [%s] %s",
-			productsResp.Result.Code, productsResp.Result.Message)
+		return productsResp, bizError("get card products", request, productsResp.Result)
 	}
 	
-	return &productsResp, nil
+	return productsResp, nil
 }
 
 // GetCardApplyResult 查询开卡结果
 func (api *CardAPI) GetCardApplyResult(requestID string) (*CardApplyResultResponse, error) {
+	return api.GetCardApplyResultCtx(context.Background(), requestID)
+}
+
+// GetCardApplyResultCtx 查询开卡结果（支持context）
+func (api *CardAPI) GetCardApplyResultCtx(ctx context.Context, requestID string) (*CardApplyResultResponse, error) {
 	if requestID == "" {
 		return nil, fmt.Errorf("request_id is required")
 	}
@@ -160,33 +130,26 @@ func (api *CardAPI) GetCardApplyResult(requestID string) (*CardApplyResultRespon
 	request := gsalary.NewRequest("GET", path)
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	resultResp, err := gsalary.DoRequest[CardApplyResultResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get card apply result failed: %w", err)
 	}
 	
-	// 解析响应
-	var resultResp CardApplyResultResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &resultResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if resultResp.Result.Result != "S" {
-		return &resultResp, fmt.Errorf("get card apply result business error: [%s] %s",
-			resultResp.Result.Code, resultResp.Result.Message)
+		return resultResp, bizError("get card apply result", request, resultResp.Result)
 	}
 	
-	return &resultResp, nil
+	return resultResp, nil
 }
 
 // GetCardList 查询卡列表
 func (api *CardAPI) GetCardList(req *CardListRequest) (*CardListResponse, error) {
+	return api.GetCardListCtx(context.Background(), req)
+}
+
+// GetCardListCtx 查询卡列表（支持context）
+func (api *CardAPI) GetCardListCtx(ctx context.Context, req *CardListRequest) (*CardListResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", "/v1/cards")
 	
@@ -219,33 +182,49 @@ func (api *CardAPI) GetCardList(req *CardListRequest) (*CardListResponse, error)
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	listResp, err := gsalary.DoRequest[CardListResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get card list failed: %w", err)
 	}
 	
-	// 解析响应
-	var listResp CardListResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &listResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if listResp.Result.Result != "S" {
-		return &listResp, fmt.Errorf("get card list business error: [%s] %s",
-			listResp.Result.Code, listResp.Result.Message)
+		return listResp, bizError("get card list", request, listResp.Result)
 	}
 	
-	return &listResp, nil
+	return listResp, nil
+}
+
+// AllCards 按需逐页遍历卡列表，filter 的 Page 为起始页、Limit 为每页数量
+func (api *CardAPI) AllCards(ctx context.Context, filter *CardListRequest) iter.Seq2[Card, error] {
+	var req CardListRequest
+	if filter != nil {
+		req = *filter
+	}
+	return Paginate(ctx, req.Page, req.Limit, func(ctx context.Context, page, limit int) (*Page[Card], error) {
+		pageReq := req
+		pageReq.Page, pageReq.Limit = page, limit
+		resp, err := api.GetCardListCtx(ctx, &pageReq)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[Card]{
+			Items:      resp.Data.Cards,
+			Page:       resp.Data.Page,
+			Limit:      resp.Data.Limit,
+			TotalCount: resp.Data.TotalCount,
+			TotalPage:  resp.Data.TotalPage,
+		}, nil
+	})
 }
 
 // GetCardInfo 查看卡信息
 func (api *CardAPI) GetCardInfo(cardID string) (*CardInfoResponse, error) {
+	return api.GetCardInfoCtx(context.Background(), cardID)
+}
+
+// GetCardInfoCtx 查看卡信息（支持context）
+func (api *CardAPI) GetCardInfoCtx(ctx context.Context, cardID string) (*CardInfoResponse, error) {
 	if cardID == "" {
 		return nil, fmt.Errorf("card_id is required")
 	}
@@ -255,248 +234,185 @@ func (api *CardAPI) GetCardInfo(cardID string) (*CardInfoResponse, error) {
 	request := gsalary.NewRequest("GET", path)
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	infoResp, err := gsalary.DoRequest[CardInfoResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get card info failed: %w", err)
 	}
 	
-	// 解析响应
-	var infoResp CardInfoResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &infoResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if infoResp.Result.Result != "S" {
-		return &infoResp, fmt.Errorf("get card info business error: [%s] %s",
-			infoResp.Result.Code, infoResp.Result.Message)
+		return infoResp, bizError("get card info", request, infoResp.Result)
 	}
 	
-	return &infoResp, nil
+	return infoResp, nil
 }
 
 // UpdateCard 修改卡信息
 func (api *CardAPI) UpdateCard(req *UpdateCardRequest) (*UpdateCardResponse, error) {
+	return api.UpdateCardCtx(context.Background(), req)
+}
+
+// UpdateCardCtx 修改卡信息（支持context）
+func (api *CardAPI) UpdateCardCtx(ctx context.Context, req *UpdateCardRequest) (*UpdateCardResponse, error) {
 	// 创建PUT请求
 	request := gsalary.NewRequest("PUT", fmt.Sprintf("/v1/cards/%s", req.CardID))
 	
 	// 设置请求体
-	body := make(map[string]interface{})
-	if req.CardName != "" {
-		body["card_name"] = req.CardName
-	}
-	if req.LimitPerDay > 0 {
-		body["limit_per_day"] = req.LimitPerDay
-	}
-	if req.LimitPerMonth > 0 {
-		body["limit_per_month"] = req.LimitPerMonth
-	}
-	if req.LimitPerTransaction > 0 {
-		body["limit_per_transaction"] = req.LimitPerTransaction
+	if err := request.SetJSONBody(req); err != nil {
+		return nil, fmt.Errorf("update card failed: %w", err)
 	}
-	request.Body = body
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	updateResp, err := gsalary.DoRequest[UpdateCardResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("update card failed: %w", err)
 	}
 	
-	// 解析响应
-	var updateResp UpdateCardResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &updateResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if updateResp.Result.Result != "S" {
-		return &updateResp, fmt.Errorf("update card business error: [%s] %s",
-			updateResp.Result.Code, updateResp.Result.Message)
+		return updateResp, bizError("update card", request, updateResp.Result)
 	}
 	
-	return &updateResp, nil
+	return updateResp, nil
 }
 
 // DeleteCard 销卡
 func (api *CardAPI) DeleteCard(cardID string) (*DeleteCardResponse, error) {
+	return api.DeleteCardCtx(context.Background(), cardID)
+}
+
+// DeleteCardCtx 销卡（支持context）
+func (api *CardAPI) DeleteCardCtx(ctx context.Context, cardID string) (*DeleteCardResponse, error) {
 	// 创建DELETE请求
 	request := gsalary.NewRequest("DELETE", fmt.Sprintf("/v1/cards/%s", cardID))
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	deleteResp, err := gsalary.DoRequest[DeleteCardResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("delete card failed: %w", err)
 	}
 	
-	// 解析响应
-	var deleteResp DeleteCardResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &deleteResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果（注意：可能返回S/F/U）
 	if deleteResp.Result.Result == "F" {
-		return &deleteResp, fmt.Errorf("delete card business error: [%s] %s",
-			deleteResp.Result.Code, deleteResp.Result.Message)
+		return deleteResp, bizError("delete card", request, deleteResp.Result)
 	}
 	
-	return &deleteResp, nil
+	return deleteResp, nil
 }
 
 // GetCardSecureInfo 获取卡机密信息（PAN、CVV、有效期）
 func (api *CardAPI) GetCardSecureInfo(cardID string) (*CardSecureInfoResponse, error) {
+	return api.GetCardSecureInfoCtx(context.Background(), cardID)
+}
+
+// GetCardSecureInfoCtx 获取卡机密信息（PAN、CVV、有效期）（支持context）
+func (api *CardAPI) GetCardSecureInfoCtx(ctx context.Context, cardID string) (*CardSecureInfoResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", fmt.Sprintf("/v1/cards/%s/secure_info", cardID))
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	secureResp, err := gsalary.DoRequest[CardSecureInfoResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get card secure info failed: %w", err)
 	}
 	
-	// 解析响应
-	var secureResp CardSecureInfoResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &secureResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if secureResp.Result.Result != "S" {
-		return &secureResp, fmt.Errorf("get card secure info business error: [%s] %s",
-			secureResp.Result.Code, secureResp.Result.Message)
+		return secureResp, bizError("get card secure info", request, secureResp.Result)
 	}
 	
-	return &secureResp, nil
+	return secureResp, nil
 }
 
 // AdjustCardBalance 卡片调额（增加或减少余额）
 func (api *CardAPI) AdjustCardBalance(req *AdjustCardBalanceRequest) (*AdjustCardBalanceResponse, error) {
+	return api.AdjustCardBalanceCtx(context.Background(), req)
+}
+
+// AdjustCardBalanceCtx 卡片调额（增加或减少余额）（支持context）
+func (api *CardAPI) AdjustCardBalanceCtx(ctx context.Context, req *AdjustCardBalanceRequest) (*AdjustCardBalanceResponse, error) {
 	// 创建POST请求
 	request := gsalary.NewRequest("POST", "/v1/cards/balance_modifies")
 	
 	// 设置请求体
-	request.Body = map[string]interface{}{
-		"card_id":    req.CardID,
-		"amount":     req.Amount,
-		"type":       req.Type,
-		"request_id": req.RequestID,
+	if err := request.SetJSONBody(req); err != nil {
+		return nil, fmt.Errorf("adjust card balance failed: %w", err)
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	adjustResp, err := gsalary.DoRequest[AdjustCardBalanceResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("adjust card balance failed: %w", err)
 	}
 	
-	// 解析响应
-	var adjustResp AdjustCardBalanceResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &adjustResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if adjustResp.Result.Result != "S" {
-		return &adjustResp, fmt.Errorf("adjust card balance business error: [%s] %s",
-			adjustResp.Result.Code, adjustResp.Result.Message)
+		return adjustResp, bizError("adjust card balance", request, adjustResp.Result)
 	}
 	
-	return &adjustResp, nil
+	return adjustResp, nil
 }
 
 // GetBalanceModifyResult 查询卡片调额结果
 func (api *CardAPI) GetBalanceModifyResult(requestID string) (*GetBalanceModifyResultResponse, error) {
+	return api.GetBalanceModifyResultCtx(context.Background(), requestID)
+}
+
+// GetBalanceModifyResultCtx 查询卡片调额结果（支持context）
+func (api *CardAPI) GetBalanceModifyResultCtx(ctx context.Context, requestID string) (*GetBalanceModifyResultResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", fmt.Sprintf("/v1/cards/balance_modifies/%s", requestID))
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	resultResp, err := gsalary.DoRequest[GetBalanceModifyResultResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get balance modify result failed: %w", err)
 	}
 	
-	// 解析响应
-	var resultResp GetBalanceModifyResultResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &resultResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if resultResp.Result.Result != "S" {
-		return &resultResp, fmt.Errorf("get balance modify result business error: [%s] %s",
-			resultResp.Result.Code, resultResp.Result.Message)
+		return resultResp, bizError("get balance modify result", request, resultResp.Result)
 	}
 	
-	return &resultResp, nil
+	return resultResp, nil
 }
 
 // SetCardFreezeStatus 冻结/解冻卡
 func (api *CardAPI) SetCardFreezeStatus(req *SetCardFreezeStatusRequest) (*SetCardFreezeStatusResponse, error) {
+	return api.SetCardFreezeStatusCtx(context.Background(), req)
+}
+
+// SetCardFreezeStatusCtx 冻结/解冻卡（支持context）
+func (api *CardAPI) SetCardFreezeStatusCtx(ctx context.Context, req *SetCardFreezeStatusRequest) (*SetCardFreezeStatusResponse, error) {
 	// 创建PUT请求
 	request := gsalary.NewRequest("PUT", fmt.Sprintf("/v1/cards/%s/freeze_status", req.CardID))
 	
 	// 设置请求体
-	request.Body = map[string]interface{}{
-		"freeze": req.Freeze,
+	if err := request.SetJSONBody(req); err != nil {
+		return nil, fmt.Errorf("set card freeze status failed: %w", err)
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	freezeResp, err := gsalary.DoRequest[SetCardFreezeStatusResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("set card freeze status failed: %w", err)
 	}
 	
-	// 解析响应
-	var freezeResp SetCardFreezeStatusResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &freezeResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果（注意：可能返回S/F/U）
 	if freezeResp.Result.Result == "F" {
-		return &freezeResp, fmt.Errorf("set card freeze status business error: [%s] %s",
-			freezeResp.Result.Code, freezeResp.Result.Message)
+		return freezeResp, bizError("set card freeze status", request, freezeResp.Result)
 	}
 	
-	return &freezeResp, nil
+	return freezeResp, nil
 }
 
 // GetCardTransactions 查询卡交易列表
 func (api *CardAPI) GetCardTransactions(req *CardTransactionsRequest) (*CardTransactionsResponse, error) {
+	return api.GetCardTransactionsCtx(context.Background(), req)
+}
+
+// GetCardTransactionsCtx 查询卡交易列表（支持context）
+func (api *CardAPI) GetCardTransactionsCtx(ctx context.Context, req *CardTransactionsRequest) (*CardTransactionsResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", "/v1/card_bill/card_transactions")
 	
@@ -521,33 +437,49 @@ func (api *CardAPI) GetCardTransactions(req *CardTransactionsRequest) (*CardTran
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	txResp, err := gsalary.DoRequest[CardTransactionsResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get card transactions failed: %w", err)
 	}
 	
-	// 解析响应
-	var txResp CardTransactionsResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &txResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if txResp.Result.Result != "S" {
-		return &txResp, fmt.Errorf("get card transactions business error: [%s] %s",
-			txResp.Result.Code, txResp.Result.Message)
+		return txResp, bizError("get card transactions", request, txResp.Result)
 	}
 	
-	return &txResp, nil
+	return txResp, nil
+}
+
+// AllTransactions 按需逐页遍历卡交易，filter 的 Page 为起始页、Limit 为每页数量
+func (api *CardAPI) AllTransactions(ctx context.Context, filter *CardTransactionsRequest) iter.Seq2[CardTransaction, error] {
+	var req CardTransactionsRequest
+	if filter != nil {
+		req = *filter
+	}
+	return Paginate(ctx, req.Page, req.Limit, func(ctx context.Context, page, limit int) (*Page[CardTransaction], error) {
+		pageReq := req
+		pageReq.Page, pageReq.Limit = page, limit
+		resp, err := api.GetCardTransactionsCtx(ctx, &pageReq)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[CardTransaction]{
+			Items:      resp.Data.Transactions,
+			Page:       resp.Data.Page,
+			Limit:      resp.Data.Limit,
+			TotalCount: resp.Data.TotalCount,
+			TotalPage:  resp.Data.TotalPage,
+		}, nil
+	})
 }
 
 // GetBalanceHistory 查询卡余额变更记录
 func (api *CardAPI) GetBalanceHistory(req *BalanceHistoryRequest) (*BalanceHistoryResponse, error) {
+	return api.GetBalanceHistoryCtx(context.Background(), req)
+}
+
+// GetBalanceHistoryCtx 查询卡余额变更记录（支持context）
+func (api *CardAPI) GetBalanceHistoryCtx(ctx context.Context, req *BalanceHistoryRequest) (*BalanceHistoryResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", "/v1/card_bill/balance_history")
 	
@@ -572,65 +504,67 @@ func (api *CardAPI) GetBalanceHistory(req *BalanceHistoryRequest) (*BalanceHisto
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	historyResp, err := gsalary.DoRequest[BalanceHistoryResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get balance history failed: %w", err)
 	}
 	
-	// 解析响应
-	var historyResp BalanceHistoryResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &historyResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if historyResp.Result.Result != "S" {
-		return &historyResp, fmt.Errorf("get balance history business error: [%s] %s",
-			historyResp.Result.Code, historyResp.Result.Message)
+		return historyResp, bizError("get balance history", request, historyResp.Result)
 	}
 	
-	return &historyResp, nil
+	return historyResp, nil
+}
+
+// AllBalanceHistory 按需逐页遍历卡余额变更记录，filter 的 Page 为起始页、Limit 为每页数量
+func (api *CardAPI) AllBalanceHistory(ctx context.Context, filter *BalanceHistoryRequest) iter.Seq2[BalanceHistoryRecord, error] {
+	var req BalanceHistoryRequest
+	if filter != nil {
+		req = *filter
+	}
+	return Paginate(ctx, req.Page, req.Limit, func(ctx context.Context, page, limit int) (*Page[BalanceHistoryRecord], error) {
+		pageReq := req
+		pageReq.Page, pageReq.Limit = page, limit
+		resp, err := api.GetBalanceHistoryCtx(ctx, &pageReq)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[BalanceHistoryRecord]{
+			Items:      resp.Data.History,
+			Page:       resp.Data.Page,
+			Limit:      resp.Data.Limit,
+			TotalCount: resp.Data.TotalCount,
+			TotalPage:  resp.Data.TotalPage,
+		}, nil
+	})
 }
 
 // UpdateCardContact 修改卡联系信息（email用于ApplePay绑卡验证）
 func (api *CardAPI) UpdateCardContact(req *UpdateCardContactRequest) (*UpdateCardContactResponse, error) {
+	return api.UpdateCardContactCtx(context.Background(), req)
+}
+
+// UpdateCardContactCtx 修改卡联系信息（email用于ApplePay绑卡验证）（支持context）
+func (api *CardAPI) UpdateCardContactCtx(ctx context.Context, req *UpdateCardContactRequest) (*UpdateCardContactResponse, error) {
 	// 创建PUT请求
 	request := gsalary.NewRequest("PUT", fmt.Sprintf("/v1/cards/%s/contact", req.CardID))
 	
 	// 设置请求体
-	body := make(map[string]interface{})
-	if req.Email != "" {
-		body["email"] = req.Email
+	if err := request.SetJSONBody(req); err != nil {
+		return nil, fmt.Errorf("update card contact failed: %w", err)
 	}
-	request.Body = body
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	contactResp, err := gsalary.DoRequest[UpdateCardContactResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("update card contact failed: %w", err)
 	}
 	
-	// 解析响应
-	var contactResp UpdateCardContactResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &contactResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if contactResp.Result.Result != "S" {
-		return &contactResp, fmt.Errorf("update card contact business error: [%s] %s",
-			contactResp.Result.Code, contactResp.Result.Message)
+		return contactResp, bizError("update card contact", request, contactResp.Result)
 	}
 	
-	return &contactResp, nil
+	return contactResp, nil
 }
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+)
+
+// benchCardTransactionsClient 返回一个直接以大页交易列表短路响应的客户端，排除网络和验签开销
+func benchCardTransactionsClient(b *testing.B) *gsalary.GSalaryClient {
+	b.Helper()
+	var page CardTransactionsResponse
+	page.Result.Result = "S"
+	page.Data.Page = 1
+	page.Data.Limit = 1000
+	page.Data.TotalCount = 1000
+	page.Data.TotalPage = 1
+	for i := 0; i < 1000; i++ {
+		page.Data.Transactions = append(page.Data.Transactions, CardTransaction{
+			TransactionID:        fmt.Sprintf("tx-%06d", i),
+			CardID:               "card-000001",
+			TransactionType:      "AUTH",
+			Amount:               NewDecimal(int64(i)*100+99, 2),
+			Currency:             "USD",
+			Status:               "SUCCESS",
+			StatusDescription:    "approved",
+			TransactionTime:      "2024-05-01T12:00:00+00:00",
+			MerchantName:         "MERCHANT NAME",
+			MerchantCountry:      "US",
+			MerchantCategoryCode: "5734",
+		})
+	}
+	body, err := json.Marshal(page)
+	if err != nil {
+		b.Fatalf("Failed to marshal page: %v", err)
+	}
+
+	config := gsalary.NewConfig()
+	config.AppID = "bench_app"
+	client := gsalary.NewClient(config)
+	client.Use(func(next gsalary.Handler) gsalary.Handler {
+		return func(ctx context.Context, req *gsalary.GSalaryRequest) (*gsalary.Response, error) {
+			return &gsalary.Response{StatusCode: 200, Body: body}, nil
+		}
+	})
+	return client
+}
+
+// BenchmarkCardTransactionsLegacyDecode 旧的解析方式：map -> Marshal -> Unmarshal
+func BenchmarkCardTransactionsLegacyDecode(b *testing.B) {
+	client := benchCardTransactionsClient(b)
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		request := gsalary.NewRequest("GET", "/v1/card_bill/card_transactions")
+		request.QueryArgs["page"] = "1"
+		request.QueryArgs["limit"] = "1000"
+		resp, err := client.RequestCtx(ctx, request)
+		if err != nil {
+			b.Fatal(err)
+		}
+		respBytes, err := json.Marshal(resp)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var txResp CardTransactionsResponse
+		if err := json.Unmarshal(respBytes, &txResp); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCardTransactionsDecode 类型化管道：响应体只解码一次
+func BenchmarkCardTransactionsDecode(b *testing.B) {
+	api := NewCardAPI(benchCardTransactionsClient(b))
+	ctx := context.Background()
+	req := &CardTransactionsRequest{Page: 1, Limit: 1000}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := api.GetCardTransactionsCtx(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package api
 
 import (
 	"fmt"
-	"os"
 	"testing"
 	"time"
 	
@@ -13,40 +12,20 @@ import (
 var testConfig *gsalary.GSalaryConfig
 
 func init() {
-	// 初始化测试配置
-	testConfig = gsalary.NewConfig()
-	
-	// 从环境变量获取 AppID（必需）
-	testConfig.AppID = os.Getenv("GSALARY_APPID")
-	if testConfig.AppID == "" {
-		testConfig.AppID = "your_app_id_here" // 占位符，实际使用时需要设置环境变量
-	}
-	
-	// 从环境变量获取 Endpoint（可选，默认测试环境）
-	testConfig.Endpoint = os.Getenv("GSALARY_ENDPOINT")
-	if testConfig.Endpoint == "" {
-		testConfig.Endpoint = "https://api-test.gsalary.com"
-	}
-	
-	// 尝试加载环境变量中的密钥路径
-	privateKeyFile := os.Getenv("GSALARY_CLIENT_PRIVATE_KEY_FILE")
-	if privateKeyFile == "" {
-		privateKeyFile = "../private_key.pem"
-	}
-	
-	publicKeyFile := os.Getenv("GSALARY_SERVER_PUBLIC_KEY_FILE")
-	if publicKeyFile == "" {
-		publicKeyFile = "../server_public_key.pem"
-	}
-	
-	// 加载密钥
-	if err := testConfig.ConfigClientPrivateKeyPEMFile(privateKeyFile); err != nil {
-		fmt.Printf("Warning: Failed to load private key: %v\n", err)
+	// 默认使用仓库根目录下的密钥文件，可被 .env 和环境变量覆盖
+	defaults := gsalary.FromMap("test defaults", map[string]string{
+		"client_private_key_file": "../private_key.pem",
+		"server_public_key_file":  "../server_public_key.pem",
+	})
+	config, _, err := gsalary.LoadConfig(defaults, gsalary.FromDotEnv("../.env"), gsalary.FromEnv())
+	if err != nil {
+		// 保留已解析的 AppID 和地址，缺少密钥的集成测试会被跳过
+		fmt.Printf("Warning: %v\n", err)
 	}
-	
-	if err := testConfig.ConfigServerPublicKeyPEMFile(publicKeyFile); err != nil {
-		fmt.Printf("Warning: Failed to load public key: %v\n", err)
+	if config == nil {
+		config = gsalary.NewConfig()
 	}
+	testConfig = config
 }
 
 // TestCardApply 测试申请卡片
@@ -68,10 +47,10 @@ func TestCardApply(t *testing.T) {
 		ProductCode:         "VIRTUAL_CARD_USD", // 根据实际产品代码调整
 		Currency:            "USD",
 		CardHolderID:        "test_holder_001",
-		LimitPerDay:         1000.00,
-		LimitPerMonth:       5000.00,
-		LimitPerTransaction: 500.00,
-		InitBalance:         100.00,
+		LimitPerDay:         MustParseDecimal("1000.00"),
+		LimitPerMonth:       MustParseDecimal("5000.00"),
+		LimitPerTransaction: MustParseDecimal("500.00"),
+		InitBalance:         MustParseDecimal("100.00"),
 	}
 	
 	// 发起申请
@@ -127,7 +106,7 @@ func TestCardApplyWithMinimalParams(t *testing.T) {
 		ProductCode:  "VIRTUAL_CARD_USD",
 		Currency:     "USD",
 		CardHolderID: "test_holder_002",
-		InitBalance:  50.00,
+		InitBalance:  MustParseDecimal("50.00"),
 		// 不设置limit参数，使用默认值
 	}
 	
@@ -169,7 +148,7 @@ func TestCardApplyInvalidParams(t *testing.T) {
 		ProductCode:  "VIRTUAL_CARD_USD",
 		Currency:     "USD",
 		CardHolderID: "test_holder_003",
-		InitBalance:  10.00,
+		InitBalance:  MustParseDecimal("10.00"),
 	}
 	
 	_, err := client.Card.ApplyCard(req)
@@ -185,7 +164,7 @@ func TestCardApplyInvalidParams(t *testing.T) {
 		ProductCode:  "VIRTUAL_CARD_USD",
 		Currency:     "INVALID", // 无效的货币代码
 		CardHolderID: "test_holder_004",
-		InitBalance:  10.00,
+		InitBalance:  MustParseDecimal("10.00"),
 	}
 	
 	_, err = client.Card.ApplyCard(req2)
@@ -231,7 +210,7 @@ func TestGetAvailableQuotas(t *testing.T) {
 	t.Logf("Result: %s", resp.Result.Result)
 	t.Logf("Currency: %s", resp.Data.Currency)
 	t.Logf("Accounting Card Type: %s", resp.Data.AccountingCardType)
-	t.Logf("Available Quota: %.2f", resp.Data.AvailableQuota)
+	t.Logf("Available Quota: %s", resp.Data.AvailableQuota)
 	
 	// 验证基本响应
 	if resp.Result.Result != "S" {
@@ -276,7 +255,7 @@ func TestGetAvailableQuotasDefaultType(t *testing.T) {
 	t.Logf("Get available quotas (default type) success!")
 	t.Logf("Currency: %s", resp.Data.Currency)
 	t.Logf("Accounting Card Type: %s", resp.Data.AccountingCardType)
-	t.Logf("Available Quota: %.2f", resp.Data.AvailableQuota)
+	t.Logf("Available Quota: %s", resp.Data.AvailableQuota)
 }
 
 // TestGetProducts 测试查询可用的卡产品列表
@@ -394,7 +373,7 @@ func TestGetCardApplyResult(t *testing.T) {
 		ProductCode:  "VIRTUAL_CARD_USD",
 		Currency:     "USD",
 		CardHolderID: "test_holder_query",
-		InitBalance:  10.00,
+		InitBalance:  MustParseDecimal("10.00"),
 	}
 	
 	applyResp, err := client.Card.ApplyCard(applyReq)
@@ -614,7 +593,7 @@ func TestGetCardListByHolder(t *testing.T) {
 		ProductCode:  "VIRTUAL_CARD_USD",
 		Currency:     "USD",
 		CardHolderID: holderID,
-		InitBalance:  10.00,
+		InitBalance:  MustParseDecimal("10.00"),
 	}
 	
 	_, err := client.Card.ApplyCard(applyReq)
@@ -702,7 +681,7 @@ func TestGetCardInfo(t *testing.T) {
 	t.Logf("Card Name: %s", resp.Data.CardName)
 	t.Logf("Mask Card Number: %s", resp.Data.MaskCardNumber)
 	t.Logf("Currency: %s", resp.Data.CardCurrency)
-	t.Logf("Available Balance: %.2f", resp.Data.AvailableBalance)
+	t.Logf("Available Balance: %s", resp.Data.AvailableBalance)
 	t.Logf("Brand Code: %s", resp.Data.BrandCode)
 	t.Logf("Status: %s", resp.Data.Status)
 	t.Logf("Card Type: %s", resp.Data.CardType)
@@ -712,9 +691,9 @@ func TestGetCardInfo(t *testing.T) {
 	t.Logf("First Name: %s", resp.Data.FirstName)
 	t.Logf("Last Name: %s", resp.Data.LastName)
 	t.Logf("Email: %s", resp.Data.Email)
-	t.Logf("Limit Per Day: %.2f", resp.Data.LimitPerDay)
-	t.Logf("Limit Per Month: %.2f", resp.Data.LimitPerMonth)
-	t.Logf("Limit Per Transaction: %.2f", resp.Data.LimitPerTransaction)
+	t.Logf("Limit Per Day: %s", resp.Data.LimitPerDay)
+	t.Logf("Limit Per Month: %s", resp.Data.LimitPerMonth)
+	t.Logf("Limit Per Transaction: %s", resp.Data.LimitPerTransaction)
 	t.Logf("Support TDS Trans: %v", resp.Data.SupportTdsTrans)
 	t.Logf("Create Time: %s", resp.Data.CreateTime)
 	
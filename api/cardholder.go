@@ -1,8 +1,9 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 	
 	gsalary "github.com/difyz9/gsalary-sdk-go"
 )
@@ -19,58 +20,40 @@ func NewCardHolderAPI(client *gsalary.GSalaryClient) *CardHolderAPI {
 
 // AddCardHolder 添加持卡人
 func (api *CardHolderAPI) AddCardHolder(req *CardHolderRequest) (*CardHolderResponse, error) {
+	return api.AddCardHolderCtx(context.Background(), req)
+}
+
+// AddCardHolderCtx 添加持卡人（支持context）
+func (api *CardHolderAPI) AddCardHolderCtx(ctx context.Context, req *CardHolderRequest) (*CardHolderResponse, error) {
 	// 创建POST请求
 	request := gsalary.NewRequest("POST", "/v1/card_holders")
 	
 	// 设置请求体
-	request.Body = map[string]interface{}{
-		"first_name": req.FirstName,
-		"last_name":  req.LastName,
-		"birth":      req.Birth,
-		"email":      req.Email,
-		"mobile": map[string]interface{}{
-			"country_code": req.Mobile.CountryCode,
-			"number":       req.Mobile.Number,
-		},
-		"region": req.Region,
-		"bill_address": map[string]interface{}{
-			"country":     req.BillAddress.Country,
-			"state":       req.BillAddress.State,
-			"city":        req.BillAddress.City,
-			"postal_code": req.BillAddress.PostalCode,
-			"line1":       req.BillAddress.Line1,
-			"line2":       req.BillAddress.Line2,
-		},
+	if err := request.SetJSONBody(req); err != nil {
+		return nil, fmt.Errorf("add card holder failed: %w", err)
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	holderResp, err := gsalary.DoRequest[CardHolderResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("add card holder failed: %w", err)
 	}
 	
-	// 解析响应
-	var holderResp CardHolderResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &holderResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if holderResp.Result.Result != "S" {
-		return &holderResp, fmt.Errorf("add card holder business error: [%s] %s",
-			holderResp.Result.Code, holderResp.Result.Message)
+		return holderResp, bizError("add card holder", request, holderResp.Result)
 	}
 	
-	return &holderResp, nil
+	return holderResp, nil
 }
 
 // GetCardHolderList 查询持卡人列表
 func (api *CardHolderAPI) GetCardHolderList(req *CardHolderListRequest) (*CardHolderListResponse, error) {
+	return api.GetCardHolderListCtx(context.Background(), req)
+}
+
+// GetCardHolderListCtx 查询持卡人列表（支持context）
+func (api *CardHolderAPI) GetCardHolderListCtx(ctx context.Context, req *CardHolderListRequest) (*CardHolderListResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", "/v1/card_holders")
 	
@@ -91,33 +74,49 @@ func (api *CardHolderAPI) GetCardHolderList(req *CardHolderListRequest) (*CardHo
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	listResp, err := gsalary.DoRequest[CardHolderListResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get card holder list failed: %w", err)
 	}
 	
-	// 解析响应
-	var listResp CardHolderListResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &listResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if listResp.Result.Result != "S" {
-		return &listResp, fmt.Errorf("get card holder list business error: [%s] %s",
-			listResp.Result.Code, listResp.Result.Message)
+		return listResp, bizError("get card holder list", request, listResp.Result)
 	}
 	
-	return &listResp, nil
+	return listResp, nil
+}
+
+// AllCardHolders 按需逐页遍历持卡人，filter 的 Page 为起始页、Limit 为每页数量
+func (api *CardHolderAPI) AllCardHolders(ctx context.Context, filter *CardHolderListRequest) iter.Seq2[CardHolderInfo, error] {
+	var req CardHolderListRequest
+	if filter != nil {
+		req = *filter
+	}
+	return Paginate(ctx, req.Page, req.Limit, func(ctx context.Context, page, limit int) (*Page[CardHolderInfo], error) {
+		pageReq := req
+		pageReq.Page, pageReq.Limit = page, limit
+		resp, err := api.GetCardHolderListCtx(ctx, &pageReq)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[CardHolderInfo]{
+			Items:      resp.Data.CardHolders,
+			Page:       resp.Data.Page,
+			Limit:      resp.Data.Limit,
+			TotalCount: resp.Data.TotalCount,
+			TotalPage:  resp.Data.TotalPage,
+		}, nil
+	})
 }
 
 // GetCardHolderInfo 查看持卡人信息
 func (api *CardHolderAPI) GetCardHolderInfo(cardHolderID string) (*CardHolderDetailResponse, error) {
+	return api.GetCardHolderInfoCtx(context.Background(), cardHolderID)
+}
+
+// GetCardHolderInfoCtx 查看持卡人信息（支持context）
+func (api *CardHolderAPI) GetCardHolderInfoCtx(ctx context.Context, cardHolderID string) (*CardHolderDetailResponse, error) {
 	if cardHolderID == "" {
 		return nil, fmt.Errorf("card_holder_id is required")
 	}
@@ -127,33 +126,26 @@ func (api *CardHolderAPI) GetCardHolderInfo(cardHolderID string) (*CardHolderDet
 	request := gsalary.NewRequest("GET", path)
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	infoResp, err := gsalary.DoRequest[CardHolderDetailResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get card holder info failed: %w", err)
 	}
 	
-	// 解析响应
-	var infoResp CardHolderDetailResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &infoResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if infoResp.Result.Result != "S" {
-		return &infoResp, fmt.Errorf("get card holder info business error: [%s] %s",
-			infoResp.Result.Code, infoResp.Result.Message)
+		return infoResp, bizError("get card holder info", request, infoResp.Result)
 	}
 	
-	return &infoResp, nil
+	return infoResp, nil
 }
 
 // UpdateCardHolder 修改持卡人信息
 func (api *CardHolderAPI) UpdateCardHolder(cardHolderID string, req *UpdateCardHolderRequest) (*UpdateCardHolderResponse, error) {
+	return api.UpdateCardHolderCtx(context.Background(), cardHolderID, req)
+}
+
+// UpdateCardHolderCtx 修改持卡人信息（支持context）
+func (api *CardHolderAPI) UpdateCardHolderCtx(ctx context.Context, cardHolderID string, req *UpdateCardHolderRequest) (*UpdateCardHolderResponse, error) {
 	if cardHolderID == "" {
 		return nil, fmt.Errorf("card_holder_id is required")
 	}
@@ -163,48 +155,20 @@ func (api *CardHolderAPI) UpdateCardHolder(cardHolderID string, req *UpdateCardH
 	request := gsalary.NewRequest("PUT", path)
 	
 	// 设置请求体
-	request.Body = map[string]interface{}{
-		"first_name": req.FirstName,
-		"last_name":  req.LastName,
-		"birth":      req.Birth,
-		"email":      req.Email,
-		"mobile": map[string]interface{}{
-			"country_code": req.Mobile.CountryCode,
-			"number":       req.Mobile.Number,
-		},
-		"region": req.Region,
-		"bill_address": map[string]interface{}{
-			"country":     req.BillAddress.Country,
-			"state":       req.BillAddress.State,
-			"city":        req.BillAddress.City,
-			"postal_code": req.BillAddress.PostalCode,
-			"line1":       req.BillAddress.Line1,
-			"line2":       req.BillAddress.Line2,
-		},
+	if err := request.SetJSONBody(req); err != nil {
+		return nil, fmt.Errorf("update card holder failed: %w", err)
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	updateResp, err := gsalary.DoRequest[UpdateCardHolderResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("update card holder failed: %w", err)
 	}
 	
-	// 解析响应
-	var updateResp UpdateCardHolderResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &updateResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if updateResp.Result.Result != "S" {
-		return &updateResp, fmt.Errorf("update card holder business error: [%s] %s",
-			updateResp.Result.Code, updateResp.Result.Message)
+		return updateResp, bizError("update card holder", request, updateResp.Result)
 	}
 	
-	return &updateResp, nil
+	return updateResp, nil
 }
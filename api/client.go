@@ -17,9 +17,9 @@ type Client struct {
 	Remittance *RemittanceAPI  // 对外付款订单
 }
 
-// NewClient 创建新的API客户端
-func NewClient(config *gsalary.GSalaryConfig) *Client {
-	gsalaryClient := gsalary.NewClient(config)
+// NewClient 创建新的API客户端，opts 会透传给 gsalary.NewClient
+func NewClient(config *gsalary.GSalaryConfig, opts ...gsalary.Option) *Client {
+	gsalaryClient := gsalary.NewClient(config, opts...)
 	
 	return &Client{
 		client:     gsalaryClient,
@@ -0,0 +1,304 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal 精确的十进制数，用于金额、余额、限额和汇率等字段
+//
+// 底层保存规范化的十进制文本（如 "100.50"），JSON 编解码时原样作为数字输出，
+// 不经过 float64，因此不会产生舍入误差。零值 "" 表示 0，并且可以配合 omitempty 省略。
+// 请通过 ParseDecimal、NewDecimal 等构造函数创建；直接转换（如 Decimal("abc")）得到的非法文本
+// 在运算时返回 ErrInvalidDecimal。
+//
+// 不要用 == 比较两个 Decimal：== 比较的是文本，"1.5" 与 "1.50" 不相等，请使用 Equal 或 Cmp。
+type Decimal string
+
+// ErrInvalidDecimal 非法的十进制文本
+var ErrInvalidDecimal = errors.New("api: invalid decimal")
+
+// maxDecimalScale 允许的最大小数位数（及指数），防止恶意输入导致超大计算
+const maxDecimalScale = 1000
+
+// NewDecimal 由整数值和小数位数创建，如 NewDecimal(150, 2) 表示 1.50
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	return formatDecimal(big.NewInt(unscaled), scale)
+}
+
+// DecimalFromInt 由整数创建
+func DecimalFromInt(i int64) Decimal {
+	return NewDecimal(i, 0)
+}
+
+// DecimalFromFloat 由 float64 创建，取能还原该 float64 的最短十进制表示
+// 用于从旧的 float64 字段迁移，新代码应使用 ParseDecimal
+func DecimalFromFloat(f float64) Decimal {
+	d, err := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		panic(fmt.Sprintf("api: invalid float decimal %v", f))
+	}
+	return d
+}
+
+// ParseDecimal 解析十进制文本，支持符号、小数和指数（如 "-1.50"、"1e3"）
+func ParseDecimal(s string) (Decimal, error) {
+	unscaled, scale, err := parseDecimal(s)
+	if err != nil {
+		return "", err
+	}
+	return formatDecimal(unscaled, scale), nil
+}
+
+// MustParseDecimal 解析十进制文本，失败时 panic，适用于常量
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// String 返回十进制文本，零值返回 "0"
+func (d Decimal) String() string {
+	if d == "" {
+		return "0"
+	}
+	return string(d)
+}
+
+// Float64 转换为 float64（可能丢失精度），用于兼容旧代码
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Valid 判断是否为合法的十进制文本
+func (d Decimal) Valid() bool {
+	_, _, err := parseDecimal(string(d))
+	return err == nil
+}
+
+// Scale 返回小数位数
+func (d Decimal) Scale() (int32, error) {
+	_, scale, err := parseDecimal(string(d))
+	return scale, err
+}
+
+// Sign 返回符号：负数 -1，零 0，正数 1
+func (d Decimal) Sign() (int, error) {
+	unscaled, _, err := parseDecimal(string(d))
+	if err != nil {
+		return 0, err
+	}
+	return unscaled.Sign(), nil
+}
+
+// IsZero 判断是否为零，非法文本返回 false
+func (d Decimal) IsZero() bool {
+	sign, err := d.Sign()
+	return err == nil && sign == 0
+}
+
+// Neg 返回相反数
+func (d Decimal) Neg() (Decimal, error) {
+	unscaled, scale, err := parseDecimal(string(d))
+	if err != nil {
+		return "", err
+	}
+	return formatDecimal(new(big.Int).Neg(unscaled), scale), nil
+}
+
+// Add 返回 d + other，结果的小数位数取两者较大值
+func (d Decimal) Add(other Decimal) (Decimal, error) {
+	a, b, scale, err := alignDecimals(d, other)
+	if err != nil {
+		return "", err
+	}
+	return formatDecimal(a.Add(a, b), scale), nil
+}
+
+// Sub 返回 d - other，结果的小数位数取两者较大值
+func (d Decimal) Sub(other Decimal) (Decimal, error) {
+	a, b, scale, err := alignDecimals(d, other)
+	if err != nil {
+		return "", err
+	}
+	return formatDecimal(a.Sub(a, b), scale), nil
+}
+
+// Cmp 比较大小：d < other 返回 -1，相等返回 0，d > other 返回 1
+// 与小数位数无关，"1.5" 与 "1.50" 相等
+func (d Decimal) Cmp(other Decimal) (int, error) {
+	a, b, _, err := alignDecimals(d, other)
+	if err != nil {
+		return 0, err
+	}
+	return a.Cmp(b), nil
+}
+
+// Equal 判断数值是否相等，与小数位数无关；任一方为非法文本时返回 false
+func (d Decimal) Equal(other Decimal) bool {
+	c, err := d.Cmp(other)
+	return err == nil && c == 0
+}
+
+// Round 四舍五入（远离零）到指定小数位数
+func (d Decimal) Round(places int32) (Decimal, error) {
+	unscaled, scale, err := parseDecimal(string(d))
+	if err != nil {
+		return "", err
+	}
+	return roundDecimal(unscaled, scale, places), nil
+}
+
+// roundDecimal 将整数值和小数位数四舍五入（远离零）到 places 位
+func roundDecimal(unscaled *big.Int, scale, places int32) Decimal {
+	if scale <= places {
+		return formatDecimal(rescale(unscaled, scale, places), places)
+	}
+	divisor := pow10(scale - places)
+	quo, rem := new(big.Int).QuoRem(unscaled, divisor, new(big.Int))
+	// |rem| * 2 >= divisor 时进位
+	if rem.Abs(rem).Lsh(rem, 1).Cmp(divisor) >= 0 {
+		if unscaled.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+	return formatDecimal(quo, places)
+}
+
+// MarshalJSON 编码为JSON数字
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if !d.Valid() {
+		return nil, fmt.Errorf("%w %q", ErrInvalidDecimal, string(d))
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON 从JSON数字解码，兼容字符串形式的数字，null 解码为零值
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		*d = ""
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		data = []byte(s)
+	}
+	parsed, err := ParseDecimal(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// alignDecimals 将两个数对齐到相同的小数位数
+func alignDecimals(x, y Decimal) (*big.Int, *big.Int, int32, error) {
+	a, scaleA, err := parseDecimal(string(x))
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	b, scaleB, err := parseDecimal(string(y))
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	scale := max(scaleA, scaleB)
+	return rescale(a, scaleA, scale), rescale(b, scaleB, scale), scale, nil
+}
+
+// rescale 将小数位数从 from 扩大到 to（to >= from）
+func rescale(unscaled *big.Int, from, to int32) *big.Int {
+	if to <= from {
+		return new(big.Int).Set(unscaled)
+	}
+	return new(big.Int).Mul(unscaled, pow10(to-from))
+}
+
+// pow10 返回 10^n
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// parseDecimal 将十进制文本解析为整数值和小数位数，空串视为 0
+func parseDecimal(s string) (*big.Int, int32, error) {
+	if s == "" {
+		return new(big.Int), 0, nil
+	}
+	mantissa, exponent := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w %q", ErrInvalidDecimal, s)
+		}
+		mantissa, exponent = s[:i], exp
+	}
+
+	digits := mantissa
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		digits = digits[1:]
+	}
+	intPart, fracPart := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		intPart, fracPart = digits[:i], digits[i+1:]
+	}
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return nil, 0, fmt.Errorf("%w %q", ErrInvalidDecimal, s)
+	}
+
+	unscaled, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return nil, 0, fmt.Errorf("%w %q", ErrInvalidDecimal, s)
+	}
+	if mantissa[0] == '-' {
+		unscaled.Neg(unscaled)
+	}
+	scale := int64(len(fracPart)) - exponent
+	if scale > maxDecimalScale || scale < -maxDecimalScale {
+		return nil, 0, fmt.Errorf("%w: %q out of range", ErrInvalidDecimal, s)
+	}
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(int32(-scale)))
+		scale = 0
+	}
+	return unscaled, int32(scale), nil
+}
+
+// isDigits 判断是否全部为数字（允许空串）
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// formatDecimal 将整数值和小数位数格式化为十进制文本
+func formatDecimal(unscaled *big.Int, scale int32) Decimal {
+	if scale <= 0 {
+		return Decimal(new(big.Int).Mul(unscaled, pow10(-scale)).String())
+	}
+	digits := new(big.Int).Abs(unscaled).String()
+	if pad := int(scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	point := len(digits) - int(scale)
+	text := digits[:point] + "." + digits[point:]
+	if unscaled.Sign() < 0 {
+		text = "-" + text
+	}
+	return Decimal(text)
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"fmt"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+)
+
+// ResultInfo 响应中的业务结果
+type ResultInfo struct {
+	Result  string `json:"result"`  // 结果：S-成功，F-失败，U-未知
+	Code    string `json:"code"`    // 结果代码
+	Message string `json:"message"` // 结果消息
+}
+
+// BaseResponse 只包含业务结果的响应
+type BaseResponse struct {
+	Result ResultInfo `json:"result"`
+}
+
+// bizError 将非成功的业务结果包装为 *gsalary.APIError
+func bizError(op string, request *gsalary.GSalaryRequest, result ResultInfo) error {
+	return fmt.Errorf("%s business error: %w", op,
+		gsalary.NewBusinessError(request, result.Result, result.Code, result.Message))
+}
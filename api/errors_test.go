@@ -0,0 +1,30 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+)
+
+// TestBizError 测试业务错误可以通过 errors.Is/As 识别
+func TestBizError(t *testing.T) {
+	request := gsalary.NewRequest("POST", "/v1/cards/balance_modifies")
+	err := bizError("adjust card balance", request, ResultInfo{
+		Result:  "F",
+		Code:    "INSUFFICIENT_BALANCE",
+		Message: "balance not enough",
+	})
+
+	if !errors.Is(err, gsalary.ErrInsufficientBalance) {
+		t.Errorf("Expected ErrInsufficientBalance, got %v", err)
+	}
+
+	var apiErr *gsalary.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *gsalary.APIError, got %T", err)
+	}
+	if apiErr.Result != "F" || apiErr.Endpoint != "/v1/cards/balance_modifies" {
+		t.Errorf("Unexpected APIError: %+v", apiErr)
+	}
+}
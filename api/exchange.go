@@ -1,8 +1,9 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 	
 	gsalary "github.com/difyz9/gsalary-sdk-go"
 )
@@ -19,6 +20,11 @@ func NewExchangeAPI(client *gsalary.GSalaryClient) *ExchangeAPI {
 
 // GetCurrentExchangeRate 查询当前汇率
 func (api *ExchangeAPI) GetCurrentExchangeRate(req *ExchangeRateRequest) (*ExchangeRateResponse, error) {
+	return api.GetCurrentExchangeRateCtx(context.Background(), req)
+}
+
+// GetCurrentExchangeRateCtx 查询当前汇率（支持context）
+func (api *ExchangeAPI) GetCurrentExchangeRateCtx(ctx context.Context, req *ExchangeRateRequest) (*ExchangeRateResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", "/v1/exchange/current_exchange_rate")
 	
@@ -27,114 +33,90 @@ func (api *ExchangeAPI) GetCurrentExchangeRate(req *ExchangeRateRequest) (*Excha
 	request.QueryArgs["sell_currency"] = req.SellCurrency
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	rateResp, err := gsalary.DoRequest[ExchangeRateResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get exchange rate failed: %w", err)
 	}
 	
-	// 解析响应
-	var rateResp ExchangeRateResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &rateResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if rateResp.Result.Result != "S" {
-		return &rateResp, fmt.Errorf("get exchange rate business error: [%s] %s",
-			rateResp.Result.Code, rateResp.Result.Message)
+		return rateResp, bizError("get exchange rate", request, rateResp.Result)
 	}
 	
-	return &rateResp, nil
+	return rateResp, nil
 }
 
 // RequestQuote 请求锁汇报价
 func (api *ExchangeAPI) RequestQuote(req *ExchangeQuoteRequest) (*ExchangeQuoteResponse, error) {
+	return api.RequestQuoteCtx(context.Background(), req)
+}
+
+// RequestQuoteCtx 请求锁汇报价（支持context）
+func (api *ExchangeAPI) RequestQuoteCtx(ctx context.Context, req *ExchangeQuoteRequest) (*ExchangeQuoteResponse, error) {
 	// 创建POST请求
 	request := gsalary.NewRequest("POST", "/v1/exchange/quotes")
 	
-	// 设置请求体
-	request.Body = map[string]interface{}{
-		"buy_currency":  req.BuyCurrency,
-		"sell_currency": req.SellCurrency,
-	}
-	
 	// 购入金额和卖出金额不可同时为空，如果同时提供将忽略购入金额
-	if req.SellAmount > 0 {
-		request.Body["sell_amount"] = req.SellAmount
-	} else if req.BuyAmount > 0 {
-		request.Body["buy_amount"] = req.BuyAmount
+	body := *req
+	if sign, err := body.SellAmount.Sign(); err == nil && sign > 0 {
+		body.BuyAmount = ""
 	}
 	
-	// 发送请求
-	resp, err := api.client.Request(request)
-	if err != nil {
+	// 设置请求体
+	if err := request.SetJSONBody(&body); err != nil {
 		return nil, fmt.Errorf("request exchange quote failed: %w", err)
 	}
 	
-	// 解析响应
-	var quoteResp ExchangeQuoteResponse
-	respBytes, err := json.Marshal(resp)
+	// 发送请求
+	quoteResp, err := gsalary.DoRequest[ExchangeQuoteResponse](ctx, api.client, request)
 	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &quoteResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
+		return nil, fmt.Errorf("request exchange quote failed: %w", err)
 	}
 	
 	// 检查业务结果
 	if quoteResp.Result.Result != "S" {
-		return &quoteResp, fmt.Errorf("request exchange quote business error: [%s] %s",
-			quoteResp.Result.Code, quoteResp.Result.Message)
+		return quoteResp, bizError("request exchange quote", request, quoteResp.Result)
 	}
 	
-	return &quoteResp, nil
+	return quoteResp, nil
 }
 
 // SubmitExchangeRequest 提交换汇订单
 func (api *ExchangeAPI) SubmitExchangeRequest(req *ExchangeSubmitRequest) (*ExchangeSubmitResponse, error) {
+	return api.SubmitExchangeRequestCtx(context.Background(), req)
+}
+
+// SubmitExchangeRequestCtx 提交换汇订单（支持context）
+func (api *ExchangeAPI) SubmitExchangeRequestCtx(ctx context.Context, req *ExchangeSubmitRequest) (*ExchangeSubmitResponse, error) {
 	// 创建POST请求
 	request := gsalary.NewRequest("POST", "/v1/exchange/submit_request")
 	
 	// 设置请求体
-	request.Body = map[string]interface{}{
-		"request_id": req.RequestID,
-		"quote_id":   req.QuoteID,
+	if err := request.SetJSONBody(req); err != nil {
+		return nil, fmt.Errorf("submit exchange request failed: %w", err)
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	submitResp, err := gsalary.DoRequest[ExchangeSubmitResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("submit exchange request failed: %w", err)
 	}
 	
-	// 解析响应
-	var submitResp ExchangeSubmitResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &submitResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if submitResp.Result.Result != "S" {
-		return &submitResp, fmt.Errorf("submit exchange request business error: [%s] %s",
-			submitResp.Result.Code, submitResp.Result.Message)
+		return submitResp, bizError("submit exchange request", request, submitResp.Result)
 	}
 	
-	return &submitResp, nil
+	return submitResp, nil
 }
 
 // GetExchangeOrders 查询换汇订单列表
 func (api *ExchangeAPI) GetExchangeOrders(req *ExchangeOrdersRequest) (*ExchangeOrdersResponse, error) {
+	return api.GetExchangeOrdersCtx(context.Background(), req)
+}
+
+// GetExchangeOrdersCtx 查询换汇订单列表（支持context）
+func (api *ExchangeAPI) GetExchangeOrdersCtx(ctx context.Context, req *ExchangeOrdersRequest) (*ExchangeOrdersResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", "/v1/exchange/orders")
 	
@@ -162,27 +144,38 @@ func (api *ExchangeAPI) GetExchangeOrders(req *ExchangeOrdersRequest) (*Exchange
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	ordersResp, err := gsalary.DoRequest[ExchangeOrdersResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get exchange orders failed: %w", err)
 	}
 	
-	// 解析响应
-	var ordersResp ExchangeOrdersResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &ordersResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if ordersResp.Result.Result != "S" {
-		return &ordersResp, fmt.Errorf("get exchange orders business error: [%s] %s",
-			ordersResp.Result.Code, ordersResp.Result.Message)
+		return ordersResp, bizError("get exchange orders", request, ordersResp.Result)
 	}
 	
-	return &ordersResp, nil
+	return ordersResp, nil
+}
+
+// AllExchangeOrders 按需逐页遍历换汇订单，filter 的 Page 为起始页、Limit 为每页数量
+func (api *ExchangeAPI) AllExchangeOrders(ctx context.Context, filter *ExchangeOrdersRequest) iter.Seq2[ExchangeOrder, error] {
+	var req ExchangeOrdersRequest
+	if filter != nil {
+		req = *filter
+	}
+	return Paginate(ctx, req.Page, req.Limit, func(ctx context.Context, page, limit int) (*Page[ExchangeOrder], error) {
+		pageReq := req
+		pageReq.Page, pageReq.Limit = page, limit
+		resp, err := api.GetExchangeOrdersCtx(ctx, &pageReq)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[ExchangeOrder]{
+			Items:      resp.Data.Orders,
+			Page:       resp.Data.Page,
+			Limit:      resp.Data.Limit,
+			TotalCount: resp.Data.TotalCount,
+			TotalPage:  resp.Data.TotalPage,
+		}, nil
+	})
 }
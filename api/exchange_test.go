@@ -43,7 +43,7 @@ func TestGetCurrentExchangeRate(t *testing.T) {
 	t.Logf("Result: %s", resp.Result.Result)
 	t.Logf("Buy Currency: %s", resp.Data.BuyCurrency)
 	t.Logf("Sell Currency: %s", resp.Data.SellCurrency)
-	t.Logf("Rate: %.6f", resp.Data.Rate)
+	t.Logf("Rate: %s", resp.Data.Rate)
 	t.Logf("Update Time: %s", resp.Data.UpdateTime)
 	
 	// 验证基本响应
@@ -59,8 +59,8 @@ func TestGetCurrentExchangeRate(t *testing.T) {
 		t.Errorf("Expected sell currency 'USD', got '%s'", resp.Data.SellCurrency)
 	}
 	
-	if resp.Data.Rate <= 0 {
-		t.Errorf("Expected positive rate, got %.6f", resp.Data.Rate)
+	if sign, err := resp.Data.Rate.Sign(); err != nil || sign <= 0 {
+		t.Errorf("Expected positive rate, got %s", resp.Data.Rate)
 	}
 }
 
@@ -96,7 +96,7 @@ func TestGetCurrentExchangeRateCNY(t *testing.T) {
 	}
 	
 	t.Logf("Get exchange rate (CNY) success!")
-	t.Logf("1 %s = %.6f %s", resp.Data.SellCurrency, resp.Data.Rate, resp.Data.BuyCurrency)
+	t.Logf("1 %s = %s %s", resp.Data.SellCurrency, resp.Data.Rate, resp.Data.BuyCurrency)
 	t.Logf("Update Time: %s", resp.Data.UpdateTime)
 }
 
@@ -132,7 +132,7 @@ func TestGetCurrentExchangeRateReverse(t *testing.T) {
 	}
 	
 	t.Logf("Get exchange rate (reverse) success!")
-	t.Logf("1 %s = %.6f %s", resp.Data.SellCurrency, resp.Data.Rate, resp.Data.BuyCurrency)
+	t.Logf("1 %s = %s %s", resp.Data.SellCurrency, resp.Data.Rate, resp.Data.BuyCurrency)
 }
 
 // TestGetCurrentExchangeRateInvalidCurrency 测试查询无效货币对
@@ -183,9 +183,9 @@ func TestGetCurrentExchangeRateSameCurrency(t *testing.T) {
 	if err != nil {
 		t.Logf("Got error for same currency: %v", err)
 	} else if resp != nil {
-		t.Logf("Same currency rate: %.6f (should be 1.0)", resp.Data.Rate)
-		if resp.Data.Rate != 1.0 {
-			t.Logf("Warning: Expected rate 1.0 for same currency, got %.6f", resp.Data.Rate)
+		t.Logf("Same currency rate: %s (should be 1.0)", resp.Data.Rate)
+		if !resp.Data.Rate.Equal(DecimalFromInt(1)) {
+			t.Logf("Warning: Expected rate 1.0 for same currency, got %s", resp.Data.Rate)
 		}
 	}
 }
@@ -204,10 +204,10 @@ func TestRequestQuote(t *testing.T) {
 	req := &ExchangeQuoteRequest{
 		BuyCurrency:  "HKD",
 		SellCurrency: "USD",
-		SellAmount:   100.00, // 卖出100 USD
+		SellAmount:   MustParseDecimal("100.00"), // 卖出100 USD
 	}
 	
-	t.Logf("Requesting exchange quote: Sell %s %.2f to buy %s", 
+	t.Logf("Requesting exchange quote: Sell %s %s to buy %s", 
 		req.SellCurrency, req.SellAmount, req.BuyCurrency)
 	
 	resp, err := client.Exchange.RequestQuote(req)
@@ -228,10 +228,10 @@ func TestRequestQuote(t *testing.T) {
 	t.Logf("Request exchange quote success!")
 	t.Logf("Result: %s", resp.Result.Result)
 	t.Logf("Quote ID: %s", resp.Data.QuoteID)
-	t.Logf("Buy: %s %.2f", resp.Data.Buy.Currency, resp.Data.Buy.Amount)
-	t.Logf("Sell: %s %.2f", resp.Data.Sell.Currency, resp.Data.Sell.Amount)
-	t.Logf("Surcharge: %s %.2f", resp.Data.Surcharge.Currency, resp.Data.Surcharge.Amount)
-	t.Logf("Total Cost: %s %.2f", resp.Data.TotalCost.Currency, resp.Data.TotalCost.Amount)
+	t.Logf("Buy: %s %s", resp.Data.Buy.Currency, resp.Data.Buy.Amount)
+	t.Logf("Sell: %s %s", resp.Data.Sell.Currency, resp.Data.Sell.Amount)
+	t.Logf("Surcharge: %s %s", resp.Data.Surcharge.Currency, resp.Data.Surcharge.Amount)
+	t.Logf("Total Cost: %s %s", resp.Data.TotalCost.Currency, resp.Data.TotalCost.Amount)
 	t.Logf("Update Time: %s", resp.Data.UpdateTime)
 	t.Logf("Expire Time: %s", resp.Data.ExpireTime)
 	
@@ -267,10 +267,10 @@ func TestRequestQuoteWithBuyAmount(t *testing.T) {
 	req := &ExchangeQuoteRequest{
 		BuyCurrency:  "HKD",
 		SellCurrency: "USD",
-		BuyAmount:    1000.00, // 购入1000 HKD
+		BuyAmount:    MustParseDecimal("1000.00"), // 购入1000 HKD
 	}
 	
-	t.Logf("Requesting exchange quote: Buy %s %.2f with %s", 
+	t.Logf("Requesting exchange quote: Buy %s %s with %s", 
 		req.BuyCurrency, req.BuyAmount, req.SellCurrency)
 	
 	resp, err := client.Exchange.RequestQuote(req)
@@ -290,9 +290,9 @@ func TestRequestQuoteWithBuyAmount(t *testing.T) {
 	
 	t.Logf("Request exchange quote (buy amount) success!")
 	t.Logf("Quote ID: %s", resp.Data.QuoteID)
-	t.Logf("Will buy: %s %.2f", resp.Data.Buy.Currency, resp.Data.Buy.Amount)
-	t.Logf("Will sell: %s %.2f", resp.Data.Sell.Currency, resp.Data.Sell.Amount)
-	t.Logf("Total Cost: %s %.2f", resp.Data.TotalCost.Currency, resp.Data.TotalCost.Amount)
+	t.Logf("Will buy: %s %s", resp.Data.Buy.Currency, resp.Data.Buy.Amount)
+	t.Logf("Will sell: %s %s", resp.Data.Sell.Currency, resp.Data.Sell.Amount)
+	t.Logf("Total Cost: %s %s", resp.Data.TotalCost.Currency, resp.Data.TotalCost.Amount)
 }
 
 // TestRequestQuoteInvalidCurrency 测试无效币种的报价请求
@@ -309,7 +309,7 @@ func TestRequestQuoteInvalidCurrency(t *testing.T) {
 	req := &ExchangeQuoteRequest{
 		BuyCurrency:  "INVALID",
 		SellCurrency: "USD",
-		SellAmount:   100.00,
+		SellAmount:   MustParseDecimal("100.00"),
 	}
 	
 	resp, err := client.Exchange.RequestQuote(req)
@@ -393,10 +393,10 @@ func TestSubmitExchangeRequest(t *testing.T) {
 	t.Logf("Request ID: %s", resp.Data.RequestID)
 	t.Logf("Status: %s", resp.Data.Status)
 	t.Logf("Source: %s", resp.Data.Source)
-	t.Logf("Sell: %s %.2f", resp.Data.Sell.Currency, resp.Data.Sell.Amount)
-	t.Logf("Buy: %s %.2f", resp.Data.Buy.Currency, resp.Data.Buy.Amount)
-	t.Logf("Surcharge: %s %.2f", resp.Data.Surcharge.Currency, resp.Data.Surcharge.Amount)
-	t.Logf("Exchange Rate: %.6f", resp.Data.ExchangeRate)
+	t.Logf("Sell: %s %s", resp.Data.Sell.Currency, resp.Data.Sell.Amount)
+	t.Logf("Buy: %s %s", resp.Data.Buy.Currency, resp.Data.Buy.Amount)
+	t.Logf("Surcharge: %s %s", resp.Data.Surcharge.Currency, resp.Data.Surcharge.Amount)
+	t.Logf("Exchange Rate: %s", resp.Data.ExchangeRate)
 	t.Logf("Create Time: %s", resp.Data.CreateTime)
 	
 	// 验证基本响应
@@ -523,9 +523,9 @@ func TestGetExchangeOrders(t *testing.T) {
 		t.Logf("  Request ID: %s", order.RequestID)
 		t.Logf("  Status: %s", order.Status)
 		t.Logf("  Source: %s", order.Source)
-		t.Logf("  Sell: %s %.2f", order.Sell.Currency, order.Sell.Amount)
-		t.Logf("  Buy: %s %.2f", order.Buy.Currency, order.Buy.Amount)
-		t.Logf("  Exchange Rate: %.6f", order.ExchangeRate)
+		t.Logf("  Sell: %s %s", order.Sell.Currency, order.Sell.Amount)
+		t.Logf("  Buy: %s %s", order.Buy.Currency, order.Buy.Amount)
+		t.Logf("  Exchange Rate: %s", order.ExchangeRate)
 		t.Logf("  Create Time: %s", order.CreateTime)
 	}
 	
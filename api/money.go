@@ -0,0 +1,160 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrCurrencyMismatch 不同币种的金额不能直接运算或比较
+var ErrCurrencyMismatch = errors.New("api: currency mismatch")
+
+// currencyMinorUnits ISO-4217 现行币种的小数位数
+var currencyMinorUnits = map[string]int32{
+	// 0位小数
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	// 2位小数
+	"AED": 2, "AFN": 2, "ALL": 2, "AMD": 2, "ANG": 2, "AOA": 2, "ARS": 2, "AUD": 2, "AWG": 2,
+	"AZN": 2, "BAM": 2, "BBD": 2, "BDT": 2, "BGN": 2, "BMD": 2, "BND": 2, "BOB": 2, "BOV": 2,
+	"BRL": 2, "BSD": 2, "BTN": 2, "BWP": 2, "BYN": 2, "BZD": 2, "CAD": 2, "CDF": 2, "CHE": 2,
+	"CHF": 2, "CHW": 2, "CNY": 2, "COP": 2, "COU": 2, "CRC": 2, "CUC": 2, "CUP": 2, "CVE": 2,
+	"CZK": 2, "DKK": 2, "DOP": 2, "DZD": 2, "EGP": 2, "ERN": 2, "ETB": 2, "EUR": 2, "FJD": 2,
+	"FKP": 2, "GBP": 2, "GEL": 2, "GHS": 2, "GIP": 2, "GMD": 2, "GTQ": 2, "GYD": 2, "HKD": 2,
+	"HNL": 2, "HTG": 2, "HUF": 2, "IDR": 2, "ILS": 2, "INR": 2, "IRR": 2, "JMD": 2, "KES": 2,
+	"KGS": 2, "KHR": 2, "KPW": 2, "KYD": 2, "KZT": 2, "LAK": 2, "LBP": 2, "LKR": 2, "LRD": 2,
+	"LSL": 2, "MAD": 2, "MDL": 2, "MGA": 2, "MKD": 2, "MMK": 2, "MNT": 2, "MOP": 2, "MRU": 2,
+	"MUR": 2, "MVR": 2, "MWK": 2, "MXN": 2, "MXV": 2, "MYR": 2, "MZN": 2, "NAD": 2, "NGN": 2,
+	"NIO": 2, "NOK": 2, "NPR": 2, "NZD": 2, "PAB": 2, "PEN": 2, "PGK": 2, "PHP": 2, "PKR": 2,
+	"PLN": 2, "QAR": 2, "RON": 2, "RSD": 2, "RUB": 2, "SAR": 2, "SBD": 2, "SCR": 2, "SDG": 2,
+	"SEK": 2, "SGD": 2, "SHP": 2, "SLE": 2, "SLL": 2, "SOS": 2, "SRD": 2, "SSP": 2, "STN": 2,
+	"SVC": 2, "SYP": 2, "SZL": 2, "THB": 2, "TJS": 2, "TMT": 2, "TOP": 2, "TRY": 2, "TTD": 2,
+	"TWD": 2, "TZS": 2, "UAH": 2, "USD": 2, "USN": 2, "UYU": 2, "UZS": 2, "VED": 2, "VES": 2,
+	"WST": 2, "XCD": 2, "XCG": 2, "YER": 2, "ZAR": 2, "ZMW": 2, "ZWG": 2, "ZWL": 2,
+	// 3位小数
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	// 4位小数
+	"CLF": 4, "UYW": 4,
+}
+
+// CurrencyMinorUnits 返回 ISO-4217 币种的小数位数（如 JPY 为 0，USD 为 2，KWD 为 3）
+// 未收录在 ISO-4217 现行币种表中的代码（如 XXX 或拼写错误）返回 false
+func CurrencyMinorUnits(currency string) (int32, bool) {
+	units, ok := currencyMinorUnits[strings.ToUpper(currency)]
+	return units, ok
+}
+
+// Money 带币种的金额
+type Money struct {
+	Amount   Decimal `json:"amount"`   // 金额
+	Currency string  `json:"currency"` // 币种，ISO-4217货币代码
+}
+
+// NewMoney 创建金额
+func NewMoney(amount Decimal, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// MoneyFromMinorUnits 由最小货币单位创建，如 (150, "USD") 表示 1.50 USD，(150, "JPY") 表示 150 JPY
+func MoneyFromMinorUnits(units int64, currency string) (Money, error) {
+	scale, ok := CurrencyMinorUnits(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("api: unknown currency %q", currency)
+	}
+	return NewMoney(NewDecimal(units, scale), currency), nil
+}
+
+// MinorUnits 转换为最小货币单位，金额精度超过币种小数位数时返回错误
+func (m Money) MinorUnits() (int64, error) {
+	scale, ok := CurrencyMinorUnits(m.Currency)
+	if !ok {
+		return 0, fmt.Errorf("api: unknown currency %q", m.Currency)
+	}
+	unscaled, amountScale, err := parseDecimal(string(m.Amount))
+	if err != nil {
+		return 0, err
+	}
+	if amountScale > scale {
+		divisor := pow10(amountScale - scale)
+		quo, rem := new(big.Int).QuoRem(unscaled, divisor, new(big.Int))
+		if rem.Sign() != 0 {
+			return 0, fmt.Errorf("api: %s has more than %d decimal places", m, scale)
+		}
+		unscaled = quo
+	} else {
+		unscaled = rescale(unscaled, amountScale, scale)
+	}
+	if !unscaled.IsInt64() {
+		return 0, fmt.Errorf("api: %s overflows int64 minor units", m)
+	}
+	return unscaled.Int64(), nil
+}
+
+// Round 按币种的小数位数四舍五入，未知币种或非法金额原样返回
+func (m Money) Round() Money {
+	scale, ok := CurrencyMinorUnits(m.Currency)
+	if !ok {
+		return m
+	}
+	amount, err := m.Amount.Round(scale)
+	if err != nil {
+		return m
+	}
+	return Money{Amount: amount, Currency: m.Currency}
+}
+
+// Add 返回 m + other，币种不同时返回 ErrCurrencyMismatch
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	amount, err := m.Amount.Add(other.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// Sub 返回 m - other，币种不同时返回 ErrCurrencyMismatch
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	amount, err := m.Amount.Sub(other.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// Cmp 比较金额大小，币种不同时返回 ErrCurrencyMismatch
+func (m Money) Cmp(other Money) (int, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	return m.Amount.Cmp(other.Amount)
+}
+
+// IsZero 判断金额是否为零
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// String 返回形如 "1.50 USD" 的文本
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
+
+// sameCurrency 检查币种是否一致
+func (m Money) sameCurrency(other Money) error {
+	if !strings.EqualFold(m.Currency, other.Currency) {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return nil
+}
+
+// Money 转换为带币种的金额
+func (c CurrencyAmount) Money() Money {
+	return NewMoney(c.Amount, c.Currency)
+}
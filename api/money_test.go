@@ -0,0 +1,178 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// TestDecimalJSONRoundTrip 测试金额按JSON数字原样编解码
+func TestDecimalJSONRoundTrip(t *testing.T) {
+	input := `{"currency":"USD","amount":12345678901234567.10,"share_card_account_balance":"0.30","available":1e2}`
+	var data WalletBalanceData
+	if err := json.Unmarshal([]byte(input), &data); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if data.Amount != "12345678901234567.10" {
+		t.Errorf("Amount lost precision: %s", data.Amount)
+	}
+	if data.ShareCardAccountBalance != "0.30" || data.Available != "100" {
+		t.Errorf("Unexpected values: %s %s", data.ShareCardAccountBalance, data.Available)
+	}
+
+	out, err := json.Marshal(CurrencyAmount{Currency: "USD", Amount: data.Amount})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(out) != `{"currency":"USD","amount":12345678901234567.10}` {
+		t.Errorf("Unexpected JSON: %s", out)
+	}
+
+	if _, err := json.Marshal(CurrencyAmount{Amount: Decimal("1,000")}); err == nil {
+		t.Error("Expected error for invalid decimal")
+	}
+}
+
+// TestDecimalOmitEmpty 测试零值配合 omitempty 省略
+func TestDecimalOmitEmpty(t *testing.T) {
+	out, err := json.Marshal(ExchangeQuoteRequest{BuyCurrency: "CNY", SellCurrency: "USD", SellAmount: MustParseDecimal("0.1")})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(out) != `{"buy_currency":"CNY","sell_currency":"USD","sell_amount":0.1}` {
+		t.Errorf("Unexpected JSON: %s", out)
+	}
+}
+
+// TestDecimalArithmetic 测试加减、比较和舍入没有浮点误差
+func TestDecimalArithmetic(t *testing.T) {
+	sum := Decimal("")
+	for i := 0; i < 10; i++ {
+		var err error
+		if sum, err = sum.Add(MustParseDecimal("0.1")); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	if sum.String() != "1.0" || !sum.Equal(DecimalFromInt(1)) {
+		t.Errorf("0.1 * 10 = %s, want 1.0", sum)
+	}
+	if got, err := MustParseDecimal("1.005").Sub(MustParseDecimal("2")); err != nil || got.String() != "-0.995" {
+		t.Errorf("Sub = %s, %v", got, err)
+	}
+	if c, err := MustParseDecimal("1.5").Cmp(MustParseDecimal("1.50")); err != nil || c != 0 {
+		t.Error("1.5 should equal 1.50")
+	}
+	if c, err := MustParseDecimal("-2").Cmp(MustParseDecimal("1")); err != nil || c != -1 {
+		t.Error("-2 should be less than 1")
+	}
+
+	rounds := map[string]string{"1.005": "1.01", "-1.005": "-1.01", "1.004": "1.00", "2": "2.00", "0.0049": "0.00"}
+	for in, want := range rounds {
+		if got, err := MustParseDecimal(in).Round(2); err != nil || got.String() != want {
+			t.Errorf("Round(%s) = %s, %v, want %s", in, got, err, want)
+		}
+	}
+
+	if DecimalFromFloat(0.1) != "0.1" || NewDecimal(-5, 3) != "-0.005" {
+		t.Error("Unexpected constructor result")
+	}
+	for _, bad := range []string{"abc", "1.2.3", "-", ".", "1e", "1e99999"} {
+		if _, err := ParseDecimal(bad); err == nil {
+			t.Errorf("Expected error for %q", bad)
+		}
+	}
+}
+
+// TestDecimalInvalidConversion 测试直接转换得到的非法文本在运算时返回错误而不是 panic
+func TestDecimalInvalidConversion(t *testing.T) {
+	bad, one := Decimal("abc"), DecimalFromInt(1)
+	if _, err := bad.Add(one); !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("Add: expected ErrInvalidDecimal, got %v", err)
+	}
+	if _, err := one.Sub(bad); !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("Sub: expected ErrInvalidDecimal, got %v", err)
+	}
+	if _, err := bad.Cmp(one); !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("Cmp: expected ErrInvalidDecimal, got %v", err)
+	}
+	if _, err := bad.Sign(); !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("Sign: expected ErrInvalidDecimal, got %v", err)
+	}
+	if _, err := bad.Round(2); !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("Round: expected ErrInvalidDecimal, got %v", err)
+	}
+	if bad.Equal(bad) || bad.IsZero() {
+		t.Error("Invalid decimal should not equal anything or be zero")
+	}
+	if _, err := NewMoney(bad, "USD").MinorUnits(); !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("MinorUnits: expected ErrInvalidDecimal, got %v", err)
+	}
+	if _, err := NewMoney(one, "USD").Add(NewMoney(bad, "USD")); !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("Money.Add: expected ErrInvalidDecimal, got %v", err)
+	}
+
+	// == 比较文本，数值相等时应使用 Equal
+	if a, b := MustParseDecimal("1.5"), MustParseDecimal("1.50"); a == b || !a.Equal(b) {
+		t.Errorf("Expected %s and %s to differ as text but be Equal", a, b)
+	}
+}
+
+// TestMoneyMinorUnits 测试按 ISO-4217 小数位数换算最小货币单位
+func TestMoneyMinorUnits(t *testing.T) {
+	cases := []struct {
+		currency string
+		units    int64
+		amount   Decimal
+	}{
+		{"USD", 150, "1.50"},
+		{"JPY", 150, "150"},
+		{"KWD", 1500, "1.500"},
+		{"usd", -1, "-0.01"},
+	}
+	for _, tc := range cases {
+		m, err := MoneyFromMinorUnits(tc.units, tc.currency)
+		if err != nil {
+			t.Fatalf("MoneyFromMinorUnits failed: %v", err)
+		}
+		if m.Amount != tc.amount {
+			t.Errorf("%s %d: amount = %s, want %s", tc.currency, tc.units, m.Amount, tc.amount)
+		}
+		units, err := m.MinorUnits()
+		if err != nil || units != tc.units {
+			t.Errorf("%s: MinorUnits = %d, %v", m, units, err)
+		}
+	}
+
+	if _, err := NewMoney(MustParseDecimal("1.5"), "JPY").MinorUnits(); err == nil {
+		t.Error("Expected error for fractional JPY")
+	}
+	if got := NewMoney(MustParseDecimal("1.5"), "JPY").Round(); got.Amount != "2" {
+		t.Errorf("Round JPY = %s", got)
+	}
+	for _, code := range []string{"US", "XXX", "USX"} {
+		if _, ok := CurrencyMinorUnits(code); ok {
+			t.Errorf("Expected %s to be unknown", code)
+		}
+		if _, err := MoneyFromMinorUnits(1, code); err == nil {
+			t.Errorf("Expected error for currency code %s", code)
+		}
+	}
+	if got := NewMoney(MustParseDecimal("1.005"), "XXX").Round(); got.Amount != "1.005" {
+		t.Errorf("Round XXX = %s", got)
+	}
+}
+
+// TestMoneyCurrencyMismatch 测试不同币种不能相加或比较
+func TestMoneyCurrencyMismatch(t *testing.T) {
+	usd := NewMoney(MustParseDecimal("1.00"), "USD")
+	total, err := usd.Add(NewMoney(MustParseDecimal("2.50"), "usd"))
+	if err != nil || total.Amount != "3.50" {
+		t.Errorf("Add = %s, %v", total, err)
+	}
+	if _, err := usd.Sub(NewMoney(MustParseDecimal("1"), "JPY")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch, got %v", err)
+	}
+	if _, err := usd.Cmp(NewMoney(MustParseDecimal("1"), "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// NonceStore Webhook去重存储，用于拒绝被截获后重放的Webhook
+// 多实例部署时应使用共享存储（如Redis）实现
+type NonceStore interface {
+	// CheckAndStore 记录 key 直到 now+ttl；key 仍在有效期内时返回 false
+	CheckAndStore(key string, now time.Time, ttl time.Duration) (bool, error)
+	// Delete 删除 key，业务处理失败需要平台重新推送时调用
+	Delete(key string) error
+}
+
+// MemoryNonceStore 进程内的 NonceStore 实现
+type MemoryNonceStore struct {
+	mu        sync.Mutex
+	entries   map[string]time.Time
+	nextSweep time.Time
+}
+
+// NewMemoryNonceStore 创建进程内去重存储
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{entries: make(map[string]time.Time)}
+}
+
+// CheckAndStore 实现 NonceStore
+func (s *MemoryNonceStore) CheckAndStore(key string, now time.Time, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 定期清理过期记录
+	if now.After(s.nextSweep) {
+		for k, expiresAt := range s.entries {
+			if !now.Before(expiresAt) {
+				delete(s.entries, k)
+			}
+		}
+		s.nextSweep = now.Add(time.Minute)
+	}
+
+	if expiresAt, ok := s.entries[key]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	s.entries[key] = now.Add(ttl)
+	return true, nil
+}
+
+// Delete 实现 NonceStore
+func (s *MemoryNonceStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
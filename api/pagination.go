@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// DefaultPageLimit 遍历分页接口时每页默认的记录数
+const DefaultPageLimit = 20
+
+// Page 分页查询的一页结果
+type Page[T any] struct {
+	Items      []T // 本页记录
+	Page       int // 当前页码
+	Limit      int // 每页数量
+	TotalCount int // 总记录数
+	TotalPage  int // 总页数
+}
+
+// HasNext 是否还有下一页
+// 服务端未返回总页数时，以本页记录数是否达到每页数量判断
+func (p *Page[T]) HasNext() bool {
+	if len(p.Items) == 0 {
+		return false
+	}
+	if p.TotalPage > 0 {
+		return p.Page < p.TotalPage
+	}
+	return p.Limit > 0 && len(p.Items) >= p.Limit
+}
+
+// PageFunc 查询指定页，page 从1开始
+type PageFunc[T any] func(ctx context.Context, page, limit int) (*Page[T], error)
+
+// Paginate 从 page 页开始按需逐页查询，依次产出每条记录
+//
+// 调用方 break 后不再请求后续页面；某一页查询失败或服务端返回的页码与请求不一致时产出一次错误后结束。
+// page 小于1时从第1页开始，limit 小于1时使用 DefaultPageLimit。
+func Paginate[T any](ctx context.Context, page, limit int, fetch PageFunc[T]) iter.Seq2[T, error] {
+	start := max(page, 1)
+	if limit < 1 {
+		limit = DefaultPageLimit
+	}
+	return func(yield func(T, error) bool) {
+		for page := start; ; {
+			var zero T
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			result, err := fetch(ctx, page, limit)
+			if err != nil {
+				yield(zero, fmt.Errorf("fetch page %d: %w", page, err))
+				return
+			}
+			// 服务端返回的页码与请求不一致时说明页码未生效，继续请求只会重复同一页
+			if result.Page > 0 && result.Page != page {
+				yield(zero, fmt.Errorf("fetch page %d: server returned page %d", page, result.Page))
+				return
+			}
+			result.Page = page
+			if result.Limit < 1 {
+				result.Limit = limit
+			}
+			for _, item := range result.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !result.HasNext() {
+				return
+			}
+			page = result.Page + 1
+		}
+	}
+}
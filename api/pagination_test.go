@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+	"github.com/difyz9/gsalary-sdk-go/gsalarytest"
+)
+
+// TestAllCardHolders 测试遍历所有页面，以及 break 后不再请求后续页面
+func TestAllCardHolders(t *testing.T) {
+	server := gsalarytest.NewServer(t)
+	gsalarytest.NewSimulator(server)
+	client := NewClient(server.Config())
+	for i := 1; i <= 5; i++ {
+		if _, err := client.CardHolder.AddCardHolder(&CardHolderRequest{
+			FirstName: "Holder", LastName: strconv.Itoa(i), Email: fmt.Sprintf("holder%d@example.com", i),
+		}); err != nil {
+			t.Fatalf("AddCardHolder failed: %v", err)
+		}
+	}
+
+	countRequests := func() int {
+		n := 0
+		for _, r := range server.Requests() {
+			if r.Method == "GET" && r.Path == "/v1/card_holders" {
+				n++
+			}
+		}
+		return n
+	}
+
+	var names []string
+	for holder, err := range client.CardHolder.AllCardHolders(context.Background(), &CardHolderListRequest{Limit: 2}) {
+		if err != nil {
+			t.Fatalf("AllCardHolders failed: %v", err)
+		}
+		names = append(names, holder.LastName)
+	}
+	if got := strings.Join(names, ","); got != "1,2,3,4,5" {
+		t.Errorf("Expected holders 1..5, got %s", got)
+	}
+	if n := countRequests(); n != 3 {
+		t.Errorf("Expected 3 page requests, got %d", n)
+	}
+
+	seen := 0
+	for _, err := range client.CardHolder.AllCardHolders(context.Background(), &CardHolderListRequest{Page: 2, Limit: 2}) {
+		if err != nil {
+			t.Fatalf("AllCardHolders failed: %v", err)
+		}
+		if seen++; seen == 1 {
+			break
+		}
+	}
+	if n := countRequests(); n != 4 {
+		t.Errorf("Expected break to stop fetching, got %d page requests", n)
+	}
+}
+
+// TestAllTransactionsPageError 测试某一页失败时产出错误并结束
+func TestAllTransactionsPageError(t *testing.T) {
+	server := gsalarytest.NewServer(t)
+	server.Handle("GET", "/v1/card_bill/card_transactions", func(r *gsalarytest.Request) *gsalarytest.Response {
+		if r.Query.Get("card_id") != "card-1" {
+			return gsalarytest.Error("INVALID_ARGUMENT", "card_id filter lost")
+		}
+		page, _ := strconv.Atoi(r.Query.Get("page"))
+		if page == 2 {
+			return gsalarytest.Error("FORBIDDEN", "no access")
+		}
+		return gsalarytest.OK(map[string]interface{}{
+			"transactions": []map[string]string{{"transaction_id": "tx-1"}, {"transaction_id": "tx-2"}},
+			"page":         page,
+			"limit":        2,
+			"total_count":  6,
+			"total_page":   3,
+		})
+	})
+	client := NewClient(server.Config())
+
+	var ids []string
+	var errs []error
+	for tx, err := range client.Card.AllTransactions(context.Background(), &CardTransactionsRequest{CardID: "card-1", Limit: 2}) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, tx.TransactionID)
+	}
+	if len(ids) != 2 || len(errs) != 1 {
+		t.Fatalf("Expected 2 transactions and 1 error, got %v %v", ids, errs)
+	}
+	if !errors.Is(errs[0], gsalary.ErrForbidden) || !strings.Contains(errs[0].Error(), "fetch page 2") {
+		t.Errorf("Expected page 2 ErrForbidden, got %v", errs[0])
+	}
+}
+
+// TestPaginateWithoutTotalPage 测试服务端未返回总页数时按本页记录数判断是否结束
+func TestPaginateWithoutTotalPage(t *testing.T) {
+	var pages []int
+	fetch := func(ctx context.Context, page, limit int) (*Page[int], error) {
+		pages = append(pages, page)
+		items := []int{page*10 + 1, page*10 + 2, page*10 + 3}
+		if page == 2 {
+			items = items[:1]
+		}
+		return &Page[int]{Items: items}, nil
+	}
+
+	var got []int
+	for item, err := range Paginate(context.Background(), 0, 3, fetch) {
+		if err != nil {
+			t.Fatalf("Paginate failed: %v", err)
+		}
+		got = append(got, item)
+	}
+	if fmt.Sprint(got) != "[11 12 13 21]" || fmt.Sprint(pages) != "[1 2]" {
+		t.Errorf("Unexpected items %v from pages %v", got, pages)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range Paginate(ctx, 1, 3, fetch) {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	}
+}
+
+// TestPaginateStalePage 测试服务端忽略页码、总是返回同一页时遍历会结束而不是重复请求
+func TestPaginateStalePage(t *testing.T) {
+	for _, echo := range []int{0, 1} {
+		var pages []int
+		fetch := func(ctx context.Context, page, limit int) (*Page[int], error) {
+			pages = append(pages, page)
+			if len(pages) > 10 {
+				t.Fatalf("Paginate did not stop, requested pages %v", pages)
+			}
+			return &Page[int]{Items: []int{1, 2}, Page: echo, Limit: 2, TotalPage: 5}, nil
+		}
+
+		var items []int
+		var errs []error
+		for item, err := range Paginate(context.Background(), 1, 2, fetch) {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			items = append(items, item)
+		}
+		for i := 1; i < len(pages); i++ {
+			if pages[i] <= pages[i-1] {
+				t.Errorf("echo %d: expected increasing page numbers, got %v", echo, pages)
+			}
+		}
+		switch echo {
+		case 0:
+			if fmt.Sprint(pages) != "[1 2 3 4 5]" || len(items) != 10 || len(errs) != 0 {
+				t.Errorf("echo 0: unexpected pages %v, %d items, errors %v", pages, len(items), errs)
+			}
+		case 1:
+			if fmt.Sprint(pages) != "[1 2]" || len(items) != 2 || len(errs) != 1 || !strings.Contains(errs[0].Error(), "server returned page 1") {
+				t.Errorf("echo 1: unexpected pages %v, %d items, errors %v", pages, len(items), errs)
+			}
+		}
+	}
+}
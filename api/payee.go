@@ -1,8 +1,9 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 	
 	gsalary "github.com/difyz9/gsalary-sdk-go"
 )
@@ -19,55 +20,40 @@ func NewPayeeAPI(client *gsalary.GSalaryClient) *PayeeAPI {
 
 // AddPayee 新增收款人
 func (api *PayeeAPI) AddPayee(req *PayeeRequest) (*PayeeResponse, error) {
+	return api.AddPayeeCtx(context.Background(), req)
+}
+
+// AddPayeeCtx 新增收款人（支持context）
+func (api *PayeeAPI) AddPayeeCtx(ctx context.Context, req *PayeeRequest) (*PayeeResponse, error) {
 	// 创建POST请求
 	request := gsalary.NewRequest("POST", "/remittance/payees")
 	
 	// 设置请求体
-	request.Body = map[string]interface{}{
-		"subject_type": req.SubjectType,
-		"account_type": req.AccountType,
-		"country":      req.Country,
-		"currency":     req.Currency,
-	}
-	
-	if req.FirstName != "" {
-		request.Body["first_name"] = req.FirstName
-	}
-	if req.LastName != "" {
-		request.Body["last_name"] = req.LastName
-	}
-	if req.AccountHolder != "" {
-		request.Body["account_holder"] = req.AccountHolder
+	if err := request.SetJSONBody(req); err != nil {
+		return nil, fmt.Errorf("add payee failed: %w", err)
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	payeeResp, err := gsalary.DoRequest[PayeeResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("add payee failed: %w", err)
 	}
 	
-	// 解析响应
-	var payeeResp PayeeResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &payeeResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if payeeResp.Result.Result != "S" {
-		return &payeeResp, fmt.Errorf("add payee business error: [%s] %s",
-			payeeResp.Result.Code, payeeResp.Result.Message)
+		return payeeResp, bizError("add payee", request, payeeResp.Result)
 	}
 	
-	return &payeeResp, nil
+	return payeeResp, nil
 }
 
 // GetPayeeList 查询收款人列表
 func (api *PayeeAPI) GetPayeeList(req *PayeeListRequest) (*PayeeListResponse, error) {
+	return api.GetPayeeListCtx(context.Background(), req)
+}
+
+// GetPayeeListCtx 查询收款人列表（支持context）
+func (api *PayeeAPI) GetPayeeListCtx(ctx context.Context, req *PayeeListRequest) (*PayeeListResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", "/remittance/payees")
 	
@@ -92,112 +78,94 @@ func (api *PayeeAPI) GetPayeeList(req *PayeeListRequest) (*PayeeListResponse, er
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	listResp, err := gsalary.DoRequest[PayeeListResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get payee list failed: %w", err)
 	}
 	
-	// 解析响应
-	var listResp PayeeListResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &listResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if listResp.Result.Result != "S" {
-		return &listResp, fmt.Errorf("get payee list business error: [%s] %s",
-			listResp.Result.Code, listResp.Result.Message)
+		return listResp, bizError("get payee list", request, listResp.Result)
 	}
 	
-	return &listResp, nil
+	return listResp, nil
+}
+
+// AllPayees 按需逐页遍历收款人，filter 的 Page 为起始页、Limit 为每页数量
+func (api *PayeeAPI) AllPayees(ctx context.Context, filter *PayeeListRequest) iter.Seq2[Payee, error] {
+	var req PayeeListRequest
+	if filter != nil {
+		req = *filter
+	}
+	return Paginate(ctx, req.Page, req.Limit, func(ctx context.Context, page, limit int) (*Page[Payee], error) {
+		pageReq := req
+		pageReq.Page, pageReq.Limit = page, limit
+		resp, err := api.GetPayeeListCtx(ctx, &pageReq)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[Payee]{
+			Items:      resp.Data.Payees,
+			Page:       resp.Data.Page,
+			Limit:      resp.Data.Limit,
+			TotalCount: resp.Data.TotalCount,
+			TotalPage:  resp.Data.TotalPage,
+		}, nil
+	})
 }
 
 // UpdatePayee 更新收款人信息
 func (api *PayeeAPI) UpdatePayee(payeeID string, req *PayeeRequest) (*PayeeResponse, error) {
+	return api.UpdatePayeeCtx(context.Background(), payeeID, req)
+}
+
+// UpdatePayeeCtx 更新收款人信息（支持context）
+func (api *PayeeAPI) UpdatePayeeCtx(ctx context.Context, payeeID string, req *PayeeRequest) (*PayeeResponse, error) {
 	// 创建PUT请求
 	request := gsalary.NewRequest("PUT", fmt.Sprintf("/remittance/payees/%s", payeeID))
 	
-	// 设置请求体
-	request.Body = map[string]interface{}{
-		"account_type": req.AccountType,
-		"country":      req.Country,
-		"currency":     req.Currency,
-	}
+	// 更新时不能修改主体类型
+	body := *req
+	body.SubjectType = ""
 	
-	if req.FirstName != "" {
-		request.Body["first_name"] = req.FirstName
-	}
-	if req.LastName != "" {
-		request.Body["last_name"] = req.LastName
-	}
-	if req.AccountHolder != "" {
-		request.Body["account_holder"] = req.AccountHolder
+	// 设置请求体
+	if err := request.SetJSONBody(&body); err != nil {
+		return nil, fmt.Errorf("update payee failed: %w", err)
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	payeeResp, err := gsalary.DoRequest[PayeeResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("update payee failed: %w", err)
 	}
 	
-	// 解析响应
-	var payeeResp PayeeResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &payeeResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if payeeResp.Result.Result != "S" {
-		return &payeeResp, fmt.Errorf("update payee business error: [%s] %s",
-			payeeResp.Result.Code, payeeResp.Result.Message)
+		return payeeResp, bizError("update payee", request, payeeResp.Result)
 	}
 	
-	return &payeeResp, nil
+	return payeeResp, nil
 }
 
 // DeletePayee 停用收款人
 func (api *PayeeAPI) DeletePayee(payeeID string) error {
+	return api.DeletePayeeCtx(context.Background(), payeeID)
+}
+
+// DeletePayeeCtx 停用收款人（支持context）
+func (api *PayeeAPI) DeletePayeeCtx(ctx context.Context, payeeID string) error {
 	// 创建DELETE请求
 	request := gsalary.NewRequest("DELETE", fmt.Sprintf("/remittance/payees/%s", payeeID))
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	result, err := gsalary.DoRequest[BaseResponse](ctx, api.client, request)
 	if err != nil {
 		return fmt.Errorf("delete payee failed: %w", err)
 	}
 	
-	// 解析响应
-	var result struct {
-		Result struct {
-			Result  string `json:"result"`
-			Code    string `json:"code"`
-			Message string `json:"message"`
-		} `json:"result"`
-	}
-	
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &result); err != nil {
-		return fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果（S=成功，F=失败，U=未知需后续查询）
 	if result.Result.Result == "F" {
-		return fmt.Errorf("delete payee business error: [%s] %s",
-			result.Result.Code, result.Result.Message)
+		return bizError("delete payee", request, result.Result)
 	}
 	
 	return nil
@@ -205,43 +173,40 @@ func (api *PayeeAPI) DeletePayee(payeeID string) error {
 
 // AddPayeeAccount 新增收款人收款账户（电子钱包）
 func (api *PayeeAPI) AddPayeeAccount(payeeID string, req *PayeeAccountRequest) (*PayeeAccountResponse, error) {
+	return api.AddPayeeAccountCtx(context.Background(), payeeID, req)
+}
+
+// AddPayeeAccountCtx 新增收款人收款账户（电子钱包）（支持context）
+func (api *PayeeAPI) AddPayeeAccountCtx(ctx context.Context, payeeID string, req *PayeeAccountRequest) (*PayeeAccountResponse, error) {
 	// 创建POST请求
 	request := gsalary.NewRequest("POST", fmt.Sprintf("/remittance/payees/%s/accounts", payeeID))
 	
 	// 设置请求体
-	request.Body = map[string]interface{}{
-		"payment_method": req.PaymentMethod,
-		"account_no":     req.AccountNo,
+	if err := request.SetJSONBody(req); err != nil {
+		return nil, fmt.Errorf("add payee account failed: %w", err)
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	accountResp, err := gsalary.DoRequest[PayeeAccountResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("add payee account failed: %w", err)
 	}
 	
-	// 解析响应
-	var accountResp PayeeAccountResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &accountResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if accountResp.Result.Result != "S" {
-		return &accountResp, fmt.Errorf("add payee account business error: [%s] %s",
-			accountResp.Result.Code, accountResp.Result.Message)
+		return accountResp, bizError("add payee account", request, accountResp.Result)
 	}
 	
-	return &accountResp, nil
+	return accountResp, nil
 }
 
 // GetPayeeAccounts 查看收款人可用收款账户
 func (api *PayeeAPI) GetPayeeAccounts(payeeID string, language string) (*PayeeAccountsResponse, error) {
+	return api.GetPayeeAccountsCtx(context.Background(), payeeID, language)
+}
+
+// GetPayeeAccountsCtx 查看收款人可用收款账户（支持context）
+func (api *PayeeAPI) GetPayeeAccountsCtx(ctx context.Context, payeeID string, language string) (*PayeeAccountsResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", fmt.Sprintf("/remittance/payees/%s/accounts", payeeID))
 	
@@ -251,70 +216,55 @@ func (api *PayeeAPI) GetPayeeAccounts(payeeID string, language string) (*PayeeAc
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	accountsResp, err := gsalary.DoRequest[PayeeAccountsResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get payee accounts failed: %w", err)
 	}
 	
-	// 解析响应
-	var accountsResp PayeeAccountsResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &accountsResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if accountsResp.Result.Result != "S" {
-		return &accountsResp, fmt.Errorf("get payee accounts business error: [%s] %s",
-			accountsResp.Result.Code, accountsResp.Result.Message)
+		return accountsResp, bizError("get payee accounts", request, accountsResp.Result)
 	}
 	
-	return &accountsResp, nil
+	return accountsResp, nil
 }
 
 // UpdatePayeeAccount 更新收款人账户（电子钱包）
 func (api *PayeeAPI) UpdatePayeeAccount(payeeID, accountID string, req *PayeeAccountRequest) (*PayeeAccountResponse, error) {
+	return api.UpdatePayeeAccountCtx(context.Background(), payeeID, accountID, req)
+}
+
+// UpdatePayeeAccountCtx 更新收款人账户（电子钱包）（支持context）
+func (api *PayeeAPI) UpdatePayeeAccountCtx(ctx context.Context, payeeID, accountID string, req *PayeeAccountRequest) (*PayeeAccountResponse, error) {
 	// 创建PUT请求
 	request := gsalary.NewRequest("PUT", fmt.Sprintf("/remittance/payees/%s/payee_accounts/%s", payeeID, accountID))
 	
 	// 设置请求体
-	request.Body = map[string]interface{}{
-		"payment_method": req.PaymentMethod,
-		"account_no":     req.AccountNo,
+	if err := request.SetJSONBody(req); err != nil {
+		return nil, fmt.Errorf("update payee account failed: %w", err)
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	accountResp, err := gsalary.DoRequest[PayeeAccountResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("update payee account failed: %w", err)
 	}
 	
-	// 解析响应
-	var accountResp PayeeAccountResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &accountResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if accountResp.Result.Result != "S" {
-		return &accountResp, fmt.Errorf("update payee account business error: [%s] %s",
-			accountResp.Result.Code, accountResp.Result.Message)
+		return accountResp, bizError("update payee account", request, accountResp.Result)
 	}
 	
-	return &accountResp, nil
+	return accountResp, nil
 }
 
 // GetPayeeAccountForm 获取收款人账户表单（银行账户）
 func (api *PayeeAPI) GetPayeeAccountForm(payeeID string, req *PayeeAccountFormRequest) (*PayeeAccountFormResponse, error) {
+	return api.GetPayeeAccountFormCtx(context.Background(), payeeID, req)
+}
+
+// GetPayeeAccountFormCtx 获取收款人账户表单（银行账户）（支持context）
+func (api *PayeeAPI) GetPayeeAccountFormCtx(ctx context.Context, payeeID string, req *PayeeAccountFormRequest) (*PayeeAccountFormResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", fmt.Sprintf("/remittance/payees/%s/account_register_format", payeeID))
 	
@@ -328,145 +278,100 @@ func (api *PayeeAPI) GetPayeeAccountForm(payeeID string, req *PayeeAccountFormRe
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	formResp, err := gsalary.DoRequest[PayeeAccountFormResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get payee account form failed: %w", err)
 	}
 	
-	// 解析响应
-	var formResp PayeeAccountFormResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &formResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if formResp.Result.Result != "S" {
-		return &formResp, fmt.Errorf("get payee account form business error: [%s] %s",
-			formResp.Result.Code, formResp.Result.Message)
+		return formResp, bizError("get payee account form", request, formResp.Result)
 	}
 	
-	return &formResp, nil
+	return formResp, nil
 }
 
 // AddPayeeAccountBank 新增收款人收款账户（银行账户）
 func (api *PayeeAPI) AddPayeeAccountBank(payeeID string, req *PayeeAccountBankRequest) (*PayeeAccountResponse, error) {
+	return api.AddPayeeAccountBankCtx(context.Background(), payeeID, req)
+}
+
+// AddPayeeAccountBankCtx 新增收款人收款账户（银行账户）（支持context）
+func (api *PayeeAPI) AddPayeeAccountBankCtx(ctx context.Context, payeeID string, req *PayeeAccountBankRequest) (*PayeeAccountResponse, error) {
 	// 创建POST请求
 	request := gsalary.NewRequest("POST", fmt.Sprintf("/remittance/payees/%s/account_registry", payeeID))
 	
 	// 设置请求体
-	request.Body = map[string]interface{}{
-		"payment_method": req.PaymentMethod,
-		"fields":         req.Fields,
-	}
-	
-	if req.Currency != "" {
-		request.Body["currency"] = req.Currency
+	if err := request.SetJSONBody(req); err != nil {
+		return nil, fmt.Errorf("add payee account bank failed: %w", err)
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	accountResp, err := gsalary.DoRequest[PayeeAccountResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("add payee account bank failed: %w", err)
 	}
 	
-	// 解析响应
-	var accountResp PayeeAccountResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &accountResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if accountResp.Result.Result != "S" {
-		return &accountResp, fmt.Errorf("add payee account bank business error: [%s] %s",
-			accountResp.Result.Code, accountResp.Result.Message)
+		return accountResp, bizError("add payee account bank", request, accountResp.Result)
 	}
 	
-	return &accountResp, nil
+	return accountResp, nil
 }
 
 // UpdatePayeeAccountBank 更新收款人账户（银行账户）
 func (api *PayeeAPI) UpdatePayeeAccountBank(accountID string, req *PayeeAccountBankRequest) (*PayeeAccountResponse, error) {
+	return api.UpdatePayeeAccountBankCtx(context.Background(), accountID, req)
+}
+
+// UpdatePayeeAccountBankCtx 更新收款人账户（银行账户）（支持context）
+func (api *PayeeAPI) UpdatePayeeAccountBankCtx(ctx context.Context, accountID string, req *PayeeAccountBankRequest) (*PayeeAccountResponse, error) {
 	// 创建PUT请求
 	request := gsalary.NewRequest("PUT", fmt.Sprintf("/remittance/payee_accounts/%s", accountID))
 	
-	// 设置请求体
-	request.Body = map[string]interface{}{
-		"fields": req.Fields,
-	}
+	// 更新时不能修改付款方式
+	body := *req
+	body.PaymentMethod = ""
 	
-	if req.Currency != "" {
-		request.Body["currency"] = req.Currency
+	// 设置请求体
+	if err := request.SetJSONBody(&body); err != nil {
+		return nil, fmt.Errorf("update payee account bank failed: %w", err)
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	accountResp, err := gsalary.DoRequest[PayeeAccountResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("update payee account bank failed: %w", err)
 	}
 	
-	// 解析响应
-	var accountResp PayeeAccountResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &accountResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if accountResp.Result.Result != "S" {
-		return &accountResp, fmt.Errorf("update payee account bank business error: [%s] %s",
-			accountResp.Result.Code, accountResp.Result.Message)
+		return accountResp, bizError("update payee account bank", request, accountResp.Result)
 	}
 	
-	return &accountResp, nil
+	return accountResp, nil
 }
 
 // DeletePayeeAccount 移除收款账户
 func (api *PayeeAPI) DeletePayeeAccount(accountID string) error {
+	return api.DeletePayeeAccountCtx(context.Background(), accountID)
+}
+
+// DeletePayeeAccountCtx 移除收款账户（支持context）
+func (api *PayeeAPI) DeletePayeeAccountCtx(ctx context.Context, accountID string) error {
 	// 创建DELETE请求
 	request := gsalary.NewRequest("DELETE", fmt.Sprintf("/remittance/payee_accounts/%s", accountID))
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	result, err := gsalary.DoRequest[BaseResponse](ctx, api.client, request)
 	if err != nil {
 		return fmt.Errorf("delete payee account failed: %w", err)
 	}
 	
-	// 解析响应
-	var result struct {
-		Result struct {
-			Result  string `json:"result"`
-			Code    string `json:"code"`
-			Message string `json:"message"`
-		} `json:"result"`
-	}
-	
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &result); err != nil {
-		return fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果（S=成功，F=失败，U=未知需后续查询）
 	if result.Result.Result == "F" {
-		return fmt.Errorf("delete payee account business error: [%s] %s",
-			result.Result.Code, result.Result.Message)
+		return bizError("delete payee account", request, result.Result)
 	}
 	
 	return nil
@@ -474,37 +379,35 @@ func (api *PayeeAPI) DeletePayeeAccount(accountID string) error {
 
 // GetAvailablePaymentMethods 查询可用付款方式
 func (api *PayeeAPI) GetAvailablePaymentMethods() (*PaymentMethodsResponse, error) {
+	return api.GetAvailablePaymentMethodsCtx(context.Background())
+}
+
+// GetAvailablePaymentMethodsCtx 查询可用付款方式（支持context）
+func (api *PayeeAPI) GetAvailablePaymentMethodsCtx(ctx context.Context) (*PaymentMethodsResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", "/remittance/available_payment_methods")
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	methodsResp, err := gsalary.DoRequest[PaymentMethodsResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get available payment methods failed: %w", err)
 	}
 	
-	// 解析响应
-	var methodsResp PaymentMethodsResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &methodsResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if methodsResp.Result.Result != "S" {
-		return &methodsResp, fmt.Errorf("get available payment methods business error: [%s] %s",
-			methodsResp.Result.Code, methodsResp.Result.Message)
+		return methodsResp, bizError("get available payment methods", request, methodsResp.Result)
 	}
 	
-	return &methodsResp, nil
+	return methodsResp, nil
 }
 
 // GetPayoutCurrencies 查询支持付款国家和币种列表
 func (api *PayeeAPI) GetPayoutCurrencies(req *PayoutCurrenciesRequest) (*PayoutCurrenciesResponse, error) {
+	return api.GetPayoutCurrenciesCtx(context.Background(), req)
+}
+
+// GetPayoutCurrenciesCtx 查询支持付款国家和币种列表（支持context）
+func (api *PayeeAPI) GetPayoutCurrenciesCtx(ctx context.Context, req *PayoutCurrenciesRequest) (*PayoutCurrenciesResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", "/remittance/payout_currencies")
 	
@@ -512,27 +415,15 @@ func (api *PayeeAPI) GetPayoutCurrencies(req *PayoutCurrenciesRequest) (*PayoutC
 	request.QueryArgs["payment_method"] = req.PaymentMethod
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	currenciesResp, err := gsalary.DoRequest[PayoutCurrenciesResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get payout currencies failed: %w", err)
 	}
 	
-	// 解析响应
-	var currenciesResp PayoutCurrenciesResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &currenciesResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if currenciesResp.Result.Result != "S" {
-		return &currenciesResp, fmt.Errorf("get payout currencies business error: [%s] %s",
-			currenciesResp.Result.Code, currenciesResp.Result.Message)
+		return currenciesResp, bizError("get payout currencies", request, currenciesResp.Result)
 	}
 	
-	return &currenciesResp, nil
+	return currenciesResp, nil
 }
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"bytes"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+	"github.com/difyz9/gsalary-sdk-go/cassette"
+	"github.com/difyz9/gsalary-sdk-go/gsalarytest"
+)
+
+// TestGetPayeeListLogRedaction 测试收款人列表查询参数中的手机号和邮箱在 Debug 日志中已脱敏
+func TestGetPayeeListLogRedaction(t *testing.T) {
+	server := gsalarytest.NewServer(t)
+	server.Handle("GET", "/remittance/payees", func(r *gsalarytest.Request) *gsalarytest.Response {
+		if r.Query.Get("mobile") != "13812345678" {
+			return gsalarytest.Error("INVALID_ARGUMENT", "mobile filter lost")
+		}
+		return gsalarytest.OK(map[string]interface{}{"payees": []interface{}{}, "page": 1, "limit": 20})
+	})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(server.Config(), gsalary.WithLogger(logger))
+	if _, err := client.Payee.GetPayeeList(&PayeeListRequest{Page: 1, Limit: 20, Name: "bob@example.com", Mobile: "13812345678"}); err != nil {
+		t.Fatalf("GetPayeeList failed: %v", err)
+	}
+
+	text := buf.String()
+	for _, secret := range []string{"13812345678", "bob@example.com"} {
+		if strings.Contains(text, secret) {
+			t.Errorf("Log contains %q:\n%s", secret, text)
+		}
+	}
+	if !strings.Contains(text, "mobile=****5678") {
+		t.Errorf("Expected masked mobile in log:\n%s", text)
+	}
+}
+
+// TestGetPayeeListRecordAndReplay 测试通过 Recorder 录制、保存后回放收款人列表，查询参数中的手机号脱敏后仍能匹配
+func TestGetPayeeListRecordAndReplay(t *testing.T) {
+	server := gsalarytest.NewServer(t)
+	server.Handle("GET", "/remittance/payees", func(r *gsalarytest.Request) *gsalarytest.Response {
+		return gsalarytest.OK(map[string]interface{}{"payees": []interface{}{}, "page": 1, "limit": 20, "total_count": 7})
+	})
+	request := &PayeeListRequest{Page: 1, Limit: 20, Mobile: "13812345678"}
+
+	recorder := cassette.NewRecorder(nil)
+	if _, err := NewClient(server.Config(), gsalary.WithTransport(recorder)).Payee.GetPayeeList(request); err != nil {
+		t.Fatalf("GetPayeeList failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "payees.json")
+	if err := recorder.Cassette().Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	recorded, err := cassette.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(recorded.Interactions) != 1 || recorded.Interactions[0].Request.Path != "/remittance/payees?limit=20&mobile=****5678&page=1" {
+		t.Fatalf("Unexpected recorded requests %+v", recorded.Interactions)
+	}
+
+	config := server.Config()
+	server.Close()
+	replay := NewClient(config, gsalary.WithTransport(cassette.NewReplayer(recorded, cassette.WithResigner(server.ServerSigner()))))
+	resp, err := replay.Payee.GetPayeeList(request)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if resp.Data.TotalCount != 7 {
+		t.Errorf("Expected the recorded total_count, got %d", resp.Data.TotalCount)
+	}
+}
@@ -20,7 +20,7 @@ func TestPaymentConsult(t *testing.T) {
 	req := &PaymentConsultRequest{
 		MchAppID:                    "test_mch_app_id",
 		PaymentCurrency:             "USD",
-		PaymentAmount:               10.00,
+		PaymentAmount:               MustParseDecimal("10.00"),
 		SettlementCurrency:          "USD",
 		AllowedPaymentMethodRegions: []string{},
 		AllowedPaymentMethods:       []string{},
@@ -30,7 +30,7 @@ func TestPaymentConsult(t *testing.T) {
 		EnvClientIP:                 "192.168.1.1",
 	}
 	
-	t.Logf("Payment consult: Currency=%s, Amount=%.2f", req.PaymentCurrency, req.PaymentAmount)
+	t.Logf("Payment consult: Currency=%s, Amount=%s", req.PaymentCurrency, req.PaymentAmount)
 	
 	resp, err := client.Payment.PaymentConsult(req)
 	if err != nil {
@@ -55,7 +55,7 @@ func TestPaymentConsult(t *testing.T) {
 		t.Logf("Payment option %d:", i+1)
 		t.Logf("  Type: %s", option.PaymentMethodType)
 		t.Logf("  Currency: %s", option.Currency)
-		t.Logf("  Min: %.2f, Max: %.2f", option.Limit.Min, option.Limit.Max)
+		t.Logf("  Min: %s, Max: %s", option.Limit.Min, option.Limit.Max)
 		t.Logf("  Country: %s", option.Country)
 	}
 }
@@ -75,14 +75,14 @@ func TestCreatePaymentSession(t *testing.T) {
 		MchAppID:          "test_mch_app_id",
 		PaymentRequestID:  fmt.Sprintf("PAY_%d", time.Now().Unix()),
 		PaymentCurrency:   "USD",
-		PaymentAmount:     10.00,
+		PaymentAmount:     MustParseDecimal("10.00"),
 		PaymentMethodType: "ALIPAY_CN",
 		PaymentRedirectURL: "https://merchant.com/callback",
 		Order: OrderInfo{
 			ReferenceOrderID:  fmt.Sprintf("ORD_%d", time.Now().Unix()),
 			OrderDescription:  "Test order",
 			OrderCurrency:     "USD",
-			OrderAmount:       10.00,
+			OrderAmount:       MustParseDecimal("10.00"),
 			OrderBuyerID:      "BUYER_123",
 			OrderBuyerEmail:   "buyer@example.com",
 		},
@@ -91,7 +91,7 @@ func TestCreatePaymentSession(t *testing.T) {
 		ProductScene:       "CHECKOUT_PAYMENT",
 	}
 	
-	t.Logf("Creating payment session: RequestID=%s, Amount=%.2f %s",
+	t.Logf("Creating payment session: RequestID=%s, Amount=%s %s",
 		req.PaymentRequestID, req.PaymentAmount, req.PaymentCurrency)
 	
 	resp, err := client.Payment.CreatePaymentSession(req)
@@ -136,14 +136,14 @@ func TestCreateEasySafePaySession(t *testing.T) {
 			MchAppID:          "test_mch_app_id",
 			PaymentRequestID:  fmt.Sprintf("PAY_%d", time.Now().Unix()),
 			PaymentCurrency:   "USD",
-			PaymentAmount:     10.00,
+			PaymentAmount:     MustParseDecimal("10.00"),
 			PaymentMethodType: "ALIPAY_CN",
 			PaymentRedirectURL: "https://merchant.com/callback",
 			Order: OrderInfo{
 				ReferenceOrderID:  fmt.Sprintf("ORD_%d", time.Now().Unix()),
 				OrderDescription:  "Test order for easy safe pay",
 				OrderCurrency:     "USD",
-				OrderAmount:       10.00,
+				OrderAmount:       MustParseDecimal("10.00"),
 			},
 			SettlementCurrency: "USD",
 			EnvClientIP:        "192.168.1.1",
@@ -191,7 +191,7 @@ func TestEasySafePayPay(t *testing.T) {
 		MchAppID:          "test_mch_app_id",
 		PaymentRequestID:  fmt.Sprintf("PAY_%d", time.Now().Unix()),
 		PaymentCurrency:   "USD",
-		PaymentAmount:     10.00,
+		PaymentAmount:     MustParseDecimal("10.00"),
 		PaymentMethodID:   "test_access_token_123456", // 从授权通知中获取
 		PaymentMethodType: "ALIPAY_CN",
 		PaymentRedirectURL: "https://merchant.com/callback",
@@ -199,7 +199,7 @@ func TestEasySafePayPay(t *testing.T) {
 			ReferenceOrderID:  fmt.Sprintf("ORD_%d", time.Now().Unix()),
 			OrderDescription:  "Test order for second payment",
 			OrderCurrency:     "USD",
-			OrderAmount:       10.00,
+			OrderAmount:       MustParseDecimal("10.00"),
 		},
 		SettlementCurrency: "USD",
 		EnvClientIP:        "192.168.1.1",
@@ -245,14 +245,14 @@ func TestCreateCardAutoDebitSession(t *testing.T) {
 		MchAppID:          "test_mch_app_id",
 		PaymentRequestID:  fmt.Sprintf("PAY_%d", time.Now().Unix()),
 		PaymentCurrency:   "USD",
-		PaymentAmount:     10.00,
+		PaymentAmount:     MustParseDecimal("10.00"),
 		PaymentMethodType: "CARD",
 		PaymentRedirectURL: "https://merchant.com/callback",
 		Order: OrderInfo{
 			ReferenceOrderID:  fmt.Sprintf("ORD_%d", time.Now().Unix()),
 			OrderDescription:  "Test order for card auto debit",
 			OrderCurrency:     "USD",
-			OrderAmount:       10.00,
+			OrderAmount:       MustParseDecimal("10.00"),
 		},
 		SettlementCurrency: "USD",
 		EnvClientIP:        "192.168.1.1",
@@ -295,7 +295,7 @@ func TestCardAutoDebitPay(t *testing.T) {
 		MchAppID:          "test_mch_app_id",
 		PaymentRequestID:  fmt.Sprintf("PAY_%d", time.Now().Unix()),
 		PaymentCurrency:   "USD",
-		PaymentAmount:     10.00,
+		PaymentAmount:     MustParseDecimal("10.00"),
 		PaymentMethodID:   "test_card_token_123456", // 从支付结果通知中获取
 		PaymentMethodType: "CARD",
 		PaymentRedirectURL: "https://merchant.com/callback",
@@ -303,7 +303,7 @@ func TestCardAutoDebitPay(t *testing.T) {
 			ReferenceOrderID:  fmt.Sprintf("ORD_%d", time.Now().Unix()),
 			OrderDescription:  "Test order for card second payment",
 			OrderCurrency:     "USD",
-			OrderAmount:       10.00,
+			OrderAmount:       MustParseDecimal("10.00"),
 		},
 		SettlementCurrency: "USD",
 		EnvClientIP:        "192.168.1.1",
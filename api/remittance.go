@@ -1,8 +1,9 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 	
 	gsalary "github.com/difyz9/gsalary-sdk-go"
 )
@@ -19,147 +20,100 @@ func NewRemittanceAPI(client *gsalary.GSalaryClient) *RemittanceAPI {
 
 // GetClearingNetworks 查询可用清算网络
 func (api *RemittanceAPI) GetClearingNetworks(req *ClearingNetworkRequest) (*ClearingNetworkResponse, error) {
+	return api.GetClearingNetworksCtx(context.Background(), req)
+}
+
+// GetClearingNetworksCtx 查询可用清算网络（支持context）
+func (api *RemittanceAPI) GetClearingNetworksCtx(ctx context.Context, req *ClearingNetworkRequest) (*ClearingNetworkResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", "/remittance/clearing_networks")
 	
 	// 设置查询参数
 	request.QueryArgs["payee_account_id"] = req.PayeeAccountID
 	request.QueryArgs["pay_currency"] = req.PayCurrency
-	request.QueryArgs["amount"] = fmt.Sprintf("%f", req.Amount)
+	request.QueryArgs["amount"] = req.Amount.String()
 	request.QueryArgs["amount_type"] = req.AmountType
 	request.QueryArgs["receive_currency"] = req.ReceiveCurrency
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	networkResp, err := gsalary.DoRequest[ClearingNetworkResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get clearing networks failed: %w", err)
 	}
 	
-	// 解析响应
-	var networkResp ClearingNetworkResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &networkResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if networkResp.Result.Result != "S" {
-		return &networkResp, fmt.Errorf("get clearing networks business error: [%s] %s",
-			networkResp.Result.Code, networkResp.Result.Message)
+		return networkResp, bizError("get clearing networks", request, networkResp.Result)
 	}
 	
-	return &networkResp, nil
+	return networkResp, nil
 }
 
 // CreateQuote 申请锁汇
 func (api *RemittanceAPI) CreateQuote(req *QuoteRequest) (*QuoteResponse, error) {
+	return api.CreateQuoteCtx(context.Background(), req)
+}
+
+// CreateQuoteCtx 申请锁汇（支持context）
+func (api *RemittanceAPI) CreateQuoteCtx(ctx context.Context, req *QuoteRequest) (*QuoteResponse, error) {
 	// 创建POST请求
 	request := gsalary.NewRequest("POST", "/remittance/quotes")
 	
 	// 设置请求体
-	request.Body = map[string]interface{}{
-		"payee_account_id": req.PayeeAccountID,
-		"purpose":          req.Purpose,
-		"pay_currency":     req.PayCurrency,
-		"amount":           req.Amount,
-		"amount_type":      req.AmountType,
-	}
-	
-	// 可选字段
-	if req.PayerID != "" {
-		request.Body["payer_id"] = req.PayerID
-	}
-	if req.ReceiveCurrency != "" {
-		request.Body["receive_currency"] = req.ReceiveCurrency
-	}
-	if req.ClearingNetwork != "" {
-		request.Body["clearing_network"] = req.ClearingNetwork
-	}
-	if req.AbaNumber != "" {
-		request.Body["aba_number"] = req.AbaNumber
-	}
-	if req.FpsBankID != "" {
-		request.Body["fps_bank_id"] = req.FpsBankID
-	}
-	if req.IfsCode != "" {
-		request.Body["ifs_code"] = req.IfsCode
-	}
-	if req.IntermediarySwiftCode != "" {
-		request.Body["intermediary_swift_code"] = req.IntermediarySwiftCode
-	}
-	if req.Remark != "" {
-		request.Body["remark"] = req.Remark
+	if err := request.SetJSONBody(req); err != nil {
+		return nil, fmt.Errorf("create quote failed: %w", err)
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	quoteResp, err := gsalary.DoRequest[QuoteResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("create quote failed: %w", err)
 	}
 	
-	// 解析响应
-	var quoteResp QuoteResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &quoteResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if quoteResp.Result.Result != "S" {
-		return &quoteResp, fmt.Errorf("create quote business error: [%s] %s",
-			quoteResp.Result.Code, quoteResp.Result.Message)
+		return quoteResp, bizError("create quote", request, quoteResp.Result)
 	}
 	
-	return &quoteResp, nil
+	return quoteResp, nil
 }
 
 // SubmitOrder 提交付款订单
 func (api *RemittanceAPI) SubmitOrder(req *OrderRequest) (*OrderResponse, error) {
+	return api.SubmitOrderCtx(context.Background(), req)
+}
+
+// SubmitOrderCtx 提交付款订单（支持context）
+func (api *RemittanceAPI) SubmitOrderCtx(ctx context.Context, req *OrderRequest) (*OrderResponse, error) {
 	// 创建POST请求
 	request := gsalary.NewRequest("POST", "/remittance/orders")
 	
 	// 设置请求体
-	request.Body = map[string]interface{}{
-		"quote_id":        req.QuoteID,
-		"client_order_id": req.ClientOrderID,
+	if err := request.SetJSONBody(req); err != nil {
+		return nil, fmt.Errorf("submit order failed: %w", err)
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	orderResp, err := gsalary.DoRequest[OrderResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("submit order failed: %w", err)
 	}
 	
-	// 解析响应
-	var orderResp OrderResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &orderResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if orderResp.Result.Result != "S" {
-		return &orderResp, fmt.Errorf("submit order business error: [%s] %s",
-			orderResp.Result.Code, orderResp.Result.Message)
+		return orderResp, bizError("submit order", request, orderResp.Result)
 	}
 	
-	return &orderResp, nil
+	return orderResp, nil
 }
 
 // GetOrderList 查询付款单列表
 func (api *RemittanceAPI) GetOrderList(req *OrderListRequest) (*OrderListResponse, error) {
+	return api.GetOrderListCtx(context.Background(), req)
+}
+
+// GetOrderListCtx 查询付款单列表（支持context）
+func (api *RemittanceAPI) GetOrderListCtx(ctx context.Context, req *OrderListRequest) (*OrderListResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", "/remittance/orders")
 	
@@ -187,27 +141,38 @@ func (api *RemittanceAPI) GetOrderList(req *OrderListRequest) (*OrderListRespons
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	listResp, err := gsalary.DoRequest[OrderListResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get order list failed: %w", err)
 	}
 	
-	// 解析响应
-	var listResp OrderListResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &listResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if listResp.Result.Result != "S" {
-		return &listResp, fmt.Errorf("get order list business error: [%s] %s",
-			listResp.Result.Code, listResp.Result.Message)
+		return listResp, bizError("get order list", request, listResp.Result)
 	}
 	
-	return &listResp, nil
+	return listResp, nil
+}
+
+// AllOrders 按需逐页遍历付款单，filter 的 Page 为起始页、Limit 为每页数量
+func (api *RemittanceAPI) AllOrders(ctx context.Context, filter *OrderListRequest) iter.Seq2[RemittanceOrder, error] {
+	var req OrderListRequest
+	if filter != nil {
+		req = *filter
+	}
+	return Paginate(ctx, req.Page, req.Limit, func(ctx context.Context, page, limit int) (*Page[RemittanceOrder], error) {
+		pageReq := req
+		pageReq.Page, pageReq.Limit = page, limit
+		resp, err := api.GetOrderListCtx(ctx, &pageReq)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[RemittanceOrder]{
+			Items:      resp.Data.Orders,
+			Page:       resp.Data.Page,
+			Limit:      resp.Data.Limit,
+			TotalCount: resp.Data.TotalCount,
+			TotalPage:  resp.Data.TotalPage,
+		}, nil
+	})
 }
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+)
+
+// TestPathTemplateCoversAPI 测试 api 包中构造的每个请求路径都能映射到路由模板，而不是 OtherPath
+func TestPathTemplateCoversAPI(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("Glob failed: %v", err)
+	}
+	fset := token.NewFileSet()
+	paths := map[string]string{}
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatalf("ParseFile %s failed: %v", name, err)
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			selector, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			// NewRequest(method, path) 的路径在第2个参数，fmt.Sprintf 的格式串在第1个参数
+			arg := -1
+			switch selector.Sel.Name {
+			case "NewRequest":
+				arg = 1
+			case "Sprintf":
+				arg = 0
+			}
+			if arg < 0 || len(call.Args) <= arg {
+				return true
+			}
+			lit, ok := call.Args[arg].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			value, err := strconv.Unquote(lit.Value)
+			if err != nil || !strings.HasPrefix(value, "/") {
+				return true
+			}
+			paths[strings.ReplaceAll(value, "%s", "id-123")] = fset.Position(lit.Pos()).String()
+			return true
+		})
+	}
+
+	if len(paths) < 40 {
+		t.Fatalf("Expected to find the request paths in api/*.go, got %d", len(paths))
+	}
+	for path, pos := range paths {
+		if got := gsalary.PathTemplate(path); got == gsalary.OtherPath || strings.Contains(got, "id-123") {
+			t.Errorf("%s: PathTemplate(%q) = %q", pos, path, got)
+		}
+	}
+}
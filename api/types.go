@@ -6,10 +6,10 @@ type CardApplyRequest struct {
 	ProductCode          string  `json:"product_code"`            // 卡产品代码
 	Currency             string  `json:"currency"`                // 卡币种，ISO-4217货币代码
 	CardHolderID         string  `json:"card_holder_id"`          // 持卡人ID
-	LimitPerDay          float64 `json:"limit_per_day,omitempty"` // 每日交易限额
-	LimitPerMonth        float64 `json:"limit_per_month,omitempty"` // 每月交易限额
-	LimitPerTransaction  float64 `json:"limit_per_transaction,omitempty"` // 单笔交易限额
-	InitBalance          float64 `json:"init_balance"`            // 初始余额
+	LimitPerDay          Decimal `json:"limit_per_day,omitempty"` // 每日交易限额
+	LimitPerMonth        Decimal `json:"limit_per_month,omitempty"` // 每月交易限额
+	LimitPerTransaction  Decimal `json:"limit_per_transaction,omitempty"` // 单笔交易限额
+	InitBalance          Decimal `json:"init_balance"`            // 初始余额
 }
 
 // CardApplyResponse 申请卡片响应
@@ -51,7 +51,7 @@ type CardAvailableQuotasResponse struct {
 	Data struct {
 		Currency           string  `json:"currency"`             // 币种
 		AccountingCardType string  `json:"accounting_card_type"` // 卡账务类型
-		AvailableQuota     float64 `json:"available_quota"`      // 可用余额
+		AvailableQuota     Decimal `json:"available_quota"`      // 可用余额
 	} `json:"data"`
 }
 
@@ -140,7 +140,7 @@ type CardInfo struct {
 	CardName            string                 `json:"card_name"`             // 卡片名称
 	MaskCardNumber      string                 `json:"mask_card_number"`      // 掩码卡号，如 41******1111
 	CardCurrency        string                 `json:"card_currency"`         // 卡币种
-	AvailableBalance    float64                `json:"available_balance"`     // 可用余额
+	AvailableBalance    Decimal                `json:"available_balance"`     // 可用余额
 	BrandCode           string                 `json:"brand_code"`            // 卡品牌 VISA/MASTER
 	Status              string                 `json:"status"`                // 卡状态
 	CardType            string                 `json:"card_type"`             // 卡类型 PHYSICAL/VIRTUAL
@@ -151,9 +151,9 @@ type CardInfo struct {
 	LastName            string                 `json:"last_name"`             // 姓
 	Mobile              map[string]interface{} `json:"mobile"`                // 手机号
 	Email               string                 `json:"email"`                 // 邮箱
-	LimitPerDay         float64                `json:"limit_per_day"`         // 每日限额
-	LimitPerMonth       float64                `json:"limit_per_month"`       // 每月限额
-	LimitPerTransaction float64                `json:"limit_per_transaction"` // 单笔限额
+	LimitPerDay         Decimal                `json:"limit_per_day"`         // 每日限额
+	LimitPerMonth       Decimal                `json:"limit_per_month"`       // 每月限额
+	LimitPerTransaction Decimal                `json:"limit_per_transaction"` // 单笔限额
 	BillAddress         map[string]interface{} `json:"bill_address"`          // 账单地址
 	SupportTdsTrans     bool                   `json:"support_tds_trans"`     // 是否支持3DS交易
 	CreateTime          string                 `json:"create_time"`           // 创建时间
@@ -171,11 +171,11 @@ type CardInfoResponse struct {
 
 // UpdateCardRequest 修改卡信息请求
 type UpdateCardRequest struct {
-	CardID               string  `json:"card_id"`                          // 卡ID（path参数）
+	CardID               string  `json:"-"`                                // 卡ID（path参数）
 	CardName             string  `json:"card_name,omitempty"`              // 卡昵称
-	LimitPerDay          float64 `json:"limit_per_day,omitempty"`          // 每日限额
-	LimitPerMonth        float64 `json:"limit_per_month,omitempty"`        // 每月限额
-	LimitPerTransaction  float64 `json:"limit_per_transaction,omitempty"`  // 单笔交易限额
+	LimitPerDay          Decimal `json:"limit_per_day,omitempty"`          // 每日限额
+	LimitPerMonth        Decimal `json:"limit_per_month,omitempty"`        // 每月限额
+	LimitPerTransaction  Decimal `json:"limit_per_transaction,omitempty"`  // 单笔交易限额
 }
 
 // UpdateCardResponse 修改卡信息响应
@@ -218,7 +218,7 @@ type CardSecureInfoResponse struct {
 // AdjustCardBalanceRequest 卡片调额请求
 type AdjustCardBalanceRequest struct {
 	CardID    string  `json:"card_id"`    // 卡ID
-	Amount    float64 `json:"amount"`     // 修改金额（必须>=0）
+	Amount    Decimal `json:"amount"`     // 修改金额（必须>=0）
 	Type      string  `json:"type"`       // 修改类型：INCREASE（增加）、DECREASE（减少）
 	RequestID string  `json:"request_id"` // 唯一请求ID
 }
@@ -231,9 +231,9 @@ type BalanceModifyResult struct {
 	Status           string  `json:"status"`             // 状态：PENDING/SUCCESS/FAIL
 	CreateTime       string  `json:"create_time"`        // 创建时间
 	FinishTime       string  `json:"finish_time"`        // 完成时间
-	Amount           float64 `json:"amount"`             // 调额金额
+	Amount           Decimal `json:"amount"`             // 调额金额
 	Type             string  `json:"type"`               // 调额类型
-	PostBalance      float64 `json:"post_balance"`       // 调额后余额
+	PostBalance      Decimal `json:"post_balance"`       // 调额后余额
 }
 
 // AdjustCardBalanceResponse 卡片调额响应
@@ -258,8 +258,8 @@ type GetBalanceModifyResultResponse struct {
 
 // SetCardFreezeStatusRequest 冻结/解冻卡请求
 type SetCardFreezeStatusRequest struct {
-	CardID string `json:"card_id"` // 卡ID（path参数）
-	Freeze bool   `json:"freeze"`   // 冻结状态：true-冻结，false-解冻
+	CardID string `json:"-"`      // 卡ID（path参数）
+	Freeze bool   `json:"freeze"` // 冻结状态：true-冻结，false-解冻
 }
 
 // SetCardFreezeStatusResponse 冻结/解冻卡响应
@@ -287,7 +287,7 @@ type CardTransaction struct {
 	TransactionID         string  `json:"transaction_id"`          // 交易ID
 	CardID                string  `json:"card_id"`                 // 卡ID
 	TransactionType       string  `json:"transaction_type"`        // 交易类型
-	Amount                float64 `json:"amount"`                  // 交易金额
+	Amount                Decimal `json:"amount"`                  // 交易金额
 	Currency              string  `json:"currency"`                // 币种
 	Status                string  `json:"status"`                  // 交易状态
 	StatusDescription     string  `json:"status_description"`      // 状态描述
@@ -330,8 +330,8 @@ type BalanceHistoryRecord struct {
 	LogID           string  `json:"log_id"`           // 入账ID
 	CardID          string  `json:"card_id"`          // 卡ID
 	TransactionID   string  `json:"transaction_id"`   // 关联交易ID
-	Amount          float64 `json:"amount"`           // 变更金额
-	PostBalance     float64 `json:"post_balance"`     // 变更后余额
+	Amount          Decimal `json:"amount"`           // 变更金额
+	PostBalance     Decimal `json:"post_balance"`     // 变更后余额
 	TransactionType string  `json:"transaction_type"` // 交易类型
 	CreateTime      string  `json:"create_time"`      // 创建时间
 	Description     string  `json:"description"`      // 描述
@@ -356,7 +356,7 @@ type BalanceHistoryResponse struct {
 
 // UpdateCardContactRequest 修改卡联系信息请求
 type UpdateCardContactRequest struct {
-	CardID string `json:"card_id"`        // 卡ID（path参数）
+	CardID string `json:"-"`               // 卡ID（path参数）
 	Email  string `json:"email,omitempty"` // 持卡人email
 }
 
@@ -506,7 +506,7 @@ type ExchangeRateResponse struct {
 	Data struct {
 		BuyCurrency  string  `json:"buy_currency"`  // 购入币种
 		SellCurrency string  `json:"sell_currency"` // 卖出币种
-		Rate         float64 `json:"rate"`          // 汇率
+		Rate         Decimal `json:"rate"`          // 汇率
 		UpdateTime   string  `json:"update_time"`   // 更新时间
 	} `json:"data"`
 }
@@ -515,14 +515,14 @@ type ExchangeRateResponse struct {
 type ExchangeQuoteRequest struct {
 	BuyCurrency  string  `json:"buy_currency"`  // 购入币种，参考ISO-4217币种清单
 	SellCurrency string  `json:"sell_currency"` // 卖出币种，参考ISO-4217币种清单
-	BuyAmount    float64 `json:"buy_amount"`    // 购入金额。购入金额和卖出金额不可同时为空，如果同时提供将忽略购入金额
-	SellAmount   float64 `json:"sell_amount"`   // 卖出金额。购入金额和卖出金额不可同时为空，如果同时提供将忽略购入金额
+	BuyAmount    Decimal `json:"buy_amount,omitempty"`  // 购入金额。购入金额和卖出金额不可同时为空，如果同时提供将忽略购入金额
+	SellAmount   Decimal `json:"sell_amount,omitempty"` // 卖出金额。购入金额和卖出金额不可同时为空，如果同时提供将忽略购入金额
 }
 
 // CurrencyAmount 货币金额
 type CurrencyAmount struct {
 	Currency string  `json:"currency"` // 币种
-	Amount   float64 `json:"amount"`   // 金额
+	Amount   Decimal `json:"amount"`   // 金额
 }
 
 // ExchangeQuoteResponse 请求锁汇报价响应
@@ -559,7 +559,7 @@ type ExchangeOrder struct {
 	Sell         CurrencyAmount `json:"sell"`          // 卖出币种和金额
 	Buy          CurrencyAmount `json:"buy"`           // 买入币种和金额
 	Surcharge    CurrencyAmount `json:"surcharge"`     // 手续费
-	ExchangeRate float64        `json:"exchange_rate"` // 汇率
+	ExchangeRate Decimal        `json:"exchange_rate"` // 汇率
 }
 
 // ExchangeSubmitResponse 提交换汇订单响应
@@ -612,7 +612,7 @@ type ExchangeOrdersResponse struct {
 type PaymentConsultRequest struct {
 	MchAppID                      string   `json:"mch_app_id"`                         // 商户应用ID
 	PaymentCurrency               string   `json:"payment_currency"`                   // 支付币种（ISO 4217三位代码）
-	PaymentAmount                 float64  `json:"payment_amount"`                     // 支付金额（单位：元）
+	PaymentAmount                 Decimal  `json:"payment_amount"`                     // 支付金额（单位：元）
 	SettlementCurrency            string   `json:"settlement_currency"`                // 结算币种（ISO 4217三位代码）
 	AllowedPaymentMethodRegions   []string `json:"allowed_payment_method_regions"`     // 允许的支付方式所属国家/地区
 	AllowedPaymentMethods         []string `json:"allowed_payment_methods"`            // 允许的支付方式列表
@@ -627,8 +627,8 @@ type PaymentOption struct {
 	PaymentMethodType string `json:"payment_method_type"` // 支付方式类型
 	Currency          string `json:"currency"`            // 币种
 	Limit             struct {
-		Min float64 `json:"min"` // 最小金额
-		Max float64 `json:"max"` // 最大金额
+		Min Decimal `json:"min"` // 最小金额
+		Max Decimal `json:"max"` // 最大金额
 	} `json:"limit"` // 限额
 	Country string `json:"country"` // 国家/地区代码
 }
@@ -650,7 +650,7 @@ type OrderInfo struct {
 	ReferenceOrderID   string  `json:"reference_order_id"`             // 商户订单ID
 	OrderDescription   string  `json:"order_description,omitempty"`    // 订单描述
 	OrderCurrency      string  `json:"order_currency,omitempty"`       // 订单币种
-	OrderAmount        float64 `json:"order_amount,omitempty"`         // 订单金额
+	OrderAmount        Decimal `json:"order_amount,omitempty"`         // 订单金额
 	OrderBuyerID       string  `json:"order_buyer_id,omitempty"`       // 买家ID
 	OrderBuyerEmail    string  `json:"order_buyer_email,omitempty"`    // 买家邮箱
 }
@@ -660,7 +660,7 @@ type PaymentSessionRequest struct {
 	MchAppID                  string    `json:"mch_app_id"`                     // 商户应用ID
 	PaymentRequestID          string    `json:"payment_request_id"`             // 商户自定义支付请求ID（唯一）
 	PaymentCurrency           string    `json:"payment_currency"`               // 支付币种（ISO 4217三位代码）
-	PaymentAmount             float64   `json:"payment_amount"`                 // 支付金额（单位：元）
+	PaymentAmount             Decimal   `json:"payment_amount"`                 // 支付金额（单位：元）
 	PaymentMethodType         string    `json:"payment_method_type"`            // 支付方式类型
 	PaymentSessionExpiryTime  string    `json:"payment_session_expiry_time,omitempty"` // 会话过期时间（ISO 8601格式）
 	PaymentRedirectURL        string    `json:"payment_redirect_url"`           // 支付完成后用户重定向地址
@@ -697,7 +697,7 @@ type EasySafePayRequest struct {
 	MchAppID            string    `json:"mch_app_id"`             // 商户应用ID
 	PaymentRequestID    string    `json:"payment_request_id"`     // 商户自定义支付请求ID（唯一）
 	PaymentCurrency     string    `json:"payment_currency"`       // 支付币种（ISO 4217三位代码）
-	PaymentAmount       float64   `json:"payment_amount"`         // 支付金额（单位：元）
+	PaymentAmount       Decimal   `json:"payment_amount"`         // 支付金额（单位：元）
 	PaymentMethodID     string    `json:"payment_method_id"`      // 支付方式ID（access_token）
 	PaymentMethodType   string    `json:"payment_method_type"`    // 支付方式类型
 	PaymentRedirectURL  string    `json:"payment_redirect_url"`   // 支付完成后重定向地址
@@ -720,7 +720,7 @@ type PaymentResponse struct {
 		PaymentRequestID string  `json:"payment_request_id"` // 支付请求ID
 		PaymentID        string  `json:"payment_id"`         // 支付ID
 		PaymentCurrency  string  `json:"payment_currency"`   // 支付币种
-		PaymentAmount    float64 `json:"payment_amount"`     // 支付金额
+		PaymentAmount    Decimal `json:"payment_amount"`     // 支付金额
 		NormalURL        string  `json:"normal_url"`         // 支付URL
 		SchemeURL        string  `json:"scheme_url"`         // Scheme URL
 		ApplinkURL       string  `json:"applink_url"`        // Applink URL
@@ -804,7 +804,7 @@ type QueryPaymentResponse struct {
 	Data struct {
 		PaymentRequestID  string                 `json:"payment_request_id"`  // 支付请求ID
 		PaymentID         string                 `json:"payment_id"`          // 支付ID
-		PaymentAmount     float64                `json:"payment_amount"`      // 支付金额
+		PaymentAmount     Decimal                `json:"payment_amount"`      // 支付金额
 		PaymentCurrency   string                 `json:"payment_currency"`    // 支付币种
 		PaymentStatus     string                 `json:"payment_status"`      // 支付状态：SUCCESS/FAIL/PROCESSING/CANCELLED
 		PaymentMethodType string                 `json:"payment_method_type"` // 支付方式类型
@@ -819,7 +819,7 @@ type QueryPaymentResponse struct {
 
 // PayeeRequest 新增收款人请求
 type PayeeRequest struct {
-	SubjectType   string `json:"subject_type"`            // 主体类型：INDIVIDUAL/ENTERPRISE
+	SubjectType   string `json:"subject_type,omitempty"`  // 主体类型：INDIVIDUAL/ENTERPRISE（更新时忽略）
 	AccountType   string `json:"account_type"`            // 账户类型：E_WALLET/BANK_ACCOUNT
 	Country       string `json:"country"`                 // 国家/地区代码
 	FirstName     string `json:"first_name,omitempty"`    // 收款人名（个人类型必填）
@@ -953,8 +953,8 @@ type PayeeAccountFormResponse struct {
 
 // PayeeAccountBankRequest 新增/更新收款账户请求（银行账户）
 type PayeeAccountBankRequest struct {
-	PaymentMethod string      `json:"payment_method"`       // 付款方式：BANK_TRANSFER
-	Currency      string      `json:"currency,omitempty"`   // 账户币种
+	PaymentMethod string      `json:"payment_method,omitempty"` // 付款方式：BANK_TRANSFER（更新时忽略）
+	Currency      string      `json:"currency,omitempty"`       // 账户币种
 	Fields        []FormField `json:"fields"`               // 表单字段集合
 }
 
@@ -1070,7 +1070,7 @@ type PayerListResponse struct {
 type ClearingNetworkRequest struct {
 	PayeeAccountID  string  `json:"payee_account_id"`  // 收款人账户ID
 	PayCurrency     string  `json:"pay_currency"`      // 付款币种（ISO-4217）
-	Amount          float64 `json:"amount"`            // 金额
+	Amount          Decimal `json:"amount"`            // 金额
 	AmountType      string  `json:"amount_type"`       // 金额类型：PAY_AMOUNT/RECEIVE_AMOUNT
 	ReceiveCurrency string  `json:"receive_currency"`  // 收款币种（ISO-4217）
 }
@@ -1078,7 +1078,7 @@ type ClearingNetworkRequest struct {
 // ClearingNetwork 清算网络信息
 type ClearingNetwork struct {
 	Network               string  `json:"network"`                 // 清算网络：SWIFT/ACH/FPS等
-	Fee                   float64 `json:"fee"`                     // 手续费
+	Fee                   Decimal `json:"fee"`                     // 手续费
 	EstimatedArrivalTime  string  `json:"estimated_arrival_time"`  // 预计到账时间
 }
 
@@ -1093,7 +1093,7 @@ type ClearingNetworkResponse struct {
 	PayeeAccountID   string            `json:"payee_account_id"`  // 收款人账户ID
 	PayCurrency      string            `json:"pay_currency"`      // 付款币种
 	ReceiveCurrency  string            `json:"receive_currency"`  // 收款币种
-	Amount           float64           `json:"amount"`            // 金额
+	Amount           Decimal           `json:"amount"`            // 金额
 	AmountType       string            `json:"amount_type"`       // 金额类型
 	Country          string            `json:"country"`           // 国家/地区
 }
@@ -1105,7 +1105,7 @@ type QuoteRequest struct {
 	Purpose                string  `json:"purpose"`                            // 汇款目的
 	PayCurrency            string  `json:"pay_currency"`                       // 付款币种（ISO-4217）
 	ReceiveCurrency        string  `json:"receive_currency,omitempty"`         // 收款币种（ISO-4217）
-	Amount                 float64 `json:"amount"`                             // 金额
+	Amount                 Decimal `json:"amount"`                             // 金额
 	AmountType             string  `json:"amount_type"`                        // 金额类型：PAY_AMOUNT/RECEIVE_AMOUNT
 	ClearingNetwork        string  `json:"clearing_network,omitempty"`         // 清算网络
 	AbaNumber              string  `json:"aba_number,omitempty"`               // ABA码
@@ -1118,7 +1118,7 @@ type QuoteRequest struct {
 // AmountInfo 金额信息
 type AmountInfo struct {
 	Currency string  `json:"currency"` // 币种
-	Amount   float64 `json:"amount"`   // 金额
+	Amount   Decimal `json:"amount"`   // 金额
 }
 
 // Quote 锁汇单信息
@@ -1128,7 +1128,7 @@ type Quote struct {
 	PayAmount     AmountInfo `json:"pay_amount"`      // 付款金额
 	ReceiveAmount AmountInfo `json:"receive_amount"`  // 收款金额
 	Surcharge     AmountInfo `json:"surcharge"`       // 手续费
-	ExchangeRate  float64    `json:"exchange_rate"`   // 汇率
+	ExchangeRate  Decimal    `json:"exchange_rate"`   // 汇率
 	ExpireAt      string     `json:"expire_at"`       // 过期时间
 }
 
@@ -1162,7 +1162,7 @@ type RemittanceOrder struct {
 	PayAmount      AmountInfo `json:"pay_amount"`       // 付款金额
 	ReceiveAmount  AmountInfo `json:"receive_amount"`   // 收款金额
 	Surcharge      AmountInfo `json:"surcharge"`        // 手续费
-	ExchangeRate   float64    `json:"exchange_rate"`    // 汇率
+	ExchangeRate   Decimal    `json:"exchange_rate"`    // 汇率
 	ErrorMessage   string     `json:"error_message"`    // 错误消息
 }
 
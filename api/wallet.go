@@ -1,7 +1,7 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	
 	gsalary "github.com/difyz9/gsalary-sdk-go"
@@ -19,6 +19,11 @@ func NewWalletAPI(client *gsalary.GSalaryClient) *WalletAPI {
 
 // GetBalance 查询钱包余额
 func (api *WalletAPI) GetBalance(req *WalletBalanceRequest) (*WalletBalanceResponse, error) {
+	return api.GetBalanceCtx(context.Background(), req)
+}
+
+// GetBalanceCtx 查询钱包余额（支持context）
+func (api *WalletAPI) GetBalanceCtx(ctx context.Context, req *WalletBalanceRequest) (*WalletBalanceResponse, error) {
 	// 创建GET请求
 	request := gsalary.NewRequest("GET", "/v1/wallets/balance")
 	
@@ -28,27 +33,15 @@ func (api *WalletAPI) GetBalance(req *WalletBalanceRequest) (*WalletBalanceRespo
 	}
 	
 	// 发送请求
-	resp, err := api.client.Request(request)
+	balanceResp, err := gsalary.DoRequest[WalletBalanceResponse](ctx, api.client, request)
 	if err != nil {
 		return nil, fmt.Errorf("get wallet balance failed: %w", err)
 	}
 	
-	// 解析响应
-	var balanceResp WalletBalanceResponse
-	respBytes, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("marshal response failed: %w", err)
-	}
-	
-	if err := json.Unmarshal(respBytes, &balanceResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response failed: %w", err)
-	}
-	
 	// 检查业务结果
 	if balanceResp.Result.Result != "S" {
-		return &balanceResp, fmt.Errorf("get wallet balance business error: [%s] %s",
-			balanceResp.Result.Code, balanceResp.Result.Message)
+		return balanceResp, bizError("get wallet balance", request, balanceResp.Result)
 	}
 	
-	return &balanceResp, nil
+	return balanceResp, nil
 }
@@ -1,9 +1,22 @@
 package api
 
 import (
+	"errors"
+	"flag"
+	"reflect"
 	"testing"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+	"github.com/difyz9/gsalary-sdk-go/cassette"
+	"github.com/difyz9/gsalary-sdk-go/gsalarytest"
 )
 
+// updateCassettes 重新录制 testdata/cassettes 下的录制文件：go test ./api -run Cassette -update
+var updateCassettes = flag.Bool("update", false, "re-record testdata/cassettes against gsalarytest.Server")
+
+// walletBalanceCassette 钱包余额录制文件
+const walletBalanceCassette = "testdata/cassettes/wallet_balance.json"
+
 // TestGetWalletBalance 测试查询钱包余额
 func TestGetWalletBalance(t *testing.T) {
 	// 检查密钥是否加载
@@ -37,9 +50,9 @@ func TestGetWalletBalance(t *testing.T) {
 	t.Logf("Get wallet balance success!")
 	t.Logf("Result: %s", resp.Result.Result)
 	t.Logf("Currency: %s", resp.Data.Currency)
-	t.Logf("Amount: %.2f", resp.Data.Amount)
-	t.Logf("Share Card Account Balance: %.2f", resp.Data.ShareCardAccountBalance)
-	t.Logf("Available: %.2f", resp.Data.Available)
+	t.Logf("Amount: %s", resp.Data.Amount)
+	t.Logf("Share Card Account Balance: %s", resp.Data.ShareCardAccountBalance)
+	t.Logf("Available: %s", resp.Data.Available)
 	t.Logf("Account Type: %s", resp.Data.AccountType)
 	t.Logf("Query Time: %s", resp.Data.QueryTime)
 	
@@ -83,7 +96,7 @@ func TestGetWalletBalanceMultipleCurrencies(t *testing.T) {
 			}
 			
 			t.Logf("Get wallet balance for %s success!", currency)
-			t.Logf("Available: %.2f %s", resp.Data.Available, resp.Data.Currency)
+			t.Logf("Available: %s %s", resp.Data.Available, resp.Data.Currency)
 		})
 	}
 }
@@ -140,7 +153,112 @@ func TestGetWalletBalanceEmpty(t *testing.T) {
 	} else {
 		t.Log("Query succeeded (API may return default currency or error)")
 		if resp != nil {
-			t.Logf("Currency: %s, Available: %.2f", resp.Data.Currency, resp.Data.Available)
+			t.Logf("Currency: %s, Available: %s", resp.Data.Currency, resp.Data.Available)
+		}
+	}
+}
+
+// TestGetWalletBalanceReplay 使用录制文件离线测试查询钱包余额，响应用测试私钥重新签名
+func TestGetWalletBalanceReplay(t *testing.T) {
+	recorded, err := cassette.Load(walletBalanceCassette)
+	if err != nil {
+		t.Fatalf("Load cassette failed: %v", err)
+	}
+	clientKey, err := gsalary.GenerateKeyPair(0)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair failed: %v", err)
+	}
+	serverKey, err := gsalary.GenerateKeyPair(0)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair failed: %v", err)
+	}
+	config := gsalary.NewConfig()
+	config.AppID = "test_app"
+	config.Endpoint = gsalary.EndpointTest
+	config.ConfigSigner(gsalary.NewRSASigner(clientKey))
+	config.ConfigVerifier(gsalary.NewRSAVerifier(&serverKey.PublicKey))
+
+	replayer := cassette.NewReplayer(recorded, cassette.WithResigner(gsalary.NewRSASigner(serverKey)))
+	client := NewClient(config, gsalary.WithTransport(replayer))
+
+	resp, err := client.Wallet.GetBalance(&WalletBalanceRequest{Currency: "USD"})
+	if err != nil {
+		t.Fatalf("GetBalance failed: %v", err)
+	}
+	if resp.Data.Currency != "USD" || resp.Data.Available.String() != "12000.5" {
+		t.Errorf("Unexpected balance %+v", resp.Data)
+	}
+
+	_, err = client.Wallet.GetBalance(&WalletBalanceRequest{Currency: "XXX"})
+	if !errors.Is(err, gsalary.ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+// recordWalletBalance 通过 cassette.Recorder 对模拟服务端录制钱包余额查询
+func recordWalletBalance(t *testing.T) *cassette.Cassette {
+	t.Helper()
+	server := gsalarytest.NewServer(t)
+	server.Handle("GET", "/v1/wallets/balance", func(r *gsalarytest.Request) *gsalarytest.Response {
+		if r.Query.Get("currency") != "USD" {
+			return gsalarytest.Error("INVALID_ARGUMENT", "unsupported currency")
+		}
+		return gsalarytest.OK(map[string]interface{}{
+			"currency":                   "USD",
+			"amount":                     12345.67,
+			"available":                  12000.5,
+			"share_card_account_balance": 345.17,
+			"account_type":               "BALANCE",
+			"query_time":                 "2025-01-01T00:00:00Z",
+		})
+	})
+
+	recorder := cassette.NewRecorder(nil)
+	client := NewClient(server.Config(), gsalary.WithTransport(recorder))
+	if _, err := client.Wallet.GetBalance(&WalletBalanceRequest{Currency: "USD"}); err != nil {
+		t.Fatalf("GetBalance failed: %v", err)
+	}
+	if _, err := client.Wallet.GetBalance(&WalletBalanceRequest{Currency: "XXX"}); !errors.Is(err, gsalary.ErrInvalidArgument) {
+		t.Fatalf("Expected ErrInvalidArgument, got %v", err)
+	}
+	return recorder.Cassette()
+}
+
+// withoutAuthorization 去掉录制时的响应签名，签名随录制时间和服务端密钥变化
+func withoutAuthorization(c *cassette.Cassette) []cassette.Interaction {
+	var interactions []cassette.Interaction
+	for _, interaction := range c.Interactions {
+		header := make(map[string]string)
+		for key, value := range interaction.Response.Header {
+			if key != "Authorization" {
+				header[key] = value
+			}
+		}
+		interaction.Response.Header = header
+		interactions = append(interactions, interaction)
+	}
+	return interactions
+}
+
+// TestWalletBalanceCassetteRecorded 测试录制文件与通过 Recorder 重新录制的结果一致，
+// 带 -update 时覆盖录制文件
+func TestWalletBalanceCassetteRecorded(t *testing.T) {
+	recorded := recordWalletBalance(t)
+	if *updateCassettes {
+		if err := recorded.Save(walletBalanceCassette); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	saved, err := cassette.Load(walletBalanceCassette)
+	if err != nil {
+		t.Fatalf("Load cassette failed: %v", err)
+	}
+	if got, want := withoutAuthorization(saved), withoutAuthorization(recorded); !reflect.DeepEqual(got, want) {
+		t.Errorf("%s is out of date, re-record with -update\ngot:  %+v\nwant: %+v", walletBalanceCassette, got, want)
+	}
+	for _, interaction := range saved.Interactions {
+		if interaction.Response.Header["Authorization"] == "" {
+			t.Errorf("Expected the recorded response signature for %s", interaction.Request.Path)
 		}
 	}
 }
@@ -8,9 +8,9 @@ type WalletBalanceRequest struct {
 // WalletBalanceData 钱包余额数据
 type WalletBalanceData struct {
 	Currency                 string  `json:"currency"`                    // 币种
-	Amount                   float64 `json:"amount"`                      // 总金额
-	ShareCardAccountBalance  float64 `json:"share_card_account_balance"`  // 共享卡账户余额
-	Available                float64 `json:"available"`                   // 可用余额
+	Amount                   Decimal `json:"amount"`                      // 总金额
+	ShareCardAccountBalance  Decimal `json:"share_card_account_balance"`  // 共享卡账户余额
+	Available                Decimal `json:"available"`                   // 可用余额
 	AccountType              string  `json:"account_type"`                // 账户类型 BALANCE
 	QueryTime                string  `json:"query_time"`                  // 查询时间
 }
@@ -1,13 +1,15 @@
 package api
 
 import (
-	"crypto"
-	"crypto/rsa"
+	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
@@ -35,21 +37,95 @@ const (
 	EventPayeeDeactivated      = "PAYEE_DEACTIVATED"       // 收款人被停用
 )
 
+// ErrWebhookReplayed 签名已经处理过的Webhook（重放或重复推送）
+var ErrWebhookReplayed = errors.New("webhook already received")
+
+// ErrWebhookTooOld 请求体中 event_time 早于 MaxEventAge 的Webhook
+var ErrWebhookTooOld = errors.New("webhook event is too old")
+
+// DefaultMaxEventAge 默认接受的事件最长时间，覆盖平台的自动重推和人工补推
+const DefaultMaxEventAge = 24 * time.Hour
+
+// defaultNonceTTL 关闭事件时间检查或请求体没有签名时间时去重记录的保留时间
+const defaultNonceTTL = 24 * time.Hour
+
 // WebhookHandler Webhook处理器
 type WebhookHandler struct {
-	config *gsalary.GSalaryConfig
+	config     *gsalary.GSalaryConfig
+	nonceStore  NonceStore
+	logger      *slog.Logger
+	maxEventAge time.Duration
+	handle      WebhookFunc
+}
+
+// WebhookOption Webhook处理器选项
+type WebhookOption func(*WebhookHandler)
+
+// WebhookFunc 处理验签通过的Webhook的业务逻辑，返回错误时平台会重新推送
+type WebhookFunc func(ctx context.Context, req *WebhookRequest) error
+
+// WithWebhookFunc 设置业务处理函数，HandleWebhook 验签和去重通过后调用
+// 处理函数返回错误时删除去重记录并应答失败，平台重推的相同请求可以再次处理
+func WithWebhookFunc(fn WebhookFunc) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.handle = fn
+	}
+}
+
+// WithNonceStore 按签名对Webhook去重，拒绝重放；时间戳偏差和去重记录都使用 config 中的时钟
+func WithNonceStore(store NonceStore) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.nonceStore = store
+	}
+}
+
+// WithMaxEventAge 设置接受的事件最长时间，默认为 DefaultMaxEventAge，0 表示不检查
+// event_time 是事件发生时间，平台重推时保持不变，因此不按 MaxClockSkew 检查，
+// 超过该时长的推送视为重放拒绝；同时决定去重记录的保留时间
+func WithMaxEventAge(age time.Duration) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.maxEventAge = age
+	}
+}
+
+// WithWebhookLogger 设置 WebhookServer 使用的日志，默认为 slog.Default()
+// 推送内容只在 Debug 级别输出，并经过 gsalary.RedactJSON 脱敏（如激活码、卡号）
+func WithWebhookLogger(logger *slog.Logger) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.logger = logger
+	}
 }
 
 // NewWebhookHandler 创建Webhook处理器
-func NewWebhookHandler(config *gsalary.GSalaryConfig) *WebhookHandler {
-	return &WebhookHandler{config: config}
+// 请求头签名时间按 config.MaxClockSkew 检查（默认±5分钟），请求体 event_time 按 MaxEventAge 检查（默认24小时）
+func NewWebhookHandler(config *gsalary.GSalaryConfig, opts ...WebhookOption) *WebhookHandler {
+	h := &WebhookHandler{config: config, maxEventAge: DefaultMaxEventAge}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ForgetSignature 从去重存储中删除该签名
+// 未使用 WithWebhookFunc、在 HandleWebhook 返回后自行处理业务时，处理失败需要平台重新推送时调用
+func (h *WebhookHandler) ForgetSignature(signatureHeader string) error {
+	if h.nonceStore == nil {
+		return nil
+	}
+	signature := parseSignatureHeader(signatureHeader)["signature"]
+	if signature == "" {
+		return nil
+	}
+	return h.nonceStore.Delete(signature)
 }
 
 // WebhookRequest Webhook请求数据
 type WebhookRequest struct {
 	AppID           string          `json:"app_id"`           // 应用ID
 	BusinessType    string          `json:"business_type"`    // 业务类型（事件类型）
-	Timestamp       int64           `json:"timestamp"`        // 时间戳（毫秒）
+	EventTime       string          `json:"event_time"`       // 事件发生时间（ISO-8601）
+	BusinessID      string          `json:"business_id"`      // 事件实体唯一ID
+	Timestamp       int64           `json:"timestamp"`        // 时间戳（毫秒），兼容旧格式
 	Data            json.RawMessage `json:"data"`             // 业务数据
 	SignatureHeader string          `json:"-"`                // 签名头（从HTTP Header获取）
 }
@@ -65,7 +141,7 @@ type WebhookResponse struct {
 type PaymentResultData struct {
 	PaymentRequestID  string                 `json:"payment_request_id"`  // 商户支付请求ID
 	PaymentID         string                 `json:"payment_id"`          // GSalary支付ID
-	PaymentAmount     float64                `json:"payment_amount"`      // 支付金额
+	PaymentAmount     Decimal                `json:"payment_amount"`      // 支付金额
 	PaymentCurrency   string                 `json:"payment_currency"`    // 支付币种
 	PaymentStatus     string                 `json:"payment_status"`      // 支付状态：SUCCESS/FAIL/PROCESSING
 	PaymentResultCode string                 `json:"payment_result_code"` // 支付结果代码
@@ -98,7 +174,7 @@ type CardTransactionData struct {
 	TransactionID      string  `json:"transaction_id"`       // 交易ID
 	CardID             string  `json:"card_id"`              // 卡片ID
 	TransactionType    string  `json:"transaction_type"`     // 交易类型
-	Amount             float64 `json:"amount"`               // 交易金额
+	Amount             Decimal `json:"amount"`               // 交易金额
 	Currency           string  `json:"currency"`             // 币种
 	Status             string  `json:"status"`               // 交易状态
 	StatusDescription  string  `json:"status_description"`   // 状态描述
@@ -107,7 +183,8 @@ type CardTransactionData struct {
 	MerchantCountry    string  `json:"merchant_country"`     // 商户国家
 }
 
-// HandleWebhook 处理Webhook请求
+// HandleWebhook 处理Webhook请求：验签、解析、去重，并调用 WithWebhookFunc 设置的处理函数
+// 去重记录在解析成功后写入，处理函数失败时删除，避免失败的推送占用签名导致平台重推被当作重复
 func (h *WebhookHandler) HandleWebhook(r *http.Request) (*WebhookResponse, error) {
 	// 读取请求体
 	body, err := io.ReadAll(r.Body)
@@ -131,7 +208,16 @@ func (h *WebhookHandler) HandleWebhook(r *http.Request) (*WebhookResponse, error
 	}
 	
 	// 验证签名
-	if err := h.verifySignature(body, signatureHeader); err != nil {
+	nonceTTL, err := h.verifySignature(r.Context(), body, signatureHeader)
+	if err != nil {
+		switch {
+		case errors.Is(err, gsalary.ErrClockSkew), errors.Is(err, gsalary.ErrInvalidTimestamp), errors.Is(err, ErrWebhookTooOld):
+			return &WebhookResponse{
+				Result:  "F",
+				Code:    "TIMESTAMP_EXPIRED",
+				Message: "Signature timestamp is invalid or expired",
+			}, fmt.Errorf("signature verification failed: %w", err)
+		}
 		return &WebhookResponse{
 			Result:  "F",
 			Code:    "SIGNATURE_VERIFICATION_FAILED",
@@ -151,6 +237,43 @@ func (h *WebhookHandler) HandleWebhook(r *http.Request) (*WebhookResponse, error
 	
 	req.SignatureHeader = signatureHeader
 	
+	// 按签名去重，拒绝重放
+	signature := parseSignatureHeader(signatureHeader)["signature"]
+	if h.nonceStore != nil {
+		fresh, err := h.nonceStore.CheckAndStore(signature, h.config.Now(), nonceTTL)
+		if err != nil {
+			return &WebhookResponse{
+				Result:  "F",
+				Code:    "NONCE_STORE_FAILED",
+				Message: "Failed to record webhook",
+			}, fmt.Errorf("nonce store failed: %w", err)
+		}
+		if !fresh {
+			// 已处理过的推送返回成功，避免平台继续重推
+			return &WebhookResponse{
+				Result:  "S",
+				Code:    "DUPLICATE_WEBHOOK",
+				Message: "Webhook already received",
+			}, ErrWebhookReplayed
+		}
+	}
+	
+	// 业务处理失败时释放签名，平台重推时可以再次处理
+	if h.handle != nil {
+		if err := h.handle(r.Context(), &req); err != nil {
+			if h.nonceStore != nil {
+				if forgetErr := h.nonceStore.Delete(signature); forgetErr != nil {
+					err = errors.Join(err, fmt.Errorf("nonce store failed: %w", forgetErr))
+				}
+			}
+			return &WebhookResponse{
+				Result:  "F",
+				Code:    "HANDLER_FAILED",
+				Message: "Webhook handling failed",
+			}, fmt.Errorf("webhook handler failed: %w", err)
+		}
+	}
+	
 	// 返回成功响应
 	return &WebhookResponse{
 		Result:  "S",
@@ -159,9 +282,8 @@ func (h *WebhookHandler) HandleWebhook(r *http.Request) (*WebhookResponse, error
 	}, nil
 }
 
-// verifySignature 验证Webhook签名
-func (h *WebhookHandler) verifySignature(body []byte, signatureHeader string) error {
-	// 解析签名头: algorithm=RSA2,time=1234567890,signature=base64_signature
+// parseSignatureHeader 解析签名头: algorithm=RSA2,time=1234567890,signature=base64_signature
+func parseSignatureHeader(signatureHeader string) map[string]string {
 	parts := strings.Split(signatureHeader, ",")
 	signatureMap := make(map[string]string)
 	for _, part := range parts {
@@ -170,46 +292,95 @@ func (h *WebhookHandler) verifySignature(body []byte, signatureHeader string) er
 			signatureMap[kv[0]] = kv[1]
 		}
 	}
+	return signatureMap
+}
+
+// verifySignature 验证Webhook签名和时间，返回去重记录需要保留的时间
+func (h *WebhookHandler) verifySignature(ctx context.Context, body []byte, signatureHeader string) (time.Duration, error) {
+	signatureMap := parseSignatureHeader(signatureHeader)
 	
 	algorithm := signatureMap["algorithm"]
 	timestamp := signatureMap["time"]
 	signature := signatureMap["signature"]
 	
-	if algorithm != "RSA2" {
-		return fmt.Errorf("unsupported algorithm: %s", algorithm)
+	verifier := h.config.GetVerifier()
+	if verifier == nil {
+		return 0, fmt.Errorf("server public key not configured")
 	}
 	
-	if signature == "" {
-		return fmt.Errorf("missing signature")
+	if algorithm != verifier.Algorithm() {
+		return 0, fmt.Errorf("unsupported algorithm: %s", algorithm)
 	}
 	
-	// 检查时间戳（防重放攻击，允许5分钟误差）
-	if timestamp != "" {
-		// 这里可以根据需要检查时间戳有效性
-		// 实际生产环境建议检查时间戳防止重放攻击
+	if signature == "" {
+		return 0, fmt.Errorf("missing signature")
 	}
 	
 	// 解码签名
 	signatureBytes, err := base64.StdEncoding.DecodeString(signature)
 	if err != nil {
-		return fmt.Errorf("decode signature failed: %w", err)
+		return 0, fmt.Errorf("decode signature failed: %w", err)
 	}
 	
 	// 计算消息摘要
 	hash := sha256.Sum256(body)
 	
 	// 验证签名
-	publicKey := h.config.GetServerPublicKey()
-	if publicKey == nil {
-		return fmt.Errorf("server public key not configured")
+	if err := verifier.Verify(ctx, hash[:], signatureBytes); err != nil {
+		return 0, fmt.Errorf("signature verification failed: %w", err)
 	}
 	
-	err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], signatureBytes)
+	// 检查请求头中的签名时间（防重放攻击，默认允许5分钟误差）
+	if err := h.config.CheckTimestamp(timestamp); err != nil {
+		return 0, err
+	}
+	// 签名只覆盖请求体，请求头中的 time 可被改写，因此再按 MaxEventAge 检查受签名保护的 event_time；
+	// event_time 是事件发生时间，平台重推时不变，不能按时钟偏差检查
+	eventTime, err := signedEventTime(body)
 	if err != nil {
-		return fmt.Errorf("signature verification failed: %w", err)
+		return 0, err
+	}
+	now := h.config.Now()
+	if !eventTime.IsZero() && h.maxEventAge > 0 {
+		if age := now.Sub(eventTime); age > h.maxEventAge {
+			return 0, fmt.Errorf("%w: event_time %s is %s old, limit %s",
+				ErrWebhookTooOld, eventTime.Format(time.RFC3339), age.Truncate(time.Second), h.maxEventAge)
+		}
+		// 事件不会发生在未来，超出时钟偏差的 event_time 按时间戳无效处理
+		if skew := h.config.ClockSkew(); skew > 0 && eventTime.After(now.Add(skew)) {
+			return 0, fmt.Errorf("%w: event_time %s is in the future", gsalary.ErrClockSkew, eventTime.Format(time.RFC3339))
+		}
 	}
 	
-	return nil
+	// 去重记录保留到 event_time 超出 MaxEventAge 为止，之后的重放由事件时间检查拒绝；
+	// 没有事件时间检查时，改写请求头 time 即可通过时间戳检查，去重记录需要保留更久
+	if !eventTime.IsZero() && h.maxEventAge > 0 {
+		return h.maxEventAge + h.config.ClockSkew(), nil
+	}
+	return defaultNonceTTL, nil
+}
+
+// signedEventTime 读取请求体中受签名保护的事件时间，没有时返回零值
+// 优先使用文档定义的 event_time（ISO-8601），兼容毫秒级的 timestamp 字段
+func signedEventTime(body []byte) (time.Time, error) {
+	var envelope struct {
+		EventTime string `json:"event_time"`
+		Timestamp int64  `json:"timestamp"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return time.Time{}, nil
+	}
+	if envelope.EventTime != "" {
+		eventTime, err := time.Parse(time.RFC3339, envelope.EventTime)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: event_time %q", gsalary.ErrInvalidTimestamp, envelope.EventTime)
+		}
+		return eventTime, nil
+	}
+	if envelope.Timestamp > 0 {
+		return time.UnixMilli(envelope.Timestamp), nil
+	}
+	return time.Time{}, nil
 }
 
 // ParsePaymentResult 解析支付结果通知
@@ -274,16 +445,21 @@ type WebhookServer struct {
 	port    string
 }
 
-// NewWebhookServer 创建Webhook服务器
-func NewWebhookServer(config *gsalary.GSalaryConfig, port string) *WebhookServer {
+// NewWebhookServer 创建Webhook服务器，业务逻辑通过 WithWebhookFunc 设置
+func NewWebhookServer(config *gsalary.GSalaryConfig, port string, opts ...WebhookOption) *WebhookServer {
 	return &WebhookServer{
-		handler: NewWebhookHandler(config),
+		handler: NewWebhookHandler(config, opts...),
 		port:    port,
 	}
 }
 
 // Start 启动Webhook服务器
 func (s *WebhookServer) Start() error {
+	logger := s.handler.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
 	http.HandleFunc("/webhook", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			w.WriteHeader(http.StatusMethodNotAllowed)
@@ -294,32 +470,38 @@ func (s *WebhookServer) Start() error {
 			})
 			return
 		}
-		
-		// 处理Webhook
-		resp, err := s.handler.HandleWebhook(r)
+
+		// 先读出请求体用于日志，再交给处理器验签
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1048576)) // 1MB max
 		if err != nil {
-			fmt.Printf("Webhook error: %v\n", err)
+			logger.Warn("webhook body read failed", slog.Any("error", err))
 		}
-		
-		// 读取请求体用于日志
-		r.Body = http.MaxBytesReader(w, r.Body, 1048576) // 1MB max
-		body, _ := io.ReadAll(r.Body)
-		
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		// 处理Webhook
+		resp, err := s.handler.HandleWebhook(r)
+
 		// 解析业务类型
 		var webhookReq WebhookRequest
 		json.Unmarshal(body, &webhookReq)
-		
-		fmt.Printf("[%s] Webhook received: business_type=%s, timestamp=%d\n",
-			time.Now().Format("2006-01-02 15:04:05"),
-			webhookReq.BusinessType,
-			webhookReq.Timestamp)
-		
+		attrs := []slog.Attr{
+			slog.String("business_type", webhookReq.BusinessType),
+			slog.Int64("timestamp", webhookReq.Timestamp),
+			slog.String("code", resp.Code),
+		}
+		if err != nil {
+			logger.LogAttrs(r.Context(), slog.LevelWarn, "webhook rejected", append(attrs, slog.Any("error", err))...)
+		} else {
+			logger.LogAttrs(r.Context(), slog.LevelInfo, "webhook received", attrs...)
+		}
+		logger.LogAttrs(r.Context(), slog.LevelDebug, "webhook body", slog.String("body", string(gsalary.RedactJSON(body))))
+
 		// 返回响应
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(resp)
 	})
-	
-	fmt.Printf("Webhook server started on port %s\n", s.port)
+
+	logger.Info("webhook server started", slog.String("port", s.port))
 	return http.ListenAndServe(":"+s.port, nil)
 }
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+)
+
+// newWebhookTestConfig 使用临时生成的服务端密钥创建配置，时钟固定为 now
+func newWebhookTestConfig(t *testing.T, now time.Time) (*gsalary.GSalaryConfig, *rsa.PrivateKey) {
+	t.Helper()
+	serverKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&serverKey.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+	config := gsalary.NewConfig()
+	config.AppID = "test_app"
+	config.Clock = func() time.Time { return now }
+	if err := config.ConfigServerPublicKeyPEM(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))); err != nil {
+		t.Fatalf("Failed to configure public key: %v", err)
+	}
+	return config, serverKey
+}
+
+// newSignedWebhook 构造一个由服务端私钥签名的Webhook请求
+func newSignedWebhook(t *testing.T, serverKey *rsa.PrivateKey, body []byte, signedAt time.Time) *http.Request {
+	t.Helper()
+	hash := sha256.Sum256(body)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, serverKey, crypto.SHA256, hash[:])
+	if err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set("authorization", fmt.Sprintf("algorithm=RSA2,time=%d,signature=%s",
+		signedAt.UnixMilli(), base64.StdEncoding.EncodeToString(signature)))
+	return req
+}
+
+// TestWebhookTimestampSkew 测试过期的Webhook会被拒绝
+func TestWebhookTimestampSkew(t *testing.T) {
+	now := time.Date(2024, 5, 28, 12, 0, 0, 0, time.UTC)
+	config, serverKey := newWebhookTestConfig(t, now)
+	handler := NewWebhookHandler(config)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"business_type": EventCardStatusUpdate,
+		"event_time":    now.Format(time.RFC3339),
+		"data":          map[string]string{"card_id": "card-1", "status": "ACTIVE"},
+	})
+	if resp, err := handler.HandleWebhook(newSignedWebhook(t, serverKey, body, now)); err != nil || resp.Result != "S" {
+		t.Fatalf("Expected fresh webhook to pass, got %+v, %v", resp, err)
+	}
+
+	resp, err := handler.HandleWebhook(newSignedWebhook(t, serverKey, body, now.Add(-10*time.Minute)))
+	if !errors.Is(err, gsalary.ErrClockSkew) || resp.Code != "TIMESTAMP_EXPIRED" {
+		t.Errorf("Expected expired header time to be rejected, got %+v, %v", resp, err)
+	}
+
+	// 请求头时间未被签名保护，伪造为当前时间时仍按请求体中的 event_time 拒绝超过 MaxEventAge 的事件
+	stale, _ := json.Marshal(map[string]interface{}{
+		"business_type": EventCardStatusUpdate,
+		"event_time":    now.Add(-DefaultMaxEventAge - time.Hour).Format(time.RFC3339),
+	})
+	resp, err = handler.HandleWebhook(newSignedWebhook(t, serverKey, stale, now))
+	if !errors.Is(err, ErrWebhookTooOld) || resp.Code != "TIMESTAMP_EXPIRED" {
+		t.Errorf("Expected event_time older than MaxEventAge to be rejected, got %+v, %v", resp, err)
+	}
+	if _, err := NewWebhookHandler(config, WithMaxEventAge(0)).HandleWebhook(newSignedWebhook(t, serverKey, stale, now)); err != nil {
+		t.Errorf("Expected event age check to be disabled, got %v", err)
+	}
+
+	future, _ := json.Marshal(map[string]interface{}{
+		"business_type": EventCardStatusUpdate,
+		"event_time":    now.Add(time.Hour).Format(time.RFC3339),
+	})
+	if _, err := handler.HandleWebhook(newSignedWebhook(t, serverKey, future, now)); !errors.Is(err, gsalary.ErrClockSkew) {
+		t.Errorf("Expected future event_time to be rejected, got %v", err)
+	}
+
+	invalid := []byte(`{"business_type":"CARD_STATUS_UPDATE","event_time":"yesterday"}`)
+	if _, err := handler.HandleWebhook(newSignedWebhook(t, serverKey, invalid, now)); !errors.Is(err, gsalary.ErrInvalidTimestamp) {
+		t.Errorf("Expected invalid event_time to be rejected, got %v", err)
+	}
+}
+
+// TestWebhookNonceStore 测试相同签名的Webhook只接受一次
+func TestWebhookNonceStore(t *testing.T) {
+	now := time.Date(2024, 5, 28, 12, 0, 0, 0, time.UTC)
+	config, serverKey := newWebhookTestConfig(t, now)
+	store := NewMemoryNonceStore()
+	handler := NewWebhookHandler(config, WithNonceStore(store))
+
+	body := []byte(`{"business_type":"CARD_TRANSACTION","event_time":"` + now.Format(time.RFC3339) + `","business_id":"tx-1"}`)
+	first := newSignedWebhook(t, serverKey, body, now)
+	if _, err := handler.HandleWebhook(first); err != nil {
+		t.Fatalf("Expected first delivery to pass, got %v", err)
+	}
+
+	replay := newSignedWebhook(t, serverKey, body, now)
+	resp, err := handler.HandleWebhook(replay)
+	if !errors.Is(err, ErrWebhookReplayed) || resp.Code != "DUPLICATE_WEBHOOK" {
+		t.Errorf("Expected replay to be rejected, got %+v, %v", resp, err)
+	}
+
+	if err := handler.ForgetSignature(first.Header.Get("authorization")); err != nil {
+		t.Fatalf("ForgetSignature failed: %v", err)
+	}
+	if _, err := handler.HandleWebhook(newSignedWebhook(t, serverKey, body, now)); err != nil {
+		t.Errorf("Expected delivery after ForgetSignature to pass, got %v", err)
+	}
+
+	// 去重记录过期后可以再次写入
+	if fresh, _ := store.CheckAndStore("k", now, time.Minute); !fresh {
+		t.Error("Expected new key to be fresh")
+	}
+	if fresh, _ := store.CheckAndStore("k", now.Add(2*time.Minute), time.Minute); !fresh {
+		t.Error("Expected expired key to be fresh again")
+	}
+}
+
+// TestWebhookRedeliveryWithOldEventTime 测试平台重推时 event_time 不变、请求头 time 为新签名时间，超过时钟偏差后仍被接受
+func TestWebhookRedeliveryWithOldEventTime(t *testing.T) {
+	eventTime := time.Date(2024, 5, 28, 12, 0, 0, 0, time.UTC)
+	now := eventTime.Add(40 * time.Minute)
+	config, serverKey := newWebhookTestConfig(t, now)
+	store := NewMemoryNonceStore()
+	handler := NewWebhookHandler(config, WithNonceStore(store))
+
+	body := []byte(`{"business_type":"CARD_TRANSACTION","event_time":"` + eventTime.Format(time.RFC3339) + `","business_id":"tx-1"}`)
+	if resp, err := handler.HandleWebhook(newSignedWebhook(t, serverKey, body, now)); err != nil || resp.Result != "S" {
+		t.Fatalf("Expected redelivery with an old event_time to be accepted, got %+v, %v", resp, err)
+	}
+
+	// 去重记录保留到事件超出 MaxEventAge，期间改写请求头 time 的重放被拒绝
+	later := eventTime.Add(DefaultMaxEventAge - time.Minute)
+	config.Clock = func() time.Time { return later }
+	if _, err := handler.HandleWebhook(newSignedWebhook(t, serverKey, body, later)); !errors.Is(err, ErrWebhookReplayed) {
+		t.Errorf("Expected replay within MaxEventAge to be rejected, got %v", err)
+	}
+}
+
+// TestWebhookFuncFailureAllowsRedelivery 测试业务处理失败时不占用签名，平台重推的相同请求可以再次处理
+func TestWebhookFuncFailureAllowsRedelivery(t *testing.T) {
+	now := time.Date(2024, 5, 28, 12, 0, 0, 0, time.UTC)
+	config, serverKey := newWebhookTestConfig(t, now)
+	var calls int
+	handler := NewWebhookHandler(config, WithNonceStore(NewMemoryNonceStore()), WithWebhookFunc(func(ctx context.Context, req *WebhookRequest) error {
+		calls++
+		if req.BusinessID != "tx-1" {
+			t.Errorf("Unexpected request %+v", req)
+		}
+		if calls == 1 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	}))
+
+	body := []byte(`{"business_type":"CARD_TRANSACTION","event_time":"` + now.Format(time.RFC3339) + `","business_id":"tx-1"}`)
+	resp, err := handler.HandleWebhook(newSignedWebhook(t, serverKey, body, now))
+	if err == nil || resp.Result != "F" || resp.Code != "HANDLER_FAILED" {
+		t.Fatalf("Expected handler failure, got %+v, %v", resp, err)
+	}
+
+	// 平台重推的请求体和签名都不变
+	if resp, err := handler.HandleWebhook(newSignedWebhook(t, serverKey, body, now)); err != nil || resp.Code != "SUCCESS" {
+		t.Fatalf("Expected redelivery after handler failure to be processed, got %+v, %v", resp, err)
+	}
+	resp, err = handler.HandleWebhook(newSignedWebhook(t, serverKey, body, now))
+	if !errors.Is(err, ErrWebhookReplayed) || resp.Code != "DUPLICATE_WEBHOOK" {
+		t.Errorf("Expected delivery after success to be a duplicate, got %+v, %v", resp, err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected handler to run twice, got %d", calls)
+	}
+}
+
+// TestWebhookNonceWithoutEventTime 测试请求体没有签名时间时，改写请求头 time 的重放在时间窗口之外仍被拒绝
+func TestWebhookNonceWithoutEventTime(t *testing.T) {
+	now := time.Date(2024, 5, 28, 12, 0, 0, 0, time.UTC)
+	config, serverKey := newWebhookTestConfig(t, now)
+	handler := NewWebhookHandler(config, WithNonceStore(NewMemoryNonceStore()))
+
+	body := []byte(`{"business_type":"CARD_TRANSACTION","business_id":"tx-1"}`)
+	if _, err := handler.HandleWebhook(newSignedWebhook(t, serverKey, body, now)); err != nil {
+		t.Fatalf("Expected first delivery to pass, got %v", err)
+	}
+
+	later := now.Add(time.Hour)
+	config.Clock = func() time.Time { return later }
+	if _, err := handler.HandleWebhook(newSignedWebhook(t, serverKey, body, later)); !errors.Is(err, ErrWebhookReplayed) {
+		t.Errorf("Expected replay with a rewritten header time to be rejected, got %v", err)
+	}
+}
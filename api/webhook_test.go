@@ -29,7 +29,7 @@ func TestWebhookHandler_HandleWebhook(t *testing.T) {
 	webhookData := map[string]interface{}{
 		"app_id":        testConfig.AppID,
 		"business_type": EventAcquiringPaymentResult,
-		"timestamp":     time.Now().Unix() * 1000,
+		"event_time":    time.Now().UTC().Format(time.RFC3339),
 		"data": map[string]interface{}{
 			"payment_request_id":  "PAY_TEST_123456",
 			"payment_id":          "PAY_ID_123456",
@@ -90,7 +90,7 @@ func TestWebhookHandler_ParsePaymentResult(t *testing.T) {
 	data := PaymentResultData{
 		PaymentRequestID:  "PAY_TEST_123456",
 		PaymentID:         "PAY_ID_123456",
-		PaymentAmount:     100.0,
+		PaymentAmount:     MustParseDecimal("100.0"),
 		PaymentCurrency:   "USD",
 		PaymentStatus:     "SUCCESS",
 		PaymentResultCode: "SUCCESS",
@@ -105,7 +105,7 @@ func TestWebhookHandler_ParsePaymentResult(t *testing.T) {
 	req := &WebhookRequest{
 		AppID:        testConfig.AppID,
 		BusinessType: EventAcquiringPaymentResult,
-		Timestamp:    time.Now().Unix() * 1000,
+		EventTime:    time.Now().UTC().Format(time.RFC3339),
 		Data:         dataBytes,
 	}
 	
@@ -118,7 +118,7 @@ func TestWebhookHandler_ParsePaymentResult(t *testing.T) {
 	t.Logf("Parsed payment result:")
 	t.Logf("  Payment Request ID: %s", result.PaymentRequestID)
 	t.Logf("  Payment ID: %s", result.PaymentID)
-	t.Logf("  Amount: %.2f %s", result.PaymentAmount, result.PaymentCurrency)
+	t.Logf("  Amount: %s %s", result.PaymentAmount, result.PaymentCurrency)
 	t.Logf("  Status: %s", result.PaymentStatus)
 	t.Logf("  Card Token: %v", result.PaymentResultInfo["card_token"])
 	
@@ -150,7 +150,7 @@ func TestWebhookHandler_ParseAuthToken(t *testing.T) {
 	req := &WebhookRequest{
 		AppID:        testConfig.AppID,
 		BusinessType: EventAcquiringAuthToken,
-		Timestamp:    time.Now().Unix() * 1000,
+		EventTime:    time.Now().UTC().Format(time.RFC3339),
 		Data:         dataBytes,
 	}
 	
@@ -189,7 +189,7 @@ func TestWebhookHandler_ParseCardStatusUpdate(t *testing.T) {
 	req := &WebhookRequest{
 		AppID:        testConfig.AppID,
 		BusinessType: EventCardStatusUpdate,
-		Timestamp:    time.Now().Unix() * 1000,
+		EventTime:    time.Now().UTC().Format(time.RFC3339),
 		Data:         dataBytes,
 	}
 	
@@ -218,7 +218,7 @@ func TestWebhookHandler_ParseCardTransaction(t *testing.T) {
 		TransactionID:     "TXN_123456",
 		CardID:            "CARD_123456",
 		TransactionType:   "PURCHASE",
-		Amount:            50.0,
+		Amount:            MustParseDecimal("50.0"),
 		Currency:          "USD",
 		Status:            "SUCCESS",
 		StatusDescription: "Transaction successful",
@@ -232,7 +232,7 @@ func TestWebhookHandler_ParseCardTransaction(t *testing.T) {
 	req := &WebhookRequest{
 		AppID:        testConfig.AppID,
 		BusinessType: EventCardTransaction,
-		Timestamp:    time.Now().Unix() * 1000,
+		EventTime:    time.Now().UTC().Format(time.RFC3339),
 		Data:         dataBytes,
 	}
 	
@@ -246,7 +246,7 @@ func TestWebhookHandler_ParseCardTransaction(t *testing.T) {
 	t.Logf("  Transaction ID: %s", result.TransactionID)
 	t.Logf("  Card ID: %s", result.CardID)
 	t.Logf("  Type: %s", result.TransactionType)
-	t.Logf("  Amount: %.2f %s", result.Amount, result.Currency)
+	t.Logf("  Amount: %s %s", result.Amount, result.Currency)
 	t.Logf("  Status: %s - %s", result.Status, result.StatusDescription)
 	t.Logf("  Merchant: %s (%s)", result.MerchantName, result.MerchantCountry)
 	
@@ -269,7 +269,7 @@ func TestWebhookHandler_InvalidSignature(t *testing.T) {
 	webhookData := map[string]interface{}{
 		"app_id":        testConfig.AppID,
 		"business_type": EventAcquiringPaymentResult,
-		"timestamp":     time.Now().Unix() * 1000,
+		"event_time":    time.Now().UTC().Format(time.RFC3339),
 		"data": map[string]interface{}{
 			"payment_request_id": "PAY_TEST_123456",
 			"payment_status":     "SUCCESS",
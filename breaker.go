@@ -0,0 +1,213 @@
+package gsalary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen 熔断器处于打开状态，请求未发送即失败
+var ErrCircuitOpen = errors.New("gsalary: circuit breaker is open")
+
+// CircuitState 熔断器状态
+type CircuitState int
+
+const (
+	// CircuitClosed 关闭：请求正常发送并统计失败率
+	CircuitClosed CircuitState = iota
+	// CircuitOpen 打开：请求直接返回 ErrCircuitOpen
+	CircuitOpen
+	// CircuitHalfOpen 半开：放行少量探测请求，全部成功后关闭，任一失败则重新打开
+	CircuitHalfOpen
+)
+
+// String 返回状态名
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitBreakerPolicy 熔断策略
+//
+// 网络传输错误、超时和 HTTP 5xx 计为失败；423（SYSTEM_BUSY）和其他 4xx 说明服务端仍在正常处理，不计为失败；
+// 调用方主动取消（context.Canceled）的请求和签名失败、密钥未配置等本地错误不参与统计。
+type CircuitBreakerPolicy struct {
+	Window           time.Duration // 关闭状态下的统计窗口，每个窗口重新计数
+	MinRequests      int           // 窗口内请求数达到该值后才判断失败率
+	FailureRatio     float64       // 失败率达到该值时打开，取值0~1
+	OpenTimeout      time.Duration // 打开多久后进入半开状态
+	HalfOpenRequests int           // 半开状态放行的探测请求数，小于1时按1处理
+	// OnStateChange 状态变化回调，可用于告警；在发送请求的 goroutine 中同步调用，不应阻塞
+	OnStateChange func(from, to CircuitState)
+}
+
+// DefaultCircuitBreakerPolicy 返回推荐的熔断策略
+func DefaultCircuitBreakerPolicy() CircuitBreakerPolicy {
+	return CircuitBreakerPolicy{
+		Window:           time.Minute,
+		MinRequests:      20,
+		FailureRatio:     0.5,
+		OpenTimeout:      30 * time.Second,
+		HalfOpenRequests: 3,
+	}
+}
+
+// CircuitBreaker 熔断器，可在多个客户端之间共享
+type CircuitBreaker struct {
+	policy CircuitBreakerPolicy
+	now    func() time.Time
+
+	mu          sync.Mutex
+	state       CircuitState
+	generation  uint64 // 每次状态变化加一，丢弃旧状态下发出的请求结果
+	windowStart time.Time
+	openedAt    time.Time
+	requests    int
+	failures    int
+	inFlight    int // 半开状态已放行的探测请求数
+}
+
+// NewCircuitBreaker 创建熔断器，初始为关闭状态
+func NewCircuitBreaker(policy CircuitBreakerPolicy) *CircuitBreaker {
+	if policy.HalfOpenRequests < 1 {
+		policy.HalfOpenRequests = 1
+	}
+	return &CircuitBreaker{policy: policy, now: time.Now}
+}
+
+// WithCircuitBreaker 为客户端设置熔断器，默认不熔断
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(o *clientOptions) {
+		o.breaker = breaker
+	}
+}
+
+// State 返回当前状态，打开超过 OpenTimeout 时返回半开
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	from, to := b.advance(b.now())
+	state := b.state
+	b.mu.Unlock()
+	b.notify(from, to)
+	return state
+}
+
+// allow 判断请求能否发送，返回当前状态的代数供 record 使用
+func (b *CircuitBreaker) allow() (uint64, error) {
+	if b == nil {
+		return 0, nil
+	}
+	b.mu.Lock()
+	now := b.now()
+	from, to := b.advance(now)
+	generation := b.generation
+	var err error
+	switch b.state {
+	case CircuitOpen:
+		err = fmt.Errorf("%w, retry in %s", ErrCircuitOpen,
+			b.openedAt.Add(b.policy.OpenTimeout).Sub(now).Round(time.Millisecond))
+	case CircuitHalfOpen:
+		if b.inFlight >= b.policy.HalfOpenRequests {
+			err = fmt.Errorf("%w, half-open probe in progress", ErrCircuitOpen)
+		} else {
+			b.inFlight++
+		}
+	}
+	b.mu.Unlock()
+	b.notify(from, to)
+	return generation, err
+}
+
+// record 记录请求结果
+func (b *CircuitBreaker) record(generation uint64, resp *Response, err error) {
+	if b == nil {
+		return
+	}
+	if err != nil && (errors.Is(err, context.Canceled) || !isTransportError(err)) {
+		// 主动取消和签名失败等本地错误与服务端是否健康无关
+		b.release(generation)
+		return
+	}
+	failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+
+	b.mu.Lock()
+	now := b.now()
+	var from, to CircuitState
+	if b.generation == generation {
+		switch b.state {
+		case CircuitClosed:
+			b.requests++
+			if failed {
+				b.failures++
+			}
+			if b.requests >= b.policy.MinRequests &&
+				float64(b.failures) >= b.policy.FailureRatio*float64(b.requests) && b.failures > 0 {
+				from, to = b.setState(CircuitOpen, now)
+			}
+		case CircuitHalfOpen:
+			if failed {
+				from, to = b.setState(CircuitOpen, now)
+			} else if b.requests++; b.requests >= b.policy.HalfOpenRequests {
+				from, to = b.setState(CircuitClosed, now)
+			}
+		}
+	}
+	b.mu.Unlock()
+	b.notify(from, to)
+}
+
+// release 归还未产生结果的请求占用的半开探测名额
+func (b *CircuitBreaker) release(generation uint64) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.generation == generation && b.state == CircuitHalfOpen {
+		b.inFlight--
+	}
+}
+
+// advance 处理随时间发生的状态变化，调用方需持有锁
+func (b *CircuitBreaker) advance(now time.Time) (CircuitState, CircuitState) {
+	switch b.state {
+	case CircuitClosed:
+		if b.policy.Window > 0 && now.Sub(b.windowStart) >= b.policy.Window {
+			b.windowStart, b.requests, b.failures = now, 0, 0
+		}
+	case CircuitOpen:
+		if now.Sub(b.openedAt) >= b.policy.OpenTimeout {
+			return b.setState(CircuitHalfOpen, now)
+		}
+	}
+	return b.state, b.state
+}
+
+// setState 切换状态并清空计数，调用方需持有锁
+func (b *CircuitBreaker) setState(state CircuitState, now time.Time) (CircuitState, CircuitState) {
+	from := b.state
+	b.state = state
+	b.generation++
+	b.windowStart, b.requests, b.failures, b.inFlight = now, 0, 0, 0
+	if state == CircuitOpen {
+		b.openedAt = now
+	}
+	return from, state
+}
+
+// notify 在锁外调用状态变化回调
+func (b *CircuitBreaker) notify(from, to CircuitState) {
+	if from != to && b.policy.OnStateChange != nil {
+		b.policy.OnStateChange(from, to)
+	}
+}
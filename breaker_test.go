@@ -0,0 +1,177 @@
+package gsalary
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestCircuitBreaker 测试失败率打开、快速失败、半开探测和状态回调
+func TestCircuitBreaker(t *testing.T) {
+	var failing atomic.Bool
+	var calls int32
+	var config *GSalaryConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		signedHandler(t, config, http.StatusOK, `{"result":{"result":"S"}}`)(w, r)
+	}))
+	defer server.Close()
+	config = newTestConfig(t, server.URL)
+
+	var transitions []string
+	breaker := NewCircuitBreaker(CircuitBreakerPolicy{
+		Window:           time.Minute,
+		MinRequests:      4,
+		FailureRatio:     0.5,
+		OpenTimeout:      50 * time.Millisecond,
+		HalfOpenRequests: 2,
+		OnStateChange: func(from, to CircuitState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+	client := NewClient(config, WithCircuitBreaker(breaker), WithRetryPolicy(fastRetryPolicy()))
+	request := func() error {
+		_, err := client.Request(NewRequest("GET", "/v1/wallets/balance"))
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := request(); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+	}
+	failing.Store(true)
+	// 502 重试一次后窗口内4个请求中2个失败，熔断器打开，第三次尝试直接失败
+	if err := request(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen after failures, got %v", err)
+	}
+	before := atomic.LoadInt32(&calls)
+	if err := request(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected fail fast, got %v", err)
+	}
+	if atomic.LoadInt32(&calls) != before {
+		t.Error("Expected no request while open")
+	}
+
+	// 半开探测失败重新打开
+	time.Sleep(60 * time.Millisecond)
+	if breaker.State() != CircuitHalfOpen {
+		t.Fatalf("Expected half-open, got %s", breaker.State())
+	}
+	if err := request(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected probe failure to reopen, got %v", err)
+	}
+
+	// 半开探测全部成功后关闭
+	failing.Store(false)
+	time.Sleep(60 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		if err := request(); err != nil {
+			t.Fatalf("Probe failed: %v", err)
+		}
+	}
+	if breaker.State() != CircuitClosed {
+		t.Errorf("Expected closed, got %s", breaker.State())
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("Expected transitions %v, got %v", want, transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("Expected transitions %v, got %v", want, transitions)
+			break
+		}
+	}
+}
+
+// TestCircuitBreakerIgnoresLocalErrors 测试签名失败等本地错误不会打开熔断器
+func TestCircuitBreakerIgnoresLocalErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	config := NewConfig()
+	config.AppID = "test_app"
+	config.Endpoint = server.URL
+	breaker := NewCircuitBreaker(CircuitBreakerPolicy{Window: time.Minute, MinRequests: 1, FailureRatio: 0.5, OpenTimeout: time.Minute})
+	client := NewClient(config, WithCircuitBreaker(breaker))
+	for i := 0; i < 3; i++ {
+		if _, err := client.Request(NewRequest("GET", "/v1/wallets/balance")); !errors.Is(err, ErrSignerNotConfigured) {
+			t.Fatalf("Expected ErrSignerNotConfigured, got %v", err)
+		}
+	}
+	if state := breaker.State(); state != CircuitClosed {
+		t.Errorf("Expected local errors to keep the breaker closed, got %s", state)
+	}
+	if atomic.LoadInt32(&calls) != 0 {
+		t.Error("Expected no request to reach the server")
+	}
+}
+
+// TestCircuitBreakerProbeNotHeldByRateLimit 测试半开状态的探测名额不会被等待限流的请求占住
+func TestCircuitBreakerProbeNotHeldByRateLimit(t *testing.T) {
+	var failing atomic.Bool
+	failing.Store(true)
+	var config *GSalaryConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		signedHandler(t, config, http.StatusOK, `{"result":{"result":"S"}}`)(w, r)
+	}))
+	defer server.Close()
+	config = newTestConfig(t, server.URL)
+
+	breaker := NewCircuitBreaker(CircuitBreakerPolicy{
+		Window:           time.Minute,
+		MinRequests:      1,
+		FailureRatio:     1,
+		OpenTimeout:      20 * time.Millisecond,
+		HalfOpenRequests: 1,
+	})
+	limiter, err := NewRateLimiter(RateLimit{Prefix: "/v1/cards", Rate: 0.1, Burst: 1})
+	if err != nil {
+		t.Fatalf("NewRateLimiter failed: %v", err)
+	}
+	client := NewClient(config, WithCircuitBreaker(breaker), WithRateLimiter(limiter))
+
+	// 用掉 /v1/cards 的令牌并打开熔断器
+	if _, err := client.Request(NewRequest("GET", "/v1/cards")); err == nil {
+		t.Fatal("Expected 502 error")
+	}
+	failing.Store(false)
+	time.Sleep(30 * time.Millisecond)
+	if state := breaker.State(); state != CircuitHalfOpen {
+		t.Fatalf("Expected half-open, got %s", state)
+	}
+
+	// 该请求需要等待约10秒的令牌，等待期间不应占用探测名额
+	ctx, cancel := context.WithCancel(context.Background())
+	waiting := make(chan error, 1)
+	go func() {
+		_, err := client.RequestCtx(ctx, NewRequest("GET", "/v1/cards"))
+		waiting <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := client.Request(NewRequest("GET", "/v1/wallets/balance")); err != nil {
+		t.Errorf("Expected probe to pass while another request waits for a token, got %v", err)
+	}
+	cancel()
+	if err := <-waiting; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected waiting request to be canceled, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+// Package cassette 录制和回放与GSalary服务端的HTTP交互，用于离线、可重复的集成测试
+//
+// 录制：
+//
+//	recorder := cassette.NewRecorder(nil)
+//	client := api.NewClient(config, gsalary.WithTransport(recorder))
+//	// ... 调用真实接口 ...
+//	recorder.Cassette().Save("testdata/cassettes/wallet.json")
+//
+// 回放：
+//
+//	c, _ := cassette.Load("testdata/cassettes/wallet.json")
+//	cassette.RelaxVerification(config) // 或 cassette.WithResigner 用测试密钥重新签名
+//	client := api.NewClient(config, gsalary.WithTransport(cassette.NewReplayer(c)))
+//
+// 录制的请求体和响应体都经过 gsalary.RedactJSON 脱敏、查询参数经过 gsalary.RedactQuery 脱敏，
+// 回放时按脱敏后的请求匹配。响应签名因此不再有效，
+// 回放时需要放宽验签，或用测试私钥重新签名并把对应公钥配置为服务端公钥。
+package cassette
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// recordedHeaders 录制的响应头，其余响应头不保存
+var recordedHeaders = []string{"Authorization", "Content-Type", "Retry-After"}
+
+// Cassette 一组录制的交互，按录制顺序排列
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction 一次请求和响应
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request 录制的请求，用于回放时匹配
+type Request struct {
+	Method string `json:"method"`
+	// Path 路径和脱敏后未转义的规范查询串，见 gsalary.CanonicalPath 和 gsalary.RedactQuery
+	Path string `json:"path"`
+	// Body 脱敏后的请求体，JSON 按键排序
+	Body string `json:"body,omitempty"`
+}
+
+// Response 录制的响应
+type Response struct {
+	StatusCode int               `json:"status"`
+	Header     map[string]string `json:"header,omitempty"`
+	// Body 脱敏后的响应体
+	Body string `json:"body"`
+}
+
+// Load 读取录制文件
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("load cassette: %w", err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parse cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save 写入录制文件，必要时创建目录
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("save cassette: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("save cassette: %w", err)
+	}
+	return nil
+}
+
+// headerMap 提取需要录制的响应头
+func headerMap(header http.Header) map[string]string {
+	recorded := make(map[string]string)
+	for _, key := range recordedHeaders {
+		if value := header.Get(key); value != "" {
+			recorded[key] = value
+		}
+	}
+	return recorded
+}
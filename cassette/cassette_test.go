@@ -0,0 +1,118 @@
+package cassette
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+)
+
+// newConfig 创建使用新生成密钥的配置，返回配置和服务端私钥
+func newConfig(t *testing.T, endpoint string) (*gsalary.GSalaryConfig, gsalary.Signer) {
+	t.Helper()
+	clientKey, err := gsalary.GenerateKeyPair(0)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair failed: %v", err)
+	}
+	serverKey, err := gsalary.GenerateKeyPair(0)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair failed: %v", err)
+	}
+	config := gsalary.NewConfig()
+	config.AppID = "test_app"
+	config.Endpoint = endpoint
+	config.ConfigSigner(gsalary.NewRSASigner(clientKey))
+	config.ConfigVerifier(gsalary.NewRSAVerifier(&serverKey.PublicKey))
+	return config, gsalary.NewRSASigner(serverKey)
+}
+
+// TestRecordAndReplay 测试录制脱敏、离线回放、放宽验签和重新签名
+func TestRecordAndReplay(t *testing.T) {
+	config, serverSigner := newConfig(t, "")
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body := `{"result":{"result":"S"},"data":{"pan":"4111111111111111","cvv":"123","n":` + strconv.Itoa(calls) + `}}`
+		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+		authorization, err := gsalary.SignResponse(r.Context(), serverSigner, r.Method, gsalary.CanonicalPath(r.URL),
+			r.Header.Get("X-Appid"), timestamp, []byte(body))
+		if err != nil {
+			t.Errorf("SignResponse failed: %v", err)
+		}
+		w.Header().Set("Authorization", authorization.ToHeaderValue())
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+	config.Endpoint = server.URL
+
+	recorder := NewRecorder(nil)
+	client := gsalary.NewClient(config, gsalary.WithTransport(recorder))
+	request := func(client *gsalary.GSalaryClient, currency string) (map[string]interface{}, error) {
+		req := gsalary.NewRequest("POST", "/v1/cards/c1/secure_info")
+		req.Query.Set("currency", currency)
+		req.Query.Set("email", "bob@example.com")
+		req.Body["pin"] = "123456"
+		return client.RequestCtx(context.Background(), req)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := request(client, "USD"); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "cassettes", "secure_info.json")
+	if err := recorder.Cassette().Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(c.Interactions) != 2 {
+		t.Fatalf("Expected 2 interactions, got %d", len(c.Interactions))
+	}
+	recorded := c.Interactions[0]
+	if recorded.Request.Path != "/v1/cards/c1/secure_info?currency=USD&email=b***@example.com" || strings.Contains(recorded.Request.Body, "123456") {
+		t.Errorf("Unexpected recorded request %+v", recorded.Request)
+	}
+	if strings.Contains(recorded.Response.Body, "4111111111111111") || recorded.Response.Header["Authorization"] == "" {
+		t.Errorf("Unexpected recorded response %+v", recorded.Response)
+	}
+	server.Close()
+
+	// 脱敏后原签名失效
+	replayConfig, _ := newConfig(t, server.URL)
+	replayConfig.ConfigVerifier(config.GetVerifier())
+	if _, err := request(gsalary.NewClient(replayConfig, gsalary.WithTransport(NewReplayer(c))), "USD"); err == nil {
+		t.Error("Expected signature error for redacted cassette")
+	}
+
+	// 放宽验签后按录制顺序回放，用完后重复最后一次
+	RelaxVerification(replayConfig)
+	replay := gsalary.NewClient(replayConfig, gsalary.WithTransport(NewReplayer(c)))
+	for _, want := range []float64{1, 2, 2} {
+		result, err := request(replay, "USD")
+		if err != nil {
+			t.Fatalf("Replay failed: %v", err)
+		}
+		if n := result["data"].(map[string]interface{})["n"]; n != want {
+			t.Errorf("Expected interaction %v, got %v", want, n)
+		}
+	}
+	if _, err := request(replay, "EUR"); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("Expected no match error, got %v", err)
+	}
+
+	// 用测试私钥重新签名后正常验签
+	resignConfig, testSigner := newConfig(t, server.URL)
+	resign := gsalary.NewClient(resignConfig, gsalary.WithTransport(NewReplayer(c, WithResigner(testSigner))))
+	if _, err := request(resign, "USD"); err != nil {
+		t.Errorf("Expected re-signed replay to verify, got %v", err)
+	}
+}
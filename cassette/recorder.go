@@ -0,0 +1,92 @@
+package cassette
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+)
+
+// Recorder 把经过的请求转发给真实传输并录制交互
+type Recorder struct {
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder 创建录制传输，transport 为 nil 时使用 http.DefaultTransport
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{transport: transport}
+}
+
+// RoundTrip 实现 http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	request, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("cassette: read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: request,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     headerMap(resp.Header),
+			Body:       string(gsalary.RedactJSON(body)),
+		},
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Cassette 返回目前录制的交互的副本
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// recordRequest 提取用于匹配的请求信息，读取后恢复请求体
+func recordRequest(req *http.Request) (Request, error) {
+	request := Request{Method: req.Method, Path: redactedPath(req.URL)}
+	if req.Body == nil || req.Body == http.NoBody {
+		return request, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return request, fmt.Errorf("cassette: read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if len(body) > 0 {
+		request.Body = string(gsalary.RedactJSON(body))
+	}
+	return request, nil
+}
+
+// redactedPath 与 gsalary.CanonicalPath 相同，但查询参数经过 gsalary.RedactQuery 脱敏
+func redactedPath(u *url.URL) string {
+	query := gsalary.RedactQuery(u.Query())
+	if query == "" {
+		return u.Path
+	}
+	return u.Path + "?" + query
+}
@@ -0,0 +1,126 @@
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+)
+
+// Replayer 从录制文件回放响应，不访问网络
+//
+// 请求按方法、规范路径（含查询串）和脱敏后的请求体匹配；同样的请求录制了多次时按录制顺序依次返回，
+// 用完后重复返回最后一次。找不到匹配的交互时 RoundTrip 返回错误。
+type Replayer struct {
+	cassette *Cassette
+	signer   gsalary.Signer
+	now      func() time.Time
+
+	mu   sync.Mutex
+	used []bool
+}
+
+// ReplayOption 回放选项
+type ReplayOption func(*Replayer)
+
+// WithResigner 回放时用 signer（通常是测试私钥）和当前时间重新签名响应，
+// 客户端把对应的公钥配置为服务端公钥后即可正常验签
+func WithResigner(signer gsalary.Signer) ReplayOption {
+	return func(r *Replayer) {
+		r.signer = signer
+	}
+}
+
+// NewReplayer 创建回放传输
+func NewReplayer(c *Cassette, opts ...ReplayOption) *Replayer {
+	r := &Replayer{cassette: c, now: time.Now, used: make([]bool, len(c.Interactions))}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// RoundTrip 实现 http.RoundTripper
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	request, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	interaction, ok := r.match(request)
+	if !ok {
+		return nil, fmt.Errorf("cassette: no recorded interaction for %s %s", request.Method, request.Path)
+	}
+
+	recorded := interaction.Response
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+	for key, value := range recorded.Header {
+		resp.Header.Set(key, value)
+	}
+	if r.signer != nil {
+		timestamp := strconv.FormatInt(r.now().UnixMilli(), 10)
+		authorization, err := gsalary.SignResponse(req.Context(), r.signer, req.Method, gsalary.CanonicalPath(req.URL),
+			req.Header.Get("X-Appid"), timestamp, []byte(recorded.Body))
+		if err != nil {
+			return nil, fmt.Errorf("cassette: re-sign response: %w", err)
+		}
+		resp.Header.Set("Authorization", authorization.ToHeaderValue())
+	}
+	return resp, nil
+}
+
+// match 查找第一个未使用的匹配交互，都已使用时返回最后一个匹配
+func (r *Replayer) match(request Request) (Interaction, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	last := -1
+	for i, interaction := range r.cassette.Interactions {
+		if interaction.Request != request {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return interaction, true
+		}
+		last = i
+	}
+	if last < 0 {
+		return Interaction{}, false
+	}
+	return r.cassette.Interactions[last], true
+}
+
+// acceptAll 不做验签的 Verifier
+type acceptAll struct{}
+
+// Verify 实现 gsalary.Verifier
+func (acceptAll) Verify(ctx context.Context, digest, signature []byte) error {
+	return nil
+}
+
+// Algorithm 实现 gsalary.Verifier
+func (acceptAll) Algorithm() string {
+	return gsalary.AlgorithmRSA2
+}
+
+// RelaxVerification 关闭配置的响应验签和时间戳检查，仅用于回放未重新签名的录制文件
+// 会替换 config 中的服务端公钥，不要用于连接真实服务端的配置
+func RelaxVerification(config *gsalary.GSalaryConfig) {
+	config.ConfigVerifier(acceptAll{})
+	config.MaxClockSkew = -1
+}
@@ -2,27 +2,61 @@ package gsalary
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"sync"
+	"time"
 )
 
 // GSalaryClient 客户端
 type GSalaryClient struct {
-	config     *GSalaryConfig
-	httpClient *http.Client
+	config      *GSalaryConfig
+	httpClient  *http.Client
+	timeout     time.Duration
+	headers     http.Header
+	userAgent   string
+	retryPolicy RetryPolicy
+	rateLimiter *RateLimiter
+	breaker     *CircuitBreaker
+
+	instrumentation Instrumentation
+	logger          *slog.Logger
+
+	mu          sync.RWMutex
+	middlewares []Middleware
 }
 
 // NewClient 创建新的客户端
-func NewClient(config *GSalaryConfig) *GSalaryClient {
+func NewClient(config *GSalaryConfig, opts ...Option) *GSalaryClient {
+	options := defaultClientOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	return &GSalaryClient{
-		config:     config,
-		httpClient: &http.Client{},
+		config:      config,
+		httpClient:  options.buildHTTPClient(),
+		timeout:     options.timeout,
+		headers:     options.headers,
+		userAgent:   options.userAgent,
+		retryPolicy: options.retryPolicy,
+		rateLimiter: options.rateLimiter,
+		breaker:     options.breaker,
+		middlewares: options.middlewares,
+
+		instrumentation: options.instrumentation,
+		logger:          options.logger,
 	}
 }
 
-// GSalaryException 业务异常
+// GSalaryException 业务异常（错误响应体）
+//
+// Deprecated: 请使用 *APIError，它额外包含HTTP状态码和请求路径；
+// errors.As 仍然可以从 *APIError 中取得 *GSalaryException。
 type GSalaryException struct {
 	BizCode   string `json:"biz_result"`
 	ErrorCode string `json:"error_code"`
@@ -34,15 +68,94 @@ func (e *GSalaryException) Error() string {
 	return fmt.Sprintf("[%s - %s] %s", e.BizCode, e.ErrorCode, e.Message)
 }
 
+// Response 原始响应，200响应在交给拦截器之前已完成验签
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
 // Request 发起请求
 func (c *GSalaryClient) Request(request *GSalaryRequest) (map[string]interface{}, error) {
+	return c.RequestCtx(context.Background(), request)
+}
+
+// RequestCtx 发起请求，ctx 的取消和超时会传递到底层HTTP传输
+func (c *GSalaryClient) RequestCtx(ctx context.Context, request *GSalaryRequest) (map[string]interface{}, error) {
+	resp, err := c.do(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析响应
+	var result map[string]interface{}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return result, nil
+}
+
+// do 校验请求、经过拦截器链发送，并把非200响应转换为 *APIError
+func (c *GSalaryClient) do(ctx context.Context, request *GSalaryRequest) (*Response, error) {
 	// 验证请求
 	if err := request.Valid(); err != nil {
 		return nil, err
 	}
 
-	// 生成签名
-	authHeader, err := request.SignRequest(c.config)
+	// 经过拦截器链发送请求
+	ctx, span := c.startSpan(ctx, request)
+	resp, err := c.handler()(ctx, request)
+	if err == nil && resp.StatusCode != http.StatusOK {
+		err = errorFromResponse(request, resp)
+	}
+	span.end(resp, err)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// send 取得限流令牌、经过熔断器后对请求签名并发送一次，200响应会校验签名
+// 先等待限流再占用熔断器名额，避免半开状态的探测名额在限流等待期间被占住
+// WithTimeout 设置的超时只作用于本次签名和HTTP往返，不包括限流等待和重试间隔
+func (c *GSalaryClient) send(ctx context.Context, request *GSalaryRequest) (*Response, error) {
+	if err := c.rateLimiter.Wait(ctx, request.Path); err != nil {
+		return nil, err
+	}
+	generation, err := c.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+	countAttempt(ctx)
+	c.logRequest(ctx, request)
+	attemptCtx := ctx
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		attemptCtx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	resp, err := c.roundTrip(attemptCtx, request)
+	c.logResponse(ctx, request, resp, err, time.Since(start))
+	c.breaker.record(generation, resp, err)
+	if err != nil {
+		return nil, err
+	}
+	c.rateLimiter.observe(request.Path, resp)
+
+	if resp.StatusCode == http.StatusOK {
+		if err := c.verifyResponse(ctx, request, resp); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// roundTrip 对请求签名并发送一次，不校验响应
+func (c *GSalaryClient) roundTrip(ctx context.Context, request *GSalaryRequest) (*Response, error) {
+	// 生成签名（每次发送都使用新的时间戳）
+	authHeader, err := request.SignRequestCtx(ctx, c.config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign request: %w", err)
 	}
@@ -50,7 +163,7 @@ func (c *GSalaryClient) Request(request *GSalaryRequest) (map[string]interface{}
 	// 准备请求body
 	var reqBody io.Reader
 	if request.HasBody() {
-		bodyBytes, err := json.Marshal(request.Body)
+		bodyBytes, err := request.bodyBytes()
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
@@ -59,12 +172,20 @@ func (c *GSalaryClient) Request(request *GSalaryRequest) (map[string]interface{}
 
 	// 创建HTTP请求
 	fullURL := c.config.ConcatPath(request.PathWithArgs(true))
-	httpReq, err := http.NewRequest(request.Method, fullURL, reqBody)
+	httpReq, err := http.NewRequestWithContext(ctx, request.Method, fullURL, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
 	// 设置请求头
+	for key, values := range c.headers {
+		for _, value := range values {
+			httpReq.Header.Add(key, value)
+		}
+	}
+	if c.userAgent != "" {
+		httpReq.Header.Set("User-Agent", c.userAgent)
+	}
 	httpReq.Header.Set("X-Appid", c.config.AppID)
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("Authorization", authHeader.ToHeaderValue())
@@ -82,32 +203,46 @@ func (c *GSalaryClient) Request(request *GSalaryRequest) (map[string]interface{}
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// 处理响应
-	if resp.StatusCode == http.StatusOK {
-		// 验证响应签名
-		respAuthHeader := FromHeaderValue(resp.Header.Get("Authorization"))
-		if !respAuthHeader.Valid() {
-			return nil, fmt.Errorf("invalid authorization header in response")
-		}
+	return &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       responseBody,
+	}, nil
+}
 
-		if !request.VerifySignature(c.config, respAuthHeader, string(responseBody)) {
-			return nil, fmt.Errorf("signature verification failed")
-		}
+// verifyResponse 校验响应签名和签名时间戳
+func (c *GSalaryClient) verifyResponse(ctx context.Context, request *GSalaryRequest, resp *Response) error {
+	respAuthHeader := FromHeaderValue(resp.Header.Get("Authorization"))
+	if !respAuthHeader.Valid() {
+		return fmt.Errorf("invalid authorization header in response")
+	}
 
-		// 解析响应
-		var result map[string]interface{}
-		if err := json.Unmarshal(responseBody, &result); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-		}
+	if err := request.verifyResponse(ctx, c.config, respAuthHeader, resp.Body); err != nil {
+		return fmt.Errorf("signature verification failed: %w", err)
+	}
 
-		return result, nil
+	// 防重放：检查响应签名时间戳
+	if err := c.config.CheckTimestamp(respAuthHeader.Timestamp); err != nil {
+		return fmt.Errorf("response timestamp check failed: %w", err)
 	}
+	return nil
+}
 
-	// 处理错误响应
+// errorFromResponse 将非200响应转换为 *APIError
+func errorFromResponse(request *GSalaryRequest, resp *Response) error {
+	apiErr := &APIError{
+		HTTPStatus: resp.StatusCode,
+		Method:     request.Method,
+		Endpoint:   request.Path,
+	}
 	var errResp GSalaryException
-	if err := json.Unmarshal(responseBody, &errResp); err != nil {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(responseBody))
+	if err := json.Unmarshal(resp.Body, &errResp); err != nil {
+		apiErr.Message = string(resp.Body)
+		return apiErr
 	}
 
-	return nil, &errResp
+	apiErr.Result = errResp.BizCode
+	apiErr.Code = errResp.ErrorCode
+	apiErr.Message = errResp.Message
+	return apiErr
 }
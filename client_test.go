@@ -0,0 +1,205 @@
+package gsalary
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var (
+	testKeysOnce  sync.Once
+	testClientKey *rsa.PrivateKey
+	testServerKey *rsa.PrivateKey
+	testKeysErr   error
+)
+
+// testKeys 返回测试共用的客户端和服务端密钥
+func testKeys(t *testing.T) (*rsa.PrivateKey, *rsa.PrivateKey) {
+	t.Helper()
+	testKeysOnce.Do(func() {
+		testClientKey, testKeysErr = rsa.GenerateKey(rand.Reader, 2048)
+		if testKeysErr != nil {
+			return
+		}
+		testServerKey, testKeysErr = rsa.GenerateKey(rand.Reader, 2048)
+	})
+	if testKeysErr != nil {
+		t.Fatalf("Failed to generate test keys: %v", testKeysErr)
+	}
+	return testClientKey, testServerKey
+}
+
+// newTestConfig 创建使用临时生成密钥的测试配置
+func newTestConfig(t *testing.T, endpoint string) *GSalaryConfig {
+	t.Helper()
+	clientKey, serverKey := testKeys(t)
+	config := NewConfig()
+	config.AppID = "test_app"
+	config.Endpoint = endpoint
+	config.clientPrivateKey = clientKey
+	config.serverPublicKey = &serverKey.PublicKey
+	return config
+}
+
+// TestRequestCtxCancel 测试context取消会中断请求
+func TestRequestCtxCancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(newTestConfig(t, server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.RequestCtx(ctx, NewRequest("GET", "/v1/cards"))
+	if err == nil {
+		t.Fatal("Expected error when context is cancelled")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+// roundTripperFunc 便于测试的RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// TestClientOptions 测试自定义传输、请求头和User-Agent
+func TestClientOptions(t *testing.T) {
+	var captured *http.Request
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		captured = r
+		return nil, errors.New("stop")
+	})
+
+	client := NewClient(newTestConfig(t, "https://example.invalid"),
+		WithTransport(transport),
+		WithHeader("X-Trace-Id", "trace-1"),
+		WithHeader("X-Appid", "should_be_overridden"),
+		WithUserAgent("payroll-worker/1.0"),
+	)
+
+	if _, err := client.Request(NewRequest("GET", "/v1/cards")); err == nil {
+		t.Fatal("Expected transport error")
+	}
+	if captured == nil {
+		t.Fatal("Custom transport was not used")
+	}
+	if got := captured.Header.Get("X-Trace-Id"); got != "trace-1" {
+		t.Errorf("Expected X-Trace-Id trace-1, got %s", got)
+	}
+	if got := captured.Header.Get("X-Appid"); got != "test_app" {
+		t.Errorf("Expected X-Appid test_app, got %s", got)
+	}
+	if got := captured.Header.Get("User-Agent"); got != "payroll-worker/1.0" {
+		t.Errorf("Expected custom User-Agent, got %s", got)
+	}
+}
+
+// TestClientTimeoutOption 测试默认请求超时
+func TestClientTimeoutOption(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(newTestConfig(t, server.URL), WithTimeout(50*time.Millisecond))
+
+	_, err := client.Request(NewRequest("GET", "/v1/cards"))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+// TestClientTimeoutPerAttempt 测试 WithTimeout 按单次发送计时，重试时每次发送都有完整的超时时间
+func TestClientTimeoutPerAttempt(t *testing.T) {
+	var calls int32
+	var config *GSalaryConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			<-r.Context().Done()
+			return
+		}
+		time.Sleep(30 * time.Millisecond)
+		signedHandler(t, config, http.StatusOK, `{"result":{"result":"S","code":"","message":""},"data":{}}`)(w, r)
+	}))
+	defer server.Close()
+	config = newTestConfig(t, server.URL)
+
+	// 第一次发送用完 50ms 超时后，重试仍有自己的 50ms，而不是与第一次共用
+	client := NewClient(config, WithTimeout(50*time.Millisecond), WithRetryPolicy(fastRetryPolicy()))
+	if _, err := client.Request(NewRequest("GET", "/v1/cards")); err != nil {
+		t.Fatalf("Expected retry after attempt timeout to succeed, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Expected 2 attempts, got %d", got)
+	}
+}
+
+// signedHandler 返回用测试服务端私钥签名响应的处理函数
+func signedHandler(t *testing.T, config *GSalaryConfig, status int, body string) http.HandlerFunc {
+	t.Helper()
+	_, serverKey := testKeys(t)
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeSignedResponse(t, w, r, config, serverKey, status, body)
+	}
+}
+
+// writeSignedResponse 写出带签名的响应
+func writeSignedResponse(t *testing.T, w http.ResponseWriter, r *http.Request, config *GSalaryConfig, serverKey *rsa.PrivateKey, status int, body string) {
+	t.Helper()
+	timestamp := fmt.Sprintf("%d", time.Now().UnixMilli())
+	hash := sha256.Sum256([]byte(body))
+	signBase := fmt.Sprintf("%s %s\n%s\n%s\n%s\n",
+		r.Method,
+		signPath(t, r),
+		config.AppID,
+		timestamp,
+		base64.StdEncoding.EncodeToString(hash[:]))
+	signature, err := signMessage(context.Background(), NewRSASigner(serverKey), signBase)
+	if err != nil {
+		t.Errorf("Failed to sign response: %v", err)
+	}
+	w.Header().Set("Authorization", NewAuthorizeHeaderInfo("RSA2", timestamp, signature).ToHeaderValue())
+	w.WriteHeader(status)
+	w.Write([]byte(body))
+}
+
+// signPath 返回服务端视角的签名路径（查询参数不转义）
+func signPath(t *testing.T, r *http.Request) string {
+	t.Helper()
+	if r.URL.RawQuery == "" {
+		return r.URL.Path
+	}
+	query, err := url.QueryUnescape(r.URL.RawQuery)
+	if err != nil {
+		t.Errorf("Failed to unescape query: %v", err)
+	}
+	return r.URL.Path + "?" + query
+}
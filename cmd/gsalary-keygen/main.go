@@ -0,0 +1,70 @@
+// gsalary-keygen 生成GSalary API鉴权使用的RSA密钥对，或从已有私钥导出公钥
+//
+//	go run github.com/difyz9/gsalary-sdk-go/cmd/gsalary-keygen -bits 2048
+//	go run github.com/difyz9/gsalary-sdk-go/cmd/gsalary-keygen -from private_key.pem
+package main
+
+import (
+	"crypto/rsa"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+)
+
+func main() {
+	bits := flag.Int("bits", gsalary.DefaultKeyBits, "RSA密钥长度（至少2048）")
+	privatePath := flag.String("private", "private_key.pem", "私钥输出路径（PKCS#8 PEM）")
+	publicPath := flag.String("public", "public_key.pem", "公钥输出路径（PKIX PEM）")
+	from := flag.String("from", "", "从已有私钥文件导出公钥，不生成新密钥")
+	passphraseEnv := flag.String("passphrase-env", "", "加密私钥的密码所在的环境变量名（配合 -from）")
+	flag.Parse()
+	log.SetFlags(0)
+
+	var publicKey *rsa.PublicKey
+	if *from != "" {
+		var passphrase []byte
+		if *passphraseEnv != "" {
+			passphrase = []byte(os.Getenv(*passphraseEnv))
+		}
+		config := gsalary.NewConfig()
+		if err := config.ConfigClientPrivateKeyFile(*from, passphrase); err != nil {
+			log.Fatalf("加载私钥失败: %v", err)
+		}
+		key, err := config.ClientPublicKey()
+		if err != nil {
+			log.Fatalf("导出公钥失败: %v", err)
+		}
+		publicKey = key
+	} else {
+		key, err := gsalary.GenerateKeyPair(*bits)
+		if err != nil {
+			log.Fatalf("生成密钥失败: %v", err)
+		}
+		if err := gsalary.WriteKeyPair(key, *privatePath, *publicPath); err != nil {
+			log.Fatalf("保存密钥失败: %v", err)
+		}
+		fmt.Printf("✓ 私钥已保存到: %s\n", *privatePath)
+		fmt.Printf("✓ 公钥已保存到: %s\n", *publicPath)
+		publicKey = &key.PublicKey
+	}
+
+	encoded, err := gsalary.PublicKeyBase64(publicKey)
+	if err != nil {
+		log.Fatalf("编码公钥失败: %v", err)
+	}
+	fingerprint, err := gsalary.PublicKeyFingerprint(publicKey)
+	if err != nil {
+		log.Fatalf("计算指纹失败: %v", err)
+	}
+
+	fmt.Println("\n公钥（单行 base64，填写到 GSalary 商户后台）:")
+	fmt.Println(encoded)
+	fmt.Println("\n公钥指纹:")
+	fmt.Println(fingerprint)
+	if *from == "" {
+		fmt.Println("\n⚠️  请妥善保管私钥，不要泄露给他人！")
+	}
+}
@@ -0,0 +1,575 @@
+package gsalary
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// EndpointTest 测试环境地址
+	EndpointTest = "https://api-test.gsalary.com"
+	// EndpointProd 生产环境地址
+	EndpointProd = "https://api.gsalary.com"
+)
+
+// builtinProfiles 内置环境及其默认地址，配置文件中的同名环境可以覆盖
+var builtinProfiles = map[string]string{
+	"test": EndpointTest,
+	"prod": EndpointProd,
+}
+
+// 配置项名称，用于配置文件的键和检查报告
+const (
+	keyProfile              = "profile"
+	keyAppID                = "app_id"
+	keyEndpoint             = "endpoint"
+	keyClientPrivateKey     = "client_private_key"
+	keyClientPrivateKeyFile = "client_private_key_file"
+	keyClientKeyPassphrase  = "client_private_key_passphrase"
+	keyServerPublicKey      = "server_public_key"
+	keyServerPublicKeyFile  = "server_public_key_file"
+)
+
+// knownConfigKeys 配置文件中允许出现的键
+var knownConfigKeys = map[string]bool{
+	keyAppID: true, keyEndpoint: true,
+	keyClientPrivateKey: true, keyClientPrivateKeyFile: true, keyClientKeyPassphrase: true,
+	keyServerPublicKey: true, keyServerPublicKeyFile: true,
+}
+
+// envConfigKeys 环境变量（及 .env 文件）与配置项的对应关系
+var envConfigKeys = map[string]string{
+	"GSALARY_PROFILE":                       keyProfile,
+	"GSALARY_APPID":                         keyAppID,
+	"GSALARY_ENDPOINT":                      keyEndpoint,
+	"GSALARY_CLIENT_PRIVATE_KEY":            keyClientPrivateKey,
+	"GSALARY_CLIENT_PRIVATE_KEY_FILE":       keyClientPrivateKeyFile,
+	"GSALARY_CLIENT_PRIVATE_KEY_PEM_FILE":   keyClientPrivateKeyFile,
+	"GSALARY_CLIENT_PRIVATE_KEY_PASSPHRASE": keyClientKeyPassphrase,
+	"GSALARY_SERVER_PUBLIC_KEY":             keyServerPublicKey,
+	"GSALARY_SERVER_PUBLIC_KEY_FILE":        keyServerPublicKeyFile,
+	"GSALARY_SERVER_PUBLIC_KEY_PEM_FILE":    keyServerPublicKeyFile,
+}
+
+// ConfigLayer 一个配置来源读取到的配置项
+type ConfigLayer struct {
+	// Name 来源描述，出现在检查报告中
+	Name string
+	// Profile 该来源指定的环境名，为空表示未指定
+	Profile string
+	// Values 不区分环境的配置项
+	Values map[string]string
+	// Profiles 按环境名分组的配置项，覆盖 Values
+	Profiles map[string]map[string]string
+}
+
+// ConfigSource 配置来源
+type ConfigSource interface {
+	Load() (*ConfigLayer, error)
+}
+
+// ConfigSourceFunc 函数形式的配置来源
+type ConfigSourceFunc func() (*ConfigLayer, error)
+
+// Load 实现 ConfigSource
+func (f ConfigSourceFunc) Load() (*ConfigLayer, error) {
+	return f()
+}
+
+// FromEnv 从环境变量读取配置，变量名与 .env.example 一致
+func FromEnv() ConfigSource {
+	return ConfigSourceFunc(func() (*ConfigLayer, error) {
+		layer := &ConfigLayer{Name: "environment", Values: make(map[string]string)}
+		for env, key := range envConfigKeys {
+			if value, ok := os.LookupEnv(env); ok && value != "" {
+				layer.setEnv(env, key, value)
+			}
+		}
+		return layer, nil
+	})
+}
+
+// FromDotEnv 从 .env 文件读取配置，文件不存在时忽略
+// 支持 # 注释、export 前缀和引号，引号内的值可以跨行（如多行 PEM 密钥）；
+// 相对路径的密钥文件按 .env 所在目录解析
+func FromDotEnv(path string) ConfigSource {
+	return ConfigSourceFunc(func() (*ConfigLayer, error) {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return &ConfigLayer{Name: path + " (not found)"}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		layer := &ConfigLayer{Name: path, Values: make(map[string]string)}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for lineNo := 1; scanner.Scan(); lineNo++ {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			line = strings.TrimPrefix(line, "export ")
+			eq := strings.Index(line, "=")
+			if eq <= 0 {
+				return nil, fmt.Errorf("%s line %d: expected KEY=VALUE", path, lineNo)
+			}
+			env := strings.TrimSpace(line[:eq])
+			raw := strings.TrimSpace(line[eq+1:])
+			// 引号内的值可以跨行，例如直接粘贴的 PEM 密钥
+			start := lineNo
+			if quote := dotEnvOpenQuote(raw); quote != "" {
+				for !strings.Contains(raw[1:], quote) && scanner.Scan() {
+					lineNo++
+					raw += "\n" + strings.TrimRight(scanner.Text(), "\r")
+				}
+			}
+			value, err := parseDotEnvValue(raw)
+			if err != nil {
+				return nil, fmt.Errorf("%s line %d: %v", path, start, err)
+			}
+			if key, ok := envConfigKeys[env]; ok && value != "" {
+				layer.setEnv(env, key, resolveKeyPath(path, key, value))
+			}
+		}
+		return layer, scanner.Err()
+	})
+}
+
+// FromFile 从 JSON（.json）或 YAML（.yaml/.yml）配置文件读取配置
+//
+// 顶层为公共配置，profiles 下按环境名分组，profile 指定默认环境：
+//
+//	profile: test
+//	app_id: your_app_id
+//	client_private_key_file: ./private_key.pem
+//	profiles:
+//	  prod:
+//	    endpoint: https://api.gsalary.com
+//	    client_private_key_file: /etc/gsalary/prod_key.pem
+//
+// 相对路径的密钥文件按配置文件所在目录解析。
+func FromFile(path string) ConfigSource {
+	return ConfigSourceFunc(func() (*ConfigLayer, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var tree map[string]interface{}
+		switch ext := strings.ToLower(filepath.Ext(path)); ext {
+		case ".json":
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.UseNumber()
+			if err := decoder.Decode(&tree); err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+		case ".yaml", ".yml":
+			if tree, err = parseYAML(data); err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+		default:
+			return nil, fmt.Errorf("%s: unsupported config file extension %q, expected .json, .yaml or .yml", path, ext)
+		}
+		return layerFromTree(path, tree)
+	})
+}
+
+// WithProfile 指定使用的环境，如 "test"、"prod"，优先级按来源顺序决定
+func WithProfile(name string) ConfigSource {
+	return ConfigSourceFunc(func() (*ConfigLayer, error) {
+		return &ConfigLayer{Name: "profile " + name, Profile: name}, nil
+	})
+}
+
+// FromMap 使用给定的配置项（键与配置文件一致），通常放在最前面作为默认值
+func FromMap(name string, values map[string]string) ConfigSource {
+	return ConfigSourceFunc(func() (*ConfigLayer, error) {
+		layer := &ConfigLayer{Name: name, Values: make(map[string]string)}
+		for key, value := range values {
+			if key == keyProfile {
+				layer.Profile = value
+				continue
+			}
+			layer.Values[key] = value
+		}
+		return layer, nil
+	})
+}
+
+// setEnv 写入环境变量对应的配置项，GSALARY_PROFILE 作为环境名
+func (l *ConfigLayer) setEnv(env, key, value string) {
+	if key == keyProfile {
+		l.Profile = value
+		return
+	}
+	// 旧变量名 *_PEM_FILE 不覆盖新变量名
+	if _, exists := l.Values[key]; exists && strings.HasSuffix(env, "_PEM_FILE") {
+		return
+	}
+	l.Values[key] = value
+}
+
+// layerFromTree 将配置文件的解析结果转换为 ConfigLayer
+func layerFromTree(path string, tree map[string]interface{}) (*ConfigLayer, error) {
+	layer := &ConfigLayer{Name: path, Values: make(map[string]string)}
+	for key, value := range tree {
+		switch key {
+		case keyProfile:
+			layer.Profile = fmt.Sprint(value)
+		case "profiles":
+			profiles, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: \"profiles\" must be a mapping", path)
+			}
+			layer.Profiles = make(map[string]map[string]string)
+			for name, section := range profiles {
+				values, ok := section.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("%s: profile %q must be a mapping", path, name)
+				}
+				layer.Profiles[name] = make(map[string]string)
+				for k, v := range values {
+					if err := setTreeValue(path, layer.Profiles[name], k, v); err != nil {
+						return nil, err
+					}
+				}
+			}
+		default:
+			if err := setTreeValue(path, layer.Values, key, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return layer, nil
+}
+
+// setTreeValue 写入配置文件中的一个标量配置项
+func setTreeValue(path string, values map[string]string, key string, value interface{}) error {
+	var text string
+	switch v := value.(type) {
+	case string:
+		text = v
+	case json.Number, bool:
+		text = fmt.Sprint(v)
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("%s: %q must be a scalar value", path, key)
+	}
+	values[key] = resolveKeyPath(path, key, text)
+	return nil
+}
+
+// resolveKeyPath 将密钥文件的相对路径按配置文件所在目录解析
+func resolveKeyPath(configPath, key, value string) string {
+	if key != keyClientPrivateKeyFile && key != keyServerPublicKeyFile {
+		return value
+	}
+	if value == "" || filepath.IsAbs(value) {
+		return value
+	}
+	return filepath.Join(filepath.Dir(configPath), value)
+}
+
+// ConfigIssue 配置检查发现的问题
+type ConfigIssue struct {
+	// Field 配置项名称，如 app_id、endpoint
+	Field string
+	// Message 问题描述
+	Message string
+	// Warning 为 true 时不阻止使用配置
+	Warning bool
+}
+
+// String 返回问题描述
+func (i ConfigIssue) String() string {
+	level := "error"
+	if i.Warning {
+		level = "warning"
+	}
+	return fmt.Sprintf("[%s] %s: %s", level, i.Field, i.Message)
+}
+
+// ConfigReport 配置加载和检查报告
+type ConfigReport struct {
+	// Profile 生效的环境名
+	Profile string
+	// Sources 按顺序读取的来源
+	Sources []string
+	// Issues 发现的问题，包括错误和警告
+	Issues []ConfigIssue
+}
+
+// OK 没有错误级别的问题时返回 true
+func (r *ConfigReport) OK() bool {
+	for _, issue := range r.Issues {
+		if !issue.Warning {
+			return false
+		}
+	}
+	return true
+}
+
+// Err 存在错误级别的问题时返回 *ConfigError
+func (r *ConfigReport) Err() error {
+	if r.OK() {
+		return nil
+	}
+	return &ConfigError{Report: r}
+}
+
+// String 返回多行的报告文本，适合在启动日志中输出
+func (r *ConfigReport) String() string {
+	var b strings.Builder
+	profile := r.Profile
+	if profile == "" {
+		profile = "(none)"
+	}
+	fmt.Fprintf(&b, "gsalary config: profile=%s sources=%s\n", profile, strings.Join(r.Sources, ", "))
+	if len(r.Issues) == 0 {
+		b.WriteString("  ok\n")
+	}
+	for _, issue := range r.Issues {
+		fmt.Fprintf(&b, "  %s\n", issue)
+	}
+	return b.String()
+}
+
+// addIssue 记录一个问题
+func (r *ConfigReport) addIssue(field string, warning bool, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, ConfigIssue{Field: field, Message: fmt.Sprintf(format, args...), Warning: warning})
+}
+
+// sortIssues 按错误在前、再按字段名和描述排序，保证多次检查的输出顺序一致
+func (r *ConfigReport) sortIssues() {
+	sort.SliceStable(r.Issues, func(i, j int) bool {
+		a, b := r.Issues[i], r.Issues[j]
+		if a.Warning != b.Warning {
+			return !a.Warning
+		}
+		if a.Field != b.Field {
+			return a.Field < b.Field
+		}
+		return a.Message < b.Message
+	})
+}
+
+// ConfigError 配置检查未通过
+type ConfigError struct {
+	Report *ConfigReport
+}
+
+// Error 实现error接口，列出所有错误级别的问题
+func (e *ConfigError) Error() string {
+	var problems []string
+	for _, issue := range e.Report.Issues {
+		if !issue.Warning {
+			problems = append(problems, issue.Field+": "+issue.Message)
+		}
+	}
+	return "invalid gsalary config: " + strings.Join(problems, "; ")
+}
+
+// LoadConfig 按顺序读取配置来源（后面的覆盖前面的），加载密钥并检查配置
+//
+// 未指定来源时等价于 LoadConfig(FromDotEnv(".env"), FromEnv())。
+// 返回的报告总是非 nil；存在错误级别的问题时 error 为 *ConfigError，config 为按已解析的值
+// 部分加载的配置（如缺少密钥），只应用于诊断或跳过集成测试；读取来源失败时 config 为 nil。
+func LoadConfig(sources ...ConfigSource) (*GSalaryConfig, *ConfigReport, error) {
+	if len(sources) == 0 {
+		sources = []ConfigSource{FromDotEnv(".env"), FromEnv()}
+	}
+	report := &ConfigReport{}
+
+	var layers []*ConfigLayer
+	for _, source := range sources {
+		layer, err := source.Load()
+		if err != nil {
+			return nil, report, fmt.Errorf("load config: %w", err)
+		}
+		layers = append(layers, layer)
+		report.Sources = append(report.Sources, layer.Name)
+		if layer.Profile != "" {
+			report.Profile = layer.Profile
+		}
+	}
+
+	// 合并：内置环境地址 < 各来源的公共配置和所选环境配置（按来源顺序）
+	values := make(map[string]string)
+	profileKnown := report.Profile == ""
+	builtinEndpoint, builtin := builtinProfiles[report.Profile]
+	if builtin {
+		values[keyEndpoint] = builtinEndpoint
+		profileKnown = true
+	}
+	endpointScoped := true // 生效的地址是否来自内置环境或所选环境的配置
+	for _, layer := range layers {
+		for key, value := range layer.Values {
+			values[key] = value
+		}
+		if _, ok := layer.Values[keyEndpoint]; ok {
+			endpointScoped = false
+		}
+		if section, ok := layer.Profiles[report.Profile]; ok {
+			profileKnown = true
+			for key, value := range section {
+				values[key] = value
+			}
+			if _, ok := section[keyEndpoint]; ok {
+				endpointScoped = true
+			}
+		}
+		for key := range layer.Values {
+			if !knownConfigKeys[key] {
+				report.addIssue(key, true, "unknown key in %s", layer.Name)
+			}
+		}
+		for name, section := range layer.Profiles {
+			for key := range section {
+				if !knownConfigKeys[key] {
+					report.addIssue(key, true, "unknown key in profile %q of %s", name, layer.Name)
+				}
+			}
+		}
+	}
+	if !profileKnown {
+		report.addIssue(keyProfile, false, "unknown profile %q", report.Profile)
+	}
+
+	config := NewConfig()
+	config.AppID = values[keyAppID]
+	if endpoint := values[keyEndpoint]; endpoint != "" {
+		config.Endpoint = endpoint
+	}
+	checkAppID(report, config.AppID)
+	checkEndpoint(report, config.Endpoint)
+	if builtin && !endpointScoped {
+		checkProfileEndpoint(report, config.Endpoint, builtinEndpoint)
+	}
+	loadConfigKeys(report, config, values)
+
+	report.sortIssues()
+	return config, report, report.Err()
+}
+
+// loadConfigKeys 加载客户端私钥和服务端公钥，内联内容优先于文件路径
+func loadConfigKeys(report *ConfigReport, config *GSalaryConfig, values map[string]string) {
+	passphrase := []byte(values[keyClientKeyPassphrase])
+	switch {
+	case values[keyClientPrivateKey] != "":
+		if err := config.ConfigClientPrivateKey([]byte(values[keyClientPrivateKey]), passphrase); err != nil {
+			report.addIssue(keyClientPrivateKey, false, "cannot load: %v", err)
+		}
+	case values[keyClientPrivateKeyFile] != "":
+		if err := config.ConfigClientPrivateKeyFile(values[keyClientPrivateKeyFile], passphrase); err != nil {
+			report.addIssue(keyClientPrivateKeyFile, false, "cannot load %s: %v", values[keyClientPrivateKeyFile], err)
+		}
+	default:
+		report.addIssue(keyClientPrivateKeyFile, false, "missing (set GSALARY_CLIENT_PRIVATE_KEY_FILE)")
+	}
+
+	switch {
+	case values[keyServerPublicKey] != "":
+		if err := config.ConfigServerPublicKey([]byte(values[keyServerPublicKey])); err != nil {
+			report.addIssue(keyServerPublicKey, false, "cannot load: %v", err)
+		}
+	case values[keyServerPublicKeyFile] != "":
+		if err := config.ConfigServerPublicKeyPEMFile(values[keyServerPublicKeyFile]); err != nil {
+			report.addIssue(keyServerPublicKeyFile, false, "cannot load %s: %v", values[keyServerPublicKeyFile], err)
+		}
+	default:
+		report.addIssue(keyServerPublicKeyFile, false, "missing (set GSALARY_SERVER_PUBLIC_KEY_FILE)")
+	}
+}
+
+// checkAppID 检查 AppID
+func checkAppID(report *ConfigReport, appID string) {
+	switch {
+	case appID == "":
+		report.addIssue(keyAppID, false, "missing (set GSALARY_APPID)")
+	case appID == "your_app_id" || appID == "your_app_id_here":
+		report.addIssue(keyAppID, false, "still the placeholder %q", appID)
+	}
+}
+
+// checkProfileEndpoint 检查公共配置中的地址是否与所选内置环境一致
+// 地址属于另一个内置环境时（如 profile=prod 而 endpoint 为测试环境）报错，其他自定义地址给出警告
+func checkProfileEndpoint(report *ConfigReport, endpoint, profileEndpoint string) {
+	if strings.TrimRight(endpoint, "/") == profileEndpoint {
+		return
+	}
+	for name, builtinEndpoint := range builtinProfiles {
+		if strings.TrimRight(endpoint, "/") == builtinEndpoint {
+			report.addIssue(keyEndpoint, false, "%s is the %q endpoint but profile %q is selected (remove GSALARY_ENDPOINT or set it under profiles)",
+				endpoint, name, report.Profile)
+			return
+		}
+	}
+	report.addIssue(keyEndpoint, true, "%s overrides the %q profile endpoint %s", endpoint, report.Profile, profileEndpoint)
+}
+
+// checkEndpoint 检查地址是否为 HTTPS，本机地址仅给出警告
+func checkEndpoint(report *ConfigReport, endpoint string) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		report.addIssue(keyEndpoint, false, "invalid URL %q", endpoint)
+		return
+	}
+	if u.Scheme == "https" {
+		return
+	}
+	if isLoopbackHost(u.Hostname()) {
+		report.addIssue(keyEndpoint, true, "%s is not HTTPS (allowed for local testing)", endpoint)
+		return
+	}
+	report.addIssue(keyEndpoint, false, "%s is not HTTPS", endpoint)
+}
+
+// isLoopbackHost 判断是否为本机地址
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// dotEnvOpenQuote 返回值开头的引号；值不以引号开头时返回空串
+func dotEnvOpenQuote(value string) string {
+	if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'") {
+		return value[:1]
+	}
+	return ""
+}
+
+// parseDotEnvValue 解析 .env 中的值：双引号支持转义，单引号原样，未加引号时去除行尾注释
+func parseDotEnvValue(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		end := strings.LastIndex(value, `"`)
+		if end == 0 {
+			return "", errors.New("unterminated double quote")
+		}
+		// strconv.Unquote 不接受原始换行，跨行的值先转成转义序列
+		return strconv.Unquote(strings.ReplaceAll(value[:end+1], "\n", `\n`))
+	case strings.HasPrefix(value, "'"):
+		end := strings.LastIndex(value, "'")
+		if end == 0 {
+			return "", errors.New("unterminated single quote")
+		}
+		return value[1:end], nil
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(value), nil
+}
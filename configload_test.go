@@ -0,0 +1,214 @@
+package gsalary
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestKeys 在临时目录中生成密钥文件，服务端公钥复用同一密钥对
+func writeTestKeys(t *testing.T, dir string) {
+	t.Helper()
+	clientKey, _ := testKeys(t)
+	if err := WriteKeyPair(clientKey, filepath.Join(dir, "private_key.pem"), filepath.Join(dir, "server_public_key.pem")); err != nil {
+		t.Fatalf("WriteKeyPair failed: %v", err)
+	}
+}
+
+// writeFile 写入测试文件
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
+
+// TestLoadConfigProfiles 测试YAML配置文件的环境选择、覆盖顺序和相对路径
+func TestLoadConfigProfiles(t *testing.T) {
+	dir := t.TempDir()
+	writeTestKeys(t, dir)
+	path := filepath.Join(dir, "gsalary.yaml")
+	writeFile(t, path, `# GSalary 配置
+profile: test
+app_id: "app-common"
+client_private_key_file: ./private_key.pem
+server_public_key_file: server_public_key.pem
+
+profiles:
+  test:
+    app_id: app-test   # 测试环境
+  prod:
+    app_id: 'app-prod'
+`)
+	for _, env := range []string{"GSALARY_PROFILE", "GSALARY_APPID", "GSALARY_ENDPOINT"} {
+		t.Setenv(env, "")
+	}
+
+	config, report, err := LoadConfig(FromFile(path))
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v\n%s", err, report)
+	}
+	if config.AppID != "app-test" || config.Endpoint != EndpointTest {
+		t.Errorf("Expected test profile, got %s %s", config.AppID, config.Endpoint)
+	}
+	if config.GetClientPrivateKey() == nil || config.GetServerPublicKey() == nil {
+		t.Error("Expected keys to be loaded relative to the config file")
+	}
+
+	// 后面的来源覆盖前面的来源
+	t.Setenv("GSALARY_PROFILE", "prod")
+	config, report, err = LoadConfig(FromFile(path), FromEnv())
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if report.Profile != "prod" || config.AppID != "app-prod" || config.Endpoint != EndpointProd {
+		t.Errorf("Expected prod profile, got %s %s %s", report.Profile, config.AppID, config.Endpoint)
+	}
+
+	// 公共配置中的地址与所选内置环境不一致：属于另一个环境时报错，自定义地址给出警告
+	t.Setenv("GSALARY_ENDPOINT", EndpointTest)
+	_, report, err = LoadConfig(FromFile(path), FromEnv())
+	if err == nil || !strings.Contains(report.String(), `is the "test" endpoint but profile "prod" is selected`) {
+		t.Errorf("Expected endpoint/profile mismatch error, got %v\n%s", err, report)
+	}
+	t.Setenv("GSALARY_ENDPOINT", "https://proxy.example.com")
+	config, report, err = LoadConfig(FromFile(path), FromEnv())
+	if err != nil || config.Endpoint != "https://proxy.example.com" ||
+		!strings.Contains(report.String(), `[warning] endpoint: https://proxy.example.com overrides the "prod" profile endpoint`) {
+		t.Errorf("Expected custom endpoint warning, got %v\n%s", err, report)
+	}
+	t.Setenv("GSALARY_ENDPOINT", "")
+
+	_, report, err = LoadConfig(FromFile(path), WithProfile("staging"))
+	if err == nil || !strings.Contains(report.String(), `unknown profile "staging"`) {
+		t.Errorf("Expected unknown profile error, got %v\n%s", err, report)
+	}
+}
+
+// TestLoadConfigDotEnvAndJSON 测试 .env 与 JSON 配置文件
+func TestLoadConfigDotEnvAndJSON(t *testing.T) {
+	dir := t.TempDir()
+	writeTestKeys(t, dir)
+	dotEnv := filepath.Join(dir, ".env")
+	writeFile(t, dotEnv, `# copied from .env.example
+export GSALARY_APPID="app-dotenv"
+GSALARY_ENDPOINT=http://127.0.0.1:8080 # 本地模拟服务
+GSALARY_CLIENT_PRIVATE_KEY_FILE=./private_key.pem
+GSALARY_SERVER_PUBLIC_KEY_FILE='server_public_key.pem'
+GSALARY_MCH_APP_ID=ignored
+`)
+	config, report, err := LoadConfig(FromDotEnv(dotEnv), FromDotEnv(filepath.Join(dir, "missing.env")))
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v\n%s", err, report)
+	}
+	if config.AppID != "app-dotenv" || config.Endpoint != "http://127.0.0.1:8080" {
+		t.Errorf("Unexpected config %s %s", config.AppID, config.Endpoint)
+	}
+	if len(report.Issues) != 1 || !report.Issues[0].Warning || report.Issues[0].Field != "endpoint" {
+		t.Errorf("Expected a single endpoint warning, got %v", report.Issues)
+	}
+
+	jsonPath := filepath.Join(dir, "gsalary.json")
+	writeFile(t, jsonPath, `{"app_id": "app-json", "endpoint": "http://api.example.com", "client_private_key_file": "nope.pem", "extra": 1}`)
+	config, report, err = LoadConfig(FromFile(jsonPath))
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) {
+		t.Fatalf("Expected *ConfigError, got %v", err)
+	}
+	if config == nil || config.AppID != "app-json" || config.GetClientPrivateKey() != nil {
+		t.Errorf("Expected the partially loaded config, got %+v", config)
+	}
+	text := report.String()
+	for _, want := range []string{
+		"[error] endpoint: http://api.example.com is not HTTPS",
+		"[error] client_private_key_file: cannot load",
+		"[error] server_public_key_file: missing",
+		"[warning] extra: unknown key",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected report to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+// TestLoadConfigDotEnvMultiline 测试 .env 中跨行的引号值，例如直接粘贴的 PEM 密钥
+func TestLoadConfigDotEnvMultiline(t *testing.T) {
+	dir := t.TempDir()
+	writeTestKeys(t, dir)
+	privatePEM, err := os.ReadFile(filepath.Join(dir, "private_key.pem"))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	publicPEM, err := os.ReadFile(filepath.Join(dir, "server_public_key.pem"))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	dotEnv := filepath.Join(dir, ".env")
+	writeFile(t, dotEnv, "GSALARY_APPID=app-dotenv\n"+
+		"GSALARY_CLIENT_PRIVATE_KEY=\""+strings.TrimSpace(string(privatePEM))+"\"\n"+
+		"GSALARY_SERVER_PUBLIC_KEY='"+strings.TrimSpace(string(publicPEM))+"'\n"+
+		"GSALARY_ENDPOINT=https://api-test.gsalary.com\n")
+	config, report, err := LoadConfig(FromDotEnv(dotEnv))
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v\n%s", err, report)
+	}
+	if config.GetClientPrivateKey() == nil || config.GetServerPublicKey() == nil {
+		t.Errorf("Expected both keys to load from the multi-line values")
+	}
+	if config.Endpoint != "https://api-test.gsalary.com" {
+		t.Errorf("Expected the line after the key to be read, got endpoint %q", config.Endpoint)
+	}
+
+	writeFile(t, dotEnv, "GSALARY_APPID=app-dotenv\nGSALARY_SERVER_PUBLIC_KEY=\"-----BEGIN PUBLIC KEY-----\nabc\n")
+	if _, _, err := LoadConfig(FromDotEnv(dotEnv)); err == nil || !strings.Contains(err.Error(), "line 2: unterminated double quote") {
+		t.Errorf("Expected unterminated quote error at line 2, got %v", err)
+	}
+}
+
+// TestConfigReportIssueOrder 测试问题按错误在前、字段名排序，多次加载输出一致
+func TestConfigReportIssueOrder(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "gsalary.json")
+	writeFile(t, jsonPath, `{"endpoint": "http://api.example.com", "zeta": 1, "alpha": 2, "mid": 3}`)
+	var first string
+	for i := 0; i < 20; i++ {
+		_, report, _ := LoadConfig(FromFile(jsonPath))
+		text := report.String()
+		if i == 0 {
+			first = text
+			continue
+		}
+		if text != first {
+			t.Fatalf("Report order changed between loads:\n%s\n%s", first, text)
+		}
+	}
+	var fields []string
+	_, report, _ := LoadConfig(FromFile(jsonPath))
+	for _, issue := range report.Issues {
+		fields = append(fields, issue.Field)
+	}
+	want := "app_id,client_private_key_file,endpoint,server_public_key_file,alpha,mid,zeta"
+	if got := strings.Join(fields, ","); got != want {
+		t.Errorf("Expected issue order %s, got %s", want, got)
+	}
+}
+
+// TestParseYAML 测试YAML子集的解析和错误
+func TestParseYAML(t *testing.T) {
+	tree, err := parseYAML([]byte("a: 1\nb:\n  c: \"x # y\"\n  d:\ne: 'it''s'\n"))
+	if err != nil {
+		t.Fatalf("parseYAML failed: %v", err)
+	}
+	b, _ := tree["b"].(map[string]interface{})
+	if tree["a"] != "1" || b["c"] != "x # y" || b["d"] != "" || tree["e"] != "it's" {
+		t.Errorf("Unexpected result %#v", tree)
+	}
+
+	for _, bad := range []string{"a:\n  - 1\n", "a: [1, 2]\n", "a: 1\n   b: 2\n", "a: 1\na: 2\n", "\ta: 1\n", "novalue\n"} {
+		if _, err := parseYAML([]byte(bad)); err == nil || !strings.Contains(err.Error(), "line") {
+			t.Errorf("Expected line error for %q, got %v", bad, err)
+		}
+	}
+}
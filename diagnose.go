@@ -0,0 +1,111 @@
+package gsalary
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// diagnosePath Diagnose 使用的只读接口（查询钱包余额）
+const diagnosePath = "/v1/wallets/balance"
+
+// Diagnosis 启动自检结果
+type Diagnosis struct {
+	Endpoint  string        // 请求地址
+	Reachable bool          // 是否收到了HTTP响应
+	Latency   time.Duration // 请求往返耗时
+	// HTTPStatus 响应状态码，401/403 通常表示AppID或客户端公钥未在商户后台正确配置
+	HTTPStatus int
+	// Authenticated 服务端是否接受了请求签名（HTTP 200）
+	Authenticated bool
+	// ServerTime 响应签名中的 time=，未收到签名响应时为零值
+	ServerTime time.Time
+	// ClockSkew 服务端时间减去本地时间（按往返耗时的一半校正），正值表示本地时钟偏慢
+	ClockSkew time.Duration
+	// SignatureValid 响应签名是否能用配置的服务端公钥验证
+	SignatureValid bool
+	// Err 第一个失败的检查，全部通过时为 nil
+	Err error
+}
+
+// OK 是否所有检查都通过
+func (d *Diagnosis) OK() bool {
+	return d.Err == nil
+}
+
+// String 返回便于输出到日志的多行文本
+func (d *Diagnosis) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "endpoint:       %s\n", d.Endpoint)
+	fmt.Fprintf(&b, "reachable:      %t", d.Reachable)
+	if d.Reachable {
+		fmt.Fprintf(&b, " (%s, HTTP %d)", d.Latency.Round(time.Millisecond), d.HTTPStatus)
+	}
+	fmt.Fprintf(&b, "\nauthenticated:  %t\n", d.Authenticated)
+	fmt.Fprintf(&b, "signature:      %t\n", d.SignatureValid)
+	if !d.ServerTime.IsZero() {
+		fmt.Fprintf(&b, "clock skew:     %s\n", d.ClockSkew.Round(time.Millisecond))
+	}
+	if d.Err != nil {
+		fmt.Fprintf(&b, "error:          %v\n", d.Err)
+	}
+	return b.String()
+}
+
+// Diagnose 检查配置和与GSalary服务端的连通性
+//
+// 先执行 GSalaryConfig.Validate，然后签名调用一次查询钱包余额接口，
+// 报告地址是否可达、往返耗时、时钟偏差以及响应签名是否有效。
+// 自检不经过拦截器链，也不会重试；结果中的 Err 为第一个失败的检查。
+func (c *GSalaryClient) Diagnose(ctx context.Context) *Diagnosis {
+	d := &Diagnosis{Endpoint: c.config.Endpoint}
+	if err := c.config.Validate(); err != nil {
+		d.Err = err
+		return d
+	}
+
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	request := NewRequest("GET", diagnosePath)
+	start := c.config.Now()
+	resp, err := c.roundTrip(ctx, request)
+	d.Latency = c.config.Now().Sub(start)
+	if err != nil {
+		d.Err = err
+		return d
+	}
+	d.Reachable = true
+	d.HTTPStatus = resp.StatusCode
+	d.Authenticated = resp.StatusCode == http.StatusOK
+
+	// 非200响应也可能带签名，有签名时同样计算时钟偏差和验签
+	header := FromHeaderValue(resp.Header.Get("Authorization"))
+	var verifyErr error
+	if header.Valid() {
+		if serverTime, err := ParseTimestamp(header.Timestamp); err == nil {
+			d.ServerTime = serverTime
+			d.ClockSkew = serverTime.Sub(start.Add(d.Latency / 2))
+		}
+		verifyErr = request.verifyResponse(ctx, c.config, header, resp.Body)
+		d.SignatureValid = verifyErr == nil
+	}
+
+	switch {
+	case !d.Authenticated:
+		d.Err = errorFromResponse(request, resp)
+	case verifyErr != nil:
+		d.Err = fmt.Errorf("signature verification failed: %w", verifyErr)
+	case !d.SignatureValid:
+		d.Err = fmt.Errorf("invalid authorization header in response")
+	default:
+		if err := c.config.CheckTimestamp(header.Timestamp); err != nil {
+			d.Err = fmt.Errorf("response timestamp check failed: %w", err)
+		}
+	}
+	return d
+}
@@ -0,0 +1,45 @@
+package gsalary
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestClientDiagnose 测试自检报告连通性、时钟偏差和验签结果
+func TestClientDiagnose(t *testing.T) {
+	config := newTestConfig(t, "")
+	server := httptest.NewServer(signedHandler(t, config, http.StatusOK, `{"result":{"balances":[]}}`))
+	defer server.Close()
+	config.Endpoint = server.URL
+	// 本地时钟慢一分钟
+	config.Clock = func() time.Time { return time.Now().Add(-time.Minute) }
+
+	d := NewClient(config).Diagnose(context.Background())
+	if !d.OK() || !d.Reachable || !d.Authenticated || !d.SignatureValid {
+		t.Fatalf("Expected successful diagnosis, got:\n%s", d)
+	}
+	if d.ClockSkew < 55*time.Second || d.ClockSkew > 65*time.Second {
+		t.Errorf("Expected about 1m clock skew, got %s", d.ClockSkew)
+	}
+
+	// 签名错误的401响应
+	unauthorized := httptest.NewServer(signedHandler(t, config, http.StatusUnauthorized, `{"biz_result":"F","error_code":"INVALID_SIGNATURE","message":"bad signature"}`))
+	defer unauthorized.Close()
+	config.Endpoint = unauthorized.URL
+	d = NewClient(config).Diagnose(context.Background())
+	var apiErr *APIError
+	if d.OK() || d.Authenticated || d.HTTPStatus != http.StatusUnauthorized || !errors.As(d.Err, &apiErr) {
+		t.Errorf("Expected 401 diagnosis, got:\n%s", d)
+	}
+
+	// 不可达
+	unauthorized.Close()
+	d = NewClient(config).Diagnose(context.Background())
+	if d.OK() || d.Reachable {
+		t.Errorf("Expected unreachable diagnosis, got:\n%s", d)
+	}
+}
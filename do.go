@@ -0,0 +1,38 @@
+package gsalary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Do 发送类型化请求
+// POST/PUT 请求按 json 标签将 req 编码为请求体（签名和发送使用同一份字节），
+// 已验签的响应体只解码一次到 Resp，interface{} 字段中的数字保留为 json.Number
+func Do[Req, Resp any](ctx context.Context, c *GSalaryClient, method, path string, req *Req) (*Resp, error) {
+	request := NewRequest(method, path)
+	if req != nil && (method == http.MethodPost || method == http.MethodPut) {
+		if err := request.SetJSONBody(req); err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+	}
+	return DoRequest[Resp](ctx, c, request)
+}
+
+// DoRequest 发送已构造好的请求，并将已验签的响应体解码到 Resp
+func DoRequest[Resp any](ctx context.Context, c *GSalaryClient, request *GSalaryRequest) (*Resp, error) {
+	resp, err := c.do(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var out Resp
+	decoder := json.NewDecoder(bytes.NewReader(resp.Body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &out, nil
+}
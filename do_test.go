@@ -0,0 +1,82 @@
+package gsalary
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type doTestRequest struct {
+	RequestID  string  `json:"request_id"`
+	SellAmount float64 `json:"sell_amount,omitempty"`
+	BuyAmount  float64 `json:"buy_amount,omitempty"`
+}
+
+type doTestResponse struct {
+	Result struct {
+		Result string `json:"result"`
+	} `json:"result"`
+	Data map[string]interface{} `json:"data"`
+}
+
+// TestDoSignsSentBody 测试签名使用的正是发送出去的请求体
+func TestDoSignsSentBody(t *testing.T) {
+	clientKey, _ := testKeys(t)
+	var config *GSalaryConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"request_id":"req-1","sell_amount":12.5}` {
+			t.Errorf("Unexpected request body: %s", body)
+		}
+
+		authInfo := FromHeaderValue(r.Header.Get("Authorization"))
+		hash := sha256.Sum256(body)
+		signBase := fmt.Sprintf("%s %s\n%s\n%s\n%s\n",
+			r.Method, signPath(t, r), config.AppID, authInfo.Timestamp,
+			base64.StdEncoding.EncodeToString(hash[:]))
+		if err := verifyMessage(context.Background(), NewRSAVerifier(&clientKey.PublicKey), signBase, authInfo.Signature); err != nil {
+			t.Error("Request signature does not match the sent body")
+		}
+
+		signedHandler(t, config, http.StatusOK,
+			`{"result":{"result":"S"},"data":{"amount":12345678901234567890.01}}`)(w, r)
+	}))
+	defer server.Close()
+	config = newTestConfig(t, server.URL)
+	client := NewClient(config)
+
+	resp, err := Do[doTestRequest, doTestResponse](context.Background(), client,
+		"POST", "/v1/exchange/quotes", &doTestRequest{RequestID: "req-1", SellAmount: 12.5})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if resp.Result.Result != ResultSuccess {
+		t.Errorf("Expected result S, got %s", resp.Result.Result)
+	}
+	amount, ok := resp.Data["amount"].(json.Number)
+	if !ok {
+		t.Fatalf("Expected json.Number, got %T", resp.Data["amount"])
+	}
+	if amount.String() != "12345678901234567890.01" {
+		t.Errorf("Number precision lost: %s", amount)
+	}
+}
+
+// TestSetJSONBodyEmpty 测试空对象不会作为请求体发送
+func TestSetJSONBodyEmpty(t *testing.T) {
+	request := NewRequest("PUT", "/v1/cards/card-1/contact")
+	if err := request.SetJSONBody(struct {
+		Email string `json:"email,omitempty"`
+	}{}); err != nil {
+		t.Fatalf("SetJSONBody failed: %v", err)
+	}
+	if request.HasBody() {
+		t.Error("Expected empty object to be sent without body")
+	}
+}
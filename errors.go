@@ -0,0 +1,145 @@
+package gsalary
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// 业务结果 result.result 取值
+const (
+	ResultSuccess = "S" // 成功
+	ResultFail    = "F" // 失败
+	ResultUnknown = "U" // 未知，需稍后查询
+)
+
+// 文档错误码清单对应的哨兵错误，可配合 errors.Is 使用
+var (
+	ErrSystemError              = errors.New("gsalary: system error")                      // SYSTEM_ERROR
+	ErrAddCardFailed            = errors.New("gsalary: add card failed")                   // ADD_CARD_FAILED
+	ErrCreatePayeeAccountFailed = errors.New("gsalary: create payee account failed")       // CREATE_PAYEE_ACCOUNT_FAILED
+	ErrUpdatePayeeAccountFailed = errors.New("gsalary: update payee account failed")       // UPDATE_PAYEE_ACCOUNT_FAILED
+	ErrSystemBusy               = errors.New("gsalary: system busy")                       // SYSTEM_BUSY
+	ErrNotFound                 = errors.New("gsalary: not found")                         // NOT_FOUND
+	ErrForbidden                = errors.New("gsalary: forbidden")                         // FORBIDDEN
+	ErrBadRequest               = errors.New("gsalary: bad request")                       // BAD_REQUEST
+	ErrMissingArgument          = errors.New("gsalary: missing argument")                  // MISSING_ARGUMENT
+	ErrInvalidArgument          = errors.New("gsalary: invalid argument")                  // INVALID_ARGUMENT
+	ErrInvalidStatus            = errors.New("gsalary: invalid status")                    // INVALID_STATUS
+	ErrDuplicated               = errors.New("gsalary: duplicated request")                // DUPLICATED
+	ErrQuoteExpired             = errors.New("gsalary: quote expired")                     // QUOTE_EXPIRE
+	ErrOrderExpired             = errors.New("gsalary: order expired")                     // ORDER_EXPIRE
+	ErrInsufficientBalance      = errors.New("gsalary: insufficient balance")              // INSUFFICIENT_BALANCE
+	ErrRiskReject               = errors.New("gsalary: risk reject")                       // RISK_REJECT
+	ErrUserAmountExceedLimit    = errors.New("gsalary: user amount exceed limit")          // USER_AMOUNT_EXCEED_LIMIT
+	ErrUserBalanceNotEnough     = errors.New("gsalary: user balance not enough")           // USER_BALANCE_NOT_ENOUGH
+	ErrResultUnknown            = errors.New("gsalary: result unknown, query again later") // result.result 为 U
+)
+
+// errorCode 文档错误码对应的哨兵错误和HTTP状态码
+type errorCode struct {
+	err    error
+	status int
+}
+
+// errorCodes 文档错误码清单：错误码到哨兵错误和HTTP状态码的映射
+var errorCodes = map[string]errorCode{
+	"SYSTEM_ERROR":                {ErrSystemError, http.StatusInternalServerError},
+	"ADD_CARD_FAILED":             {ErrAddCardFailed, http.StatusInternalServerError},
+	"CREATE_PAYEE_ACCOUNT_FAILED": {ErrCreatePayeeAccountFailed, http.StatusInternalServerError},
+	"UPDATE_PAYEE_ACCOUNT_FAILED": {ErrUpdatePayeeAccountFailed, http.StatusInternalServerError},
+	"SYSTEM_BUSY":                 {ErrSystemBusy, http.StatusLocked},
+	"NOT_FOUND":                   {ErrNotFound, http.StatusNotFound},
+	"FORBIDDEN":                   {ErrForbidden, http.StatusForbidden},
+	"BAD_REQUEST":                 {ErrBadRequest, http.StatusBadRequest},
+	"MISSING_ARGUMENT":            {ErrMissingArgument, http.StatusBadRequest},
+	"INVALID_ARGUMENT":            {ErrInvalidArgument, http.StatusBadRequest},
+	"INVALID_STATUS":              {ErrInvalidStatus, http.StatusBadRequest},
+	"DUPLICATED":                  {ErrDuplicated, http.StatusBadRequest},
+	"QUOTE_EXPIRE":                {ErrQuoteExpired, http.StatusBadRequest},
+	"ORDER_EXPIRE":                {ErrOrderExpired, http.StatusBadRequest},
+	"INSUFFICIENT_BALANCE":        {ErrInsufficientBalance, http.StatusBadRequest},
+	"RISK_REJECT":                 {ErrRiskReject, http.StatusBadRequest},
+	"USER_AMOUNT_EXCEED_LIMIT":    {ErrUserAmountExceedLimit, http.StatusBadRequest},
+	"USER_BALANCE_NOT_ENOUGH":     {ErrUserBalanceNotEnough, http.StatusBadRequest},
+}
+
+// ErrorCodes 返回文档错误码清单中的所有错误码，按字母排序
+func ErrorCodes() []string {
+	codes := make([]string, 0, len(errorCodes))
+	for code := range errorCodes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// ErrorCodeStatus 返回文档中错误码对应的HTTP状态码，未收录的错误码返回 false
+func ErrorCodeStatus(code string) (int, bool) {
+	entry, ok := errorCodes[code]
+	return entry.status, ok
+}
+
+// statusErrors 未返回错误码时按HTTP状态码匹配的哨兵错误
+var statusErrors = map[int]error{
+	http.StatusInternalServerError: ErrSystemError,
+	http.StatusLocked:              ErrSystemBusy,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusForbidden:           ErrForbidden,
+	http.StatusBadRequest:          ErrBadRequest,
+}
+
+// APIError GSalary接口错误
+// 非200响应和 result.result 不为 S 的200响应都会转换为 APIError
+type APIError struct {
+	HTTPStatus int    // HTTP状态码
+	Result     string // 业务结果：S/F/U
+	Code       string // 错误码
+	Message    string // 错误信息
+	Method     string // 请求方法
+	Endpoint   string // 请求路径
+}
+
+// Error 实现error接口
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gsalary: %s %s: HTTP %d [%s - %s] %s",
+		e.Method, e.Endpoint, e.HTTPStatus, e.Result, e.Code, e.Message)
+}
+
+// Is 支持 errors.Is 匹配哨兵错误：按错误码匹配，错误码不在清单中时按 statusErrors 中的HTTP状态码匹配
+func (e *APIError) Is(target error) bool {
+	if entry, ok := errorCodes[e.Code]; ok {
+		if entry.err == target {
+			return true
+		}
+	} else if sentinel, ok := statusErrors[e.HTTPStatus]; ok && sentinel == target {
+		return true
+	}
+	return target == ErrResultUnknown && e.Result == ResultUnknown
+}
+
+// As 兼容旧版本的 *GSalaryException
+func (e *APIError) As(target interface{}) bool {
+	if exception, ok := target.(**GSalaryException); ok {
+		*exception = &GSalaryException{
+			BizCode:   e.Result,
+			ErrorCode: e.Code,
+			Message:   e.Message,
+		}
+		return true
+	}
+	return false
+}
+
+// NewBusinessError 根据200响应中的业务结果创建 APIError
+func NewBusinessError(request *GSalaryRequest, result, code, message string) *APIError {
+	return &APIError{
+		HTTPStatus: http.StatusOK,
+		Result:     result,
+		Code:       code,
+		Message:    message,
+		Method:     request.Method,
+		Endpoint:   request.Path,
+	}
+}
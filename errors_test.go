@@ -0,0 +1,101 @@
+package gsalary
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestAPIErrorFromErrorResponse 测试非200响应转换为 APIError
+func TestAPIErrorFromErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"biz_result":"F","error_code":"INSUFFICIENT_BALANCE","message":"balance not enough"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(newTestConfig(t, server.URL))
+	_, err := client.Request(NewRequest("GET", "/v1/wallets/balance"))
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T %v", err, err)
+	}
+	if apiErr.HTTPStatus != http.StatusBadRequest || apiErr.Code != "INSUFFICIENT_BALANCE" || apiErr.Endpoint != "/v1/wallets/balance" {
+		t.Errorf("Unexpected APIError: %+v", apiErr)
+	}
+	if !errors.Is(err, ErrInsufficientBalance) {
+		t.Error("Expected errors.Is(err, ErrInsufficientBalance)")
+	}
+	if errors.Is(err, ErrBadRequest) {
+		t.Error("Error code should take precedence over HTTP status")
+	}
+
+	var exception *GSalaryException
+	if !errors.As(err, &exception) || exception.ErrorCode != "INSUFFICIENT_BALANCE" {
+		t.Errorf("Expected legacy GSalaryException, got %v", exception)
+	}
+}
+
+// TestAPIErrorStatusFallback 测试没有错误码时按HTTP状态码匹配
+func TestAPIErrorStatusFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusLocked)
+		w.Write([]byte("busy"))
+	}))
+	defer server.Close()
+
+	client := NewClient(newTestConfig(t, server.URL))
+	_, err := client.Request(NewRequest("GET", "/v1/cards"))
+	if !errors.Is(err, ErrSystemBusy) {
+		t.Errorf("Expected ErrSystemBusy, got %v", err)
+	}
+}
+
+// TestErrorCodeStatus 测试文档错误码清单的HTTP状态码，以及按状态码回退时与错误码清单一致
+func TestErrorCodeStatus(t *testing.T) {
+	codes := ErrorCodes()
+	if len(codes) != len(errorCodes) || codes[0] != "ADD_CARD_FAILED" {
+		t.Fatalf("Unexpected error codes: %v", codes)
+	}
+	for code, want := range map[string]int{
+		"SYSTEM_BUSY":          http.StatusLocked,
+		"ADD_CARD_FAILED":      http.StatusInternalServerError,
+		"INSUFFICIENT_BALANCE": http.StatusBadRequest,
+	} {
+		if got, ok := ErrorCodeStatus(code); !ok || got != want {
+			t.Errorf("ErrorCodeStatus(%s) = %d, %v, want %d", code, got, ok, want)
+		}
+	}
+	if _, ok := ErrorCodeStatus("UNKNOWN_CODE"); ok {
+		t.Error("Expected unknown code to be reported")
+	}
+	for status, sentinel := range statusErrors {
+		var found bool
+		for _, entry := range errorCodes {
+			if entry.err == sentinel {
+				found = entry.status == status
+				break
+			}
+		}
+		if !found {
+			t.Errorf("HTTP %d fallback %v does not match the error code table", status, sentinel)
+		}
+	}
+}
+
+// TestNewBusinessError 测试业务结果错误
+func TestNewBusinessError(t *testing.T) {
+	request := NewRequest("POST", "/v1/exchange/submit_request")
+
+	err := error(NewBusinessError(request, ResultFail, "QUOTE_EXPIRE", "quote expired"))
+	if !errors.Is(err, ErrQuoteExpired) {
+		t.Error("Expected errors.Is(err, ErrQuoteExpired)")
+	}
+
+	err = NewBusinessError(request, ResultUnknown, "", "processing")
+	if !errors.Is(err, ErrResultUnknown) {
+		t.Error("Expected errors.Is(err, ErrResultUnknown)")
+	}
+}
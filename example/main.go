@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"os"
 
 	gsalary "github.com/difyz9/gsalary-sdk-go"
 )
@@ -68,26 +67,11 @@ func main() {
 		printJSON(resp)
 	}
 
-	// 从环境变量加载配置的示例
+	// 从 .env 文件和环境变量加载配置的示例
 	fmt.Println("\n=== 从环境变量加载配置 ===")
-	configFromEnv := gsalary.NewConfig()
-	configFromEnv.AppID = os.Getenv("GSALARY_APPID")
-
-	privateKeyFile := os.Getenv("GSALARY_CLIENT_PRIVATE_KEY_PEM_FILE")
-	if privateKeyFile != "" {
-		if err := configFromEnv.ConfigClientPrivateKeyPEMFile(privateKeyFile); err != nil {
-			log.Printf("Failed to load private key from env: %v", err)
-		}
-	}
-
-	publicKeyFile := os.Getenv("GSALARY_SERVER_PUBLIC_KEY_PEM_FILE")
-	if publicKeyFile != "" {
-		if err := configFromEnv.ConfigServerPublicKeyPEMFile(publicKeyFile); err != nil {
-			log.Printf("Failed to load public key from env: %v", err)
-		}
-	}
-
-	if configFromEnv.AppID != "" && configFromEnv.GetClientPrivateKey() != nil && configFromEnv.GetServerPublicKey() != nil {
+	configFromEnv, report, err := gsalary.LoadConfig(gsalary.FromDotEnv(".env"), gsalary.FromEnv())
+	fmt.Print(report)
+	if err == nil {
 		clientFromEnv := gsalary.NewClient(configFromEnv)
 		fmt.Println("使用环境变量配置创建客户端成功")
 		_ = clientFromEnv
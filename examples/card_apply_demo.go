@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 	"log"
-	"os"
 	"time"
 	
 	gsalary "github.com/difyz9/gsalary-sdk-go"
@@ -11,36 +10,18 @@ import (
 )
 
 func main() {
-	// 1. 配置客户端
-	config := gsalary.NewConfig()
-	
-	// 从环境变量读取配置（推荐）
-	config.AppID = os.Getenv("GSALARY_APPID")
-	if config.AppID == "" {
-		log.Fatal("请设置环境变量 GSALARY_APPID")
-	}
-	
-	config.Endpoint = os.Getenv("GSALARY_ENDPOINT")
-	if config.Endpoint == "" {
-		config.Endpoint = "https://api-test.gsalary.com" // 默认测试环境
-	}
-	
-	// 2. 加载密钥
-	privateKeyFile := os.Getenv("GSALARY_CLIENT_PRIVATE_KEY_FILE")
-	if privateKeyFile == "" {
-		privateKeyFile = "./private_key.pem"
-	}
-	if err := config.ConfigClientPrivateKeyPEMFile(privateKeyFile); err != nil {
-		log.Fatal("加载私钥失败:", err)
+	// 1. 加载配置：默认密钥路径 < .env 文件 < 环境变量（变量名见 .env.example）
+	defaults := gsalary.FromMap("defaults", map[string]string{
+		"client_private_key_file": "./private_key.pem",
+		"server_public_key_file":  "./server_public_key.pem",
+	})
+	config, report, err := gsalary.LoadConfig(defaults, gsalary.FromDotEnv(".env"), gsalary.FromEnv())
+	if err != nil {
+		log.Fatalf("配置检查未通过:\n%s", report)
 	}
 	
-	publicKeyFile := os.Getenv("GSALARY_SERVER_PUBLIC_KEY_FILE")
-	if publicKeyFile == "" {
-		publicKeyFile = "./server_public_key.pem"
-	}
-	if err := config.ConfigServerPublicKeyPEMFile(publicKeyFile); err != nil {
-		log.Fatal("加载服务端公钥失败:", err)
-	}
+	// 2. 输出配置检查报告（包括警告）
+	log.Print(report)
 	
 	// 3. 创建API客户端
 	client := api.NewClient(config)
@@ -161,8 +142,8 @@ func getExchangeRate(client *api.Client) {
 	fmt.Printf("   结果: %s\n", resp.Result.Result)
 	fmt.Printf("   卖出币种: %s\n", resp.Data.SellCurrency)
 	fmt.Printf("   买入币种: %s\n", resp.Data.BuyCurrency)
-	fmt.Printf("   汇率: %.6f\n", resp.Data.Rate)
-	fmt.Printf("   说明: 1 %s = %.6f %s\n", resp.Data.SellCurrency, resp.Data.Rate, resp.Data.BuyCurrency)
+	fmt.Printf("   汇率: %s\n", resp.Data.Rate)
+	fmt.Printf("   说明: 1 %s = %s %s\n", resp.Data.SellCurrency, resp.Data.Rate, resp.Data.BuyCurrency)
 	fmt.Printf("   更新时间: %s\n", resp.Data.UpdateTime)
 }
 
@@ -171,10 +152,10 @@ func requestExchangeQuote(client *api.Client) {
 	req := &api.ExchangeQuoteRequest{
 		BuyCurrency:  "HKD",
 		SellCurrency: "USD",
-		SellAmount:   100.00,
+		SellAmount:   api.MustParseDecimal("100.00"),
 	}
 	
-	fmt.Printf("请求锁汇报价: 卖出 %s %.2f，换取 %s\n", 
+	fmt.Printf("请求锁汇报价: 卖出 %s %s，换取 %s\n", 
 		req.SellCurrency, req.SellAmount, req.BuyCurrency)
 	fmt.Println()
 	
@@ -194,10 +175,10 @@ func requestExchangeQuote(client *api.Client) {
 	fmt.Println("✅ 请求锁汇报价成功!")
 	fmt.Printf("   结果: %s\n", resp.Result.Result)
 	fmt.Printf("   报价ID: %s\n", resp.Data.QuoteID)
-	fmt.Printf("   买入: %s %.2f\n", resp.Data.Buy.Currency, resp.Data.Buy.Amount)
-	fmt.Printf("   卖出: %s %.2f\n", resp.Data.Sell.Currency, resp.Data.Sell.Amount)
-	fmt.Printf("   手续费: %s %.2f\n", resp.Data.Surcharge.Currency, resp.Data.Surcharge.Amount)
-	fmt.Printf("   总成本: %s %.2f\n", resp.Data.TotalCost.Currency, resp.Data.TotalCost.Amount)
+	fmt.Printf("   买入: %s %s\n", resp.Data.Buy.Currency, resp.Data.Buy.Amount)
+	fmt.Printf("   卖出: %s %s\n", resp.Data.Sell.Currency, resp.Data.Sell.Amount)
+	fmt.Printf("   手续费: %s %s\n", resp.Data.Surcharge.Currency, resp.Data.Surcharge.Amount)
+	fmt.Printf("   总成本: %s %s\n", resp.Data.TotalCost.Currency, resp.Data.TotalCost.Amount)
 	fmt.Printf("   更新时间: %s\n", resp.Data.UpdateTime)
 	fmt.Printf("   过期时间: %s\n", resp.Data.ExpireTime)
 	fmt.Println()
@@ -229,9 +210,9 @@ func getWalletBalance(client *api.Client) {
 	fmt.Println("✅ 查询钱包余额成功!")
 	fmt.Printf("   结果: %s\n", resp.Result.Result)
 	fmt.Printf("   币种: %s\n", resp.Data.Currency)
-	fmt.Printf("   总金额: %.2f\n", resp.Data.Amount)
-	fmt.Printf("   共享卡账户余额: %.2f\n", resp.Data.ShareCardAccountBalance)
-	fmt.Printf("   可用余额: %.2f\n", resp.Data.Available)
+	fmt.Printf("   总金额: %s\n", resp.Data.Amount)
+	fmt.Printf("   共享卡账户余额: %s\n", resp.Data.ShareCardAccountBalance)
+	fmt.Printf("   可用余额: %s\n", resp.Data.Available)
 	fmt.Printf("   账户类型: %s\n", resp.Data.AccountType)
 	fmt.Printf("   查询时间: %s\n", resp.Data.QueryTime)
 }
@@ -457,17 +438,17 @@ func applyCard(client *api.Client, holderID string) string {
 		ProductCode:         "VIRTUAL_CARD_USD", // 虚拟卡产品代码
 		Currency:            "USD",               // 美元
 		CardHolderID:        holderID,            // 持卡人ID
-		LimitPerDay:         1000.00,             // 每日限额
-		LimitPerMonth:       5000.00,             // 每月限额
-		LimitPerTransaction: 500.00,              // 单笔限额
-		InitBalance:         100.00,              // 初始余额100美元
+		LimitPerDay:         api.MustParseDecimal("1000.00"),             // 每日限额
+		LimitPerMonth:       api.MustParseDecimal("5000.00"),             // 每月限额
+		LimitPerTransaction: api.MustParseDecimal("500.00"),              // 单笔限额
+		InitBalance:         api.MustParseDecimal("100.00"),              // 初始余额100美元
 	}
 	
 	fmt.Printf("请求ID: %s\n", req.RequestID)
 	fmt.Printf("产品代码: %s\n", req.ProductCode)
 	fmt.Printf("货币: %s\n", req.Currency)
 	fmt.Printf("持卡人ID: %s\n", req.CardHolderID)
-	fmt.Printf("初始余额: %.2f\n", req.InitBalance)
+	fmt.Printf("初始余额: %s\n", req.InitBalance)
 	fmt.Println()
 	
 	// 发起申请
@@ -523,7 +504,7 @@ func getAvailableQuotas(client *api.Client) {
 	fmt.Printf("   消息: %s\n", resp.Result.Message)
 	fmt.Printf("   货币: %s\n", resp.Data.Currency)
 	fmt.Printf("   卡账务类型: %s\n", resp.Data.AccountingCardType)
-	fmt.Printf("   可用余额: %.2f\n", resp.Data.AvailableQuota)
+	fmt.Printf("   可用余额: %s\n", resp.Data.AvailableQuota)
 }
 
 func getProducts(client *api.Client) {
@@ -629,7 +610,7 @@ func getCardInfo(client *api.Client, cardID string) {
 	fmt.Printf("  卡片名称: %s\n", resp.Data.CardName)
 	fmt.Printf("  掩码卡号: %s\n", resp.Data.MaskCardNumber)
 	fmt.Printf("  币种: %s\n", resp.Data.CardCurrency)
-	fmt.Printf("  可用余额: %.2f\n", resp.Data.AvailableBalance)
+	fmt.Printf("  可用余额: %s\n", resp.Data.AvailableBalance)
 	fmt.Printf("  品牌: %s\n", resp.Data.BrandCode)
 	fmt.Printf("  状态: %s\n", resp.Data.Status)
 	fmt.Printf("  卡类型: %s\n", resp.Data.CardType)
@@ -649,9 +630,9 @@ func getCardInfo(client *api.Client, cardID string) {
 		fmt.Printf("  手机号: %v\n", resp.Data.Mobile)
 	}
 	
-	fmt.Printf("  每日限额: %.2f\n", resp.Data.LimitPerDay)
-	fmt.Printf("  每月限额: %.2f\n", resp.Data.LimitPerMonth)
-	fmt.Printf("  单笔限额: %.2f\n", resp.Data.LimitPerTransaction)
+	fmt.Printf("  每日限额: %s\n", resp.Data.LimitPerDay)
+	fmt.Printf("  每月限额: %s\n", resp.Data.LimitPerMonth)
+	fmt.Printf("  单笔限额: %s\n", resp.Data.LimitPerTransaction)
 	fmt.Printf("  支持3DS交易: %v\n", resp.Data.SupportTdsTrans)
 	fmt.Printf("  创建时间: %s\n", resp.Data.CreateTime)
 	
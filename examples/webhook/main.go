@@ -5,43 +5,24 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"os"
 	
 	gsalary "github.com/difyz9/gsalary-sdk-go"
 	"github.com/difyz9/gsalary-sdk-go/api"
 )
 
 func main() {
-	// 1. 配置客户端
-	config := gsalary.NewConfig()
-	
-	// 从环境变量读取配置（推荐）
-	config.AppID = os.Getenv("GSALARY_APPID")
-	if config.AppID == "" {
-		log.Fatal("请设置环境变量 GSALARY_APPID")
-	}
-	
-	config.Endpoint = os.Getenv("GSALARY_ENDPOINT")
-	if config.Endpoint == "" {
-		config.Endpoint = "https://api-test.gsalary.com"
-	}
-	
-	// 2. 加载密钥
-	privateKeyFile := os.Getenv("GSALARY_CLIENT_PRIVATE_KEY_FILE")
-	if privateKeyFile == "" {
-		privateKeyFile = "../../private_key.pem"
-	}
-	if err := config.ConfigClientPrivateKeyPEMFile(privateKeyFile); err != nil {
-		log.Fatal("加载私钥失败:", err)
+	// 1. 加载配置：默认密钥路径 < .env 文件 < 环境变量（变量名见 .env.example）
+	defaults := gsalary.FromMap("defaults", map[string]string{
+		"client_private_key_file": "../../private_key.pem",
+		"server_public_key_file":  "../../server_public_key.pem",
+	})
+	config, report, err := gsalary.LoadConfig(defaults, gsalary.FromDotEnv(".env"), gsalary.FromEnv())
+	if err != nil {
+		log.Fatalf("配置检查未通过:\n%s", report)
 	}
 	
-	publicKeyFile := os.Getenv("GSALARY_SERVER_PUBLIC_KEY_FILE")
-	if publicKeyFile == "" {
-		publicKeyFile = "../../server_public_key.pem"
-	}
-	if err := config.ConfigServerPublicKeyPEMFile(publicKeyFile); err != nil {
-		log.Fatal("加载服务端公钥失败:", err)
-	}
+	// 2. 输出配置检查报告（包括警告）
+	log.Print(report)
 	
 	// 3. 创建Webhook处理器
 	webhookHandler := api.NewWebhookHandler(config)
@@ -0,0 +1,356 @@
+// Package fault 提供故障注入的 http.RoundTripper，用于验证重试、熔断和对账逻辑
+//
+//	transport := fault.NewTransport(nil,
+//		fault.WithSeed(42),
+//		fault.WithRules(
+//			fault.Rule{Method: "POST", Path: "/v1/cards/balance_modifies", Fault: fault.UnknownResult, Times: 1},
+//			fault.Rule{Path: "/v1/cards", Fault: fault.Locked, Probability: 0.2},
+//			fault.Rule{Fault: fault.Latency, Latency: 50 * time.Millisecond},
+//		),
+//	)
+//	client := gsalary.NewClient(config, gsalary.WithTransport(transport), gsalary.WithRetryPolicy(policy))
+//
+// 每个请求按顺序检查所有规则：触发的 Latency 规则累加等待时间，其余故障只生效第一个触发的。
+// 随机触发使用可指定种子的随机数，测试失败时可以用 Seed() 记录的种子复现。
+package fault
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+)
+
+// Fault 故障类型
+type Fault int
+
+const (
+	// Latency 发送请求前等待 Rule.Latency，可与其他故障叠加
+	Latency Fault = iota + 1
+	// ConnReset 连接被重置：默认请求已送达服务端但客户端收不到响应，Rule.BeforeSend 时请求未发出
+	ConnReset
+	// TruncatedBody 响应体只返回前一半，随后读取返回 io.ErrUnexpectedEOF
+	TruncatedBody
+	// Locked 不发送请求，返回 423 SYSTEM_BUSY
+	Locked
+	// ServerError 不发送请求，返回 500 SYSTEM_ERROR
+	ServerError
+	// BadSignature 请求正常处理，篡改响应的签名
+	BadSignature
+	// UnknownResult 请求正常处理，响应替换为 result=U；需要 WithResigner 才能通过验签
+	UnknownResult
+)
+
+// String 返回故障名称
+func (f Fault) String() string {
+	switch f {
+	case Latency:
+		return "latency"
+	case ConnReset:
+		return "conn_reset"
+	case TruncatedBody:
+		return "truncated_body"
+	case Locked:
+		return "locked"
+	case ServerError:
+		return "server_error"
+	case BadSignature:
+		return "bad_signature"
+	case UnknownResult:
+		return "unknown_result"
+	}
+	return "fault(" + strconv.Itoa(int(f)) + ")"
+}
+
+// Rule 故障规则
+//
+// Probability 为0时每次匹配都触发，否则按概率随机触发；Skip 和 Times 用于确定性地
+// 控制触发时机，如 Skip: 1, Times: 1 表示只有第二次匹配的请求触发。
+type Rule struct {
+	Method      string        // 请求方法，空表示任意方法
+	Path        string        // 请求路径前缀，空表示任意路径
+	Fault       Fault         // 故障类型
+	Probability float64       // 触发概率，0表示总是触发
+	Skip        int           // 前 Skip 次匹配不触发
+	Times       int           // 最多触发次数，0表示不限
+	Latency     time.Duration // Latency 故障的等待时长
+	BeforeSend  bool          // ConnReset 是否在发送请求前发生
+}
+
+// Injection 一次已注入的故障
+type Injection struct {
+	Method string
+	Path   string
+	Fault  Fault
+}
+
+// Transport 故障注入传输
+type Transport struct {
+	base     http.RoundTripper
+	seed     int64
+	resigner gsalary.Signer
+
+	mu         sync.Mutex
+	rng        *rand.Rand
+	rules      []*ruleState
+	injections []Injection
+}
+
+// ruleState 规则及其计数
+type ruleState struct {
+	Rule
+	matched int
+	fired   int
+}
+
+// Option 故障注入传输选项
+type Option func(*Transport)
+
+// WithRules 添加故障规则
+func WithRules(rules ...Rule) Option {
+	return func(t *Transport) {
+		for _, rule := range rules {
+			t.rules = append(t.rules, &ruleState{Rule: rule})
+		}
+	}
+}
+
+// WithSeed 设置随机数种子，默认使用当前时间
+func WithSeed(seed int64) Option {
+	return func(t *Transport) {
+		t.seed = seed
+	}
+}
+
+// WithResigner 用服务端签名器对 UnknownResult 改写后的响应重新签名
+// 例如 gsalarytest.Server 的 ServerSigner()
+func WithResigner(signer gsalary.Signer) Option {
+	return func(t *Transport) {
+		t.resigner = signer
+	}
+}
+
+// NewTransport 创建故障注入传输，base 为 nil 时使用 http.DefaultTransport
+func NewTransport(base http.RoundTripper, opts ...Option) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &Transport{base: base, seed: time.Now().UnixNano()}
+	for _, opt := range opts {
+		opt(t)
+	}
+	t.rng = rand.New(rand.NewSource(t.seed))
+	return t
+}
+
+// Seed 返回随机数种子，用于复现随机注入的结果
+func (t *Transport) Seed() int64 {
+	return t.seed
+}
+
+// AddRule 追加故障规则
+func (t *Transport) AddRule(rule Rule) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rules = append(t.rules, &ruleState{Rule: rule})
+}
+
+// Injections 返回已注入的故障，按发生顺序排列
+func (t *Transport) Injections() []Injection {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Injection(nil), t.injections...)
+}
+
+// RoundTrip 实现 http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	latency, rule := t.pick(req)
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			closeBody(req)
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+	if rule == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	switch {
+	case rule.Fault == Locked:
+		closeBody(req)
+		return errorResponse(req, http.StatusLocked, "SYSTEM_BUSY"), nil
+	case rule.Fault == ServerError:
+		closeBody(req)
+		return errorResponse(req, http.StatusInternalServerError, "SYSTEM_ERROR"), nil
+	case rule.Fault == ConnReset && rule.BeforeSend:
+		closeBody(req)
+		return nil, connReset()
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	switch rule.Fault {
+	case ConnReset:
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return nil, connReset()
+	case TruncatedBody:
+		body, err := readBody(resp)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = &truncatedBody{Reader: bytes.NewReader(body[:len(body)/2])}
+		resp.ContentLength = int64(len(body))
+	case BadSignature:
+		header := gsalary.FromHeaderValue(resp.Header.Get("Authorization"))
+		signature, _ := base64.StdEncoding.DecodeString(header.Signature)
+		if len(signature) == 0 {
+			signature = []byte{0}
+		}
+		signature[0] ^= 0xff
+		header.Signature = base64.StdEncoding.EncodeToString(signature)
+		if header.Algorithm == "" {
+			header.Algorithm = gsalary.AlgorithmRSA2
+		}
+		resp.Header.Set("Authorization", header.ToHeaderValue())
+	case UnknownResult:
+		if _, err := readBody(resp); err != nil {
+			return nil, err
+		}
+		body := []byte(`{"result":{"result":"U","code":"","message":"fault: result unknown"}}`)
+		resp.StatusCode, resp.Status = http.StatusOK, "200 OK"
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		resp.ContentLength = int64(len(body))
+		resp.Header.Del("Content-Length")
+		if err := t.resign(req, resp, body); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// pick 更新规则计数，返回累计的等待时间和第一个触发的非 Latency 规则
+func (t *Transport) pick(req *http.Request) (time.Duration, *Rule) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var latency time.Duration
+	var picked *Rule
+	for _, rule := range t.rules {
+		if rule.Method != "" && !strings.EqualFold(rule.Method, req.Method) {
+			continue
+		}
+		if !strings.HasPrefix(req.URL.Path, rule.Path) {
+			continue
+		}
+		rule.matched++
+		if rule.Fault != Latency && picked != nil {
+			continue
+		}
+		if rule.matched <= rule.Skip || (rule.Times > 0 && rule.fired >= rule.Times) {
+			continue
+		}
+		if rule.Probability > 0 && t.rng.Float64() >= rule.Probability {
+			continue
+		}
+		rule.fired++
+		t.injections = append(t.injections, Injection{Method: req.Method, Path: req.URL.Path, Fault: rule.Fault})
+		if rule.Fault == Latency {
+			latency += rule.Latency
+			continue
+		}
+		r := rule.Rule
+		picked = &r
+	}
+	return latency, picked
+}
+
+// resign 用服务端签名器对改写后的响应重新签名，沿用原响应的时间戳
+func (t *Transport) resign(req *http.Request, resp *http.Response, body []byte) error {
+	if t.resigner == nil {
+		return nil
+	}
+	timestamp := gsalary.FromHeaderValue(resp.Header.Get("Authorization")).Timestamp
+	if timestamp == "" {
+		timestamp = strconv.FormatInt(time.Now().UnixMilli(), 10)
+	}
+	header, err := gsalary.SignResponse(req.Context(), t.resigner, req.Method, gsalary.CanonicalPath(req.URL),
+		req.Header.Get("X-Appid"), timestamp, body)
+	if err != nil {
+		return fmt.Errorf("fault: resign response: %w", err)
+	}
+	resp.Header.Set("Authorization", header.ToHeaderValue())
+	return nil
+}
+
+// truncatedBody 读完截断的内容后返回 io.ErrUnexpectedEOF
+type truncatedBody struct {
+	*bytes.Reader
+}
+
+func (b *truncatedBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (b *truncatedBody) Close() error {
+	return nil
+}
+
+// readBody 读出并关闭响应体
+func readBody(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// errorResponse 构造不经过服务端的错误响应
+func errorResponse(req *http.Request, status int, code string) *http.Response {
+	body, _ := json.Marshal(map[string]string{
+		"biz_result": gsalary.ResultFail,
+		"error_code": code,
+		"message":    "fault: injected " + strings.ToLower(http.StatusText(status)),
+	})
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// connReset 构造与真实连接重置相同的错误，errors.Is(err, syscall.ECONNRESET) 为 true
+func connReset() error {
+	return &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+}
+
+// closeBody 未发送的请求也需要关闭请求体
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
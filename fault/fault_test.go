@@ -0,0 +1,181 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+	"github.com/difyz9/gsalary-sdk-go/api"
+	"github.com/difyz9/gsalary-sdk-go/gsalarytest"
+)
+
+// fastRetryPolicy 测试使用的快速重试策略
+func fastRetryPolicy(attempts int) gsalary.RetryPolicy {
+	return gsalary.RetryPolicy{
+		MaxAttempts:    attempts,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Multiplier:     2,
+		RetryOnUnknown: true,
+	}
+}
+
+// newServer 创建返回余额的模拟服务端
+func newServer(t *testing.T) *gsalarytest.Server {
+	server := gsalarytest.NewServer(t)
+	server.Handle("GET", "/v1/wallets/balance", func(r *gsalarytest.Request) *gsalarytest.Response {
+		return gsalarytest.OK(map[string]interface{}{"currency": "USD", "available": 100})
+	})
+	server.Handle("GET", "/v1/cards/{card_id}", func(r *gsalarytest.Request) *gsalarytest.Response {
+		return gsalarytest.OK(map[string]string{"card_id": r.Params["card_id"]})
+	})
+	return server
+}
+
+// TestDeterministicFaults 测试 Skip/Times 控制的423/500由客户端重试恢复
+func TestDeterministicFaults(t *testing.T) {
+	server := newServer(t)
+	transport := NewTransport(nil, WithRules(
+		Rule{Method: "GET", Path: "/v1/wallets", Fault: Locked, Times: 1},
+		Rule{Path: "/v1/wallets", Fault: ServerError, Skip: 1, Times: 1},
+	))
+	client := gsalary.NewClient(server.Config(), gsalary.WithTransport(transport), gsalary.WithRetryPolicy(fastRetryPolicy(3)))
+
+	if _, err := client.Request(gsalary.NewRequest("GET", "/v1/wallets/balance")); err != nil {
+		t.Fatalf("Expected retries to recover, got %v", err)
+	}
+	if _, err := client.Request(gsalary.NewRequest("GET", "/v1/cards/card-1")); err != nil {
+		t.Fatalf("Expected unmatched path to pass through, got %v", err)
+	}
+	want := []Injection{
+		{Method: "GET", Path: "/v1/wallets/balance", Fault: Locked},
+		{Method: "GET", Path: "/v1/wallets/balance", Fault: ServerError},
+	}
+	if got := transport.Injections(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected injections %v, got %v", want, got)
+	}
+	if n := len(server.Requests()); n != 2 {
+		t.Errorf("Expected 2 requests to reach the server, got %d", n)
+	}
+
+	client = gsalary.NewClient(server.Config(), gsalary.WithTransport(transport), gsalary.WithRetryPolicy(fastRetryPolicy(1)))
+	transport.AddRule(Rule{Path: "/v1/cards", Fault: Locked})
+	_, err := client.Request(gsalary.NewRequest("GET", "/v1/cards/card-1"))
+	var apiErr *gsalary.APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatus != http.StatusLocked || apiErr.Code != "SYSTEM_BUSY" {
+		t.Errorf("Expected 423 SYSTEM_BUSY, got %v", err)
+	}
+}
+
+// TestConnReset 测试连接重置发生在请求送达前后
+func TestConnReset(t *testing.T) {
+	server := newServer(t)
+	transport := NewTransport(nil, WithRules(
+		Rule{Path: "/v1/wallets", Fault: ConnReset, Times: 1},
+		Rule{Path: "/v1/cards", Fault: ConnReset, BeforeSend: true, Times: 1},
+	))
+	client := gsalary.NewClient(server.Config(), gsalary.WithTransport(transport), gsalary.WithRetryPolicy(fastRetryPolicy(1)))
+
+	if _, err := client.Request(gsalary.NewRequest("GET", "/v1/wallets/balance")); !errors.Is(err, syscall.ECONNRESET) {
+		t.Errorf("Expected ECONNRESET, got %v", err)
+	}
+	if n := len(server.Requests()); n != 1 {
+		t.Errorf("Expected the reset request to reach the server, got %d requests", n)
+	}
+	if _, err := client.Request(gsalary.NewRequest("GET", "/v1/cards/card-1")); !errors.Is(err, syscall.ECONNRESET) {
+		t.Errorf("Expected ECONNRESET, got %v", err)
+	}
+	if n := len(server.Requests()); n != 1 {
+		t.Errorf("Expected the request reset before sending not to reach the server, got %d requests", n)
+	}
+}
+
+// TestResponseFaults 测试截断的响应体、篡改的签名和 U 结果
+func TestResponseFaults(t *testing.T) {
+	server := newServer(t)
+	transport := NewTransport(nil, WithResigner(server.ServerSigner()), WithRules(
+		Rule{Path: "/v1/wallets", Fault: TruncatedBody, Times: 1},
+		Rule{Path: "/v1/wallets", Fault: BadSignature, Times: 1},
+		Rule{Path: "/v1/wallets", Fault: UnknownResult, Times: 1},
+	))
+	client := gsalary.NewClient(server.Config(), gsalary.WithTransport(transport), gsalary.WithRetryPolicy(fastRetryPolicy(1)))
+	request := gsalary.NewRequest("GET", "/v1/wallets/balance")
+
+	if _, err := client.Request(request); err == nil || !strings.Contains(err.Error(), "read response body") {
+		t.Errorf("Expected truncated body error, got %v", err)
+	}
+	if _, err := client.Request(request); err == nil || !strings.Contains(err.Error(), "signature verification failed") {
+		t.Errorf("Expected signature verification error, got %v", err)
+	}
+
+	apiClient := api.NewClient(server.Config(), gsalary.WithTransport(transport), gsalary.WithRetryPolicy(fastRetryPolicy(1)))
+	if _, err := apiClient.Wallet.GetBalance(&api.WalletBalanceRequest{Currency: "USD"}); !errors.Is(err, gsalary.ErrResultUnknown) {
+		t.Errorf("Expected re-signed U result, got %v", err)
+	}
+
+	transport.AddRule(Rule{Path: "/v1/wallets", Fault: UnknownResult, Times: 1})
+	retrying := api.NewClient(server.Config(), gsalary.WithTransport(transport), gsalary.WithRetryPolicy(fastRetryPolicy(2)))
+	if _, err := retrying.Wallet.GetBalance(&api.WalletBalanceRequest{Currency: "USD"}); err != nil {
+		t.Errorf("Expected retry after U result to succeed, got %v", err)
+	}
+}
+
+// TestLatency 测试延迟可叠加其他故障并遵守请求的超时
+func TestLatency(t *testing.T) {
+	server := newServer(t)
+	transport := NewTransport(nil, WithRules(
+		Rule{Fault: Latency, Latency: 20 * time.Millisecond},
+		Rule{Path: "/v1/cards", Fault: Latency, Latency: time.Second},
+	))
+	client := gsalary.NewClient(server.Config(), gsalary.WithTransport(transport), gsalary.WithRetryPolicy(fastRetryPolicy(1)))
+
+	start := time.Now()
+	if _, err := client.Request(gsalary.NewRequest("GET", "/v1/wallets/balance")); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Expected at least 20ms latency, got %v", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.RequestCtx(ctx, gsalary.NewRequest("GET", "/v1/cards/card-1")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if n := len(server.Requests()); n != 1 {
+		t.Errorf("Expected the timed out request not to reach the server, got %d requests", n)
+	}
+}
+
+// TestSeedReproducible 测试相同种子的随机注入结果一致
+func TestSeedReproducible(t *testing.T) {
+	server := newServer(t)
+	run := func(seed int64) []Injection {
+		transport := NewTransport(nil, WithSeed(seed), WithRules(
+			Rule{Fault: Locked, Probability: 0.3},
+			Rule{Fault: ServerError, Probability: 0.3},
+		))
+		if transport.Seed() != seed {
+			t.Fatalf("Expected seed %d, got %d", seed, transport.Seed())
+		}
+		client := gsalary.NewClient(server.Config(), gsalary.WithTransport(transport), gsalary.WithRetryPolicy(fastRetryPolicy(1)))
+		for i := 0; i < 20; i++ {
+			client.Request(gsalary.NewRequest("GET", "/v1/wallets/balance"))
+		}
+		return transport.Injections()
+	}
+
+	first, second := run(42), run(42)
+	if len(first) == 0 || len(first) == 20 {
+		t.Fatalf("Expected some but not all requests to fail, got %d injections", len(first))
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Expected identical injections for the same seed:\n%v\n%v", first, second)
+	}
+}
@@ -0,0 +1,102 @@
+package gsalarytest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+)
+
+// HandlerFunc 路由处理函数，返回 nil 等价于 OK(nil)
+type HandlerFunc func(r *Request) *Response
+
+// Request 通过验签的请求
+type Request struct {
+	Method string
+	Path   string
+	Params map[string]string // 路由中 {name} 对应的路径段
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+	AppID  string
+}
+
+// Decode 将请求体按JSON解码到 v
+func (r *Request) Decode(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Response 模拟响应，写出前由服务端签名
+type Response struct {
+	StatusCode int // 0 表示 200
+	Header     http.Header
+	Body       []byte
+}
+
+// ErrorCodes 返回文档错误码清单中的所有错误码，见 gsalary.ErrorCodes
+func ErrorCodes() []string {
+	return gsalary.ErrorCodes()
+}
+
+// StatusForCode 返回错误码对应的HTTP状态码，未知错误码返回400
+func StatusForCode(code string) int {
+	if status, ok := gsalary.ErrorCodeStatus(code); ok {
+		return status
+	}
+	return http.StatusBadRequest
+}
+
+// OK 返回 result 为 S 的200响应，data 为 nil 时不输出 data 字段
+func OK(data interface{}) *Response {
+	return result(gsalary.ResultSuccess, "", "", data)
+}
+
+// Fail 返回 result 为 F 的200响应（请求被拒绝，无需重试）
+func Fail(code, message string) *Response {
+	return result(gsalary.ResultFail, code, message, nil)
+}
+
+// Unknown 返回 result 为 U 的200响应（结果未知，需稍后查询）
+func Unknown(message string) *Response {
+	return result(gsalary.ResultUnknown, "", message, nil)
+}
+
+// Error 返回文档错误码对应HTTP状态码的错误响应
+func Error(code, message string) *Response {
+	return ErrorStatus(StatusForCode(code), code, message)
+}
+
+// ErrorStatus 返回指定HTTP状态码的错误响应：{"biz_result":"F","error_code":...,"message":...}
+func ErrorStatus(status int, code, message string) *Response {
+	return JSON(status, map[string]string{
+		"biz_result": gsalary.ResultFail,
+		"error_code": code,
+		"message":    message,
+	})
+}
+
+// JSON 返回任意JSON响应
+func JSON(status int, v interface{}) *Response {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return Raw(http.StatusInternalServerError, `{"biz_result":"F","error_code":"SYSTEM_ERROR","message":"gsalarytest: encode response failed"}`)
+	}
+	return &Response{StatusCode: status, Body: body}
+}
+
+// Raw 返回原样的响应体
+func Raw(status int, body string) *Response {
+	return &Response{StatusCode: status, Body: []byte(body)}
+}
+
+// result 构造标准响应信封
+func result(res, code, message string, data interface{}) *Response {
+	envelope := map[string]interface{}{
+		"result": map[string]string{"result": res, "code": code, "message": message},
+	}
+	if data != nil {
+		envelope["data"] = data
+	}
+	return JSON(http.StatusOK, envelope)
+}
@@ -0,0 +1,323 @@
+// Package gsalarytest 提供进程内的GSalary模拟服务端，用于测试基于本SDK的代码
+//
+//	server := gsalarytest.NewServer(t)
+//	server.Handle("GET", "/v1/wallets/balance", func(r *gsalarytest.Request) *gsalarytest.Response {
+//		return gsalarytest.OK(map[string]interface{}{"currency": r.Query.Get("currency"), "amount": 100})
+//	})
+//	client := api.NewClient(server.Config())
+//
+// 服务端用配置的客户端公钥验证请求签名（含 X-Appid 和时间戳），并用自己生成的服务端私钥对所有响应签名，
+// Config 返回的配置已经包含匹配的客户端私钥和服务端公钥。
+package gsalarytest
+
+import (
+	"crypto/rsa"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+)
+
+// DefaultAppID Config 使用的默认AppID
+const DefaultAppID = "gsalarytest-app"
+
+var (
+	keysOnce  sync.Once
+	clientKey *rsa.PrivateKey
+	serverKey *rsa.PrivateKey
+	keysErr   error
+)
+
+// testKeys 生成进程内共享的测试密钥对，避免每个测试都生成RSA密钥
+func testKeys() (*rsa.PrivateKey, *rsa.PrivateKey, error) {
+	keysOnce.Do(func() {
+		if clientKey, keysErr = gsalary.GenerateKeyPair(0); keysErr != nil {
+			return
+		}
+		serverKey, keysErr = gsalary.GenerateKeyPair(0)
+	})
+	return clientKey, serverKey, keysErr
+}
+
+// Server 模拟GSalary服务端
+type Server struct {
+	// URL 服务地址，如 http://127.0.0.1:12345
+	URL string
+	// AppID 服务端接受的 X-Appid
+	AppID string
+
+	server    *httptest.Server
+	clientKey *rsa.PrivateKey
+	serverKey *rsa.PrivateKey
+	verifier  gsalary.Verifier
+	signer    gsalary.Signer
+	now       func() time.Time
+
+	mu       sync.Mutex
+	routes   []*route
+	requests []*Request
+	closers  []func()
+}
+
+// Option 服务端选项
+type Option func(*Server)
+
+// WithAppID 设置服务端接受的AppID，默认为 DefaultAppID
+func WithAppID(appID string) Option {
+	return func(s *Server) {
+		s.AppID = appID
+	}
+}
+
+// WithClientKey 使用指定的客户端私钥，服务端用其公钥验签
+func WithClientKey(key *rsa.PrivateKey) Option {
+	return func(s *Server) {
+		s.clientKey = key
+	}
+}
+
+// WithClock 设置服务端时钟，用于响应签名时间和请求时间戳检查
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// NewServer 启动模拟服务端，测试结束时自动关闭
+func NewServer(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+	defaultClientKey, defaultServerKey, err := testKeys()
+	if err != nil {
+		t.Fatalf("gsalarytest: generate keys: %v", err)
+	}
+	s := &Server{
+		AppID:     DefaultAppID,
+		clientKey: defaultClientKey,
+		serverKey: defaultServerKey,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.verifier = gsalary.NewRSAVerifier(&s.clientKey.PublicKey)
+	s.signer = gsalary.NewRSASigner(s.serverKey)
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Close 关闭服务端，并停止挂在服务端上的后台任务（如模拟器的Webhook推送）
+func (s *Server) Close() {
+	s.mu.Lock()
+	closers := s.closers
+	s.closers = nil
+	s.mu.Unlock()
+	for _, closer := range closers {
+		closer()
+	}
+	s.server.Close()
+}
+
+// onClose 注册 Close 时执行的清理函数
+func (s *Server) onClose(closer func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closers = append(s.closers, closer)
+}
+
+// Config 返回指向该服务端的配置，包含客户端私钥和服务端公钥
+func (s *Server) Config() *gsalary.GSalaryConfig {
+	config := gsalary.NewConfig()
+	config.AppID = s.AppID
+	config.Endpoint = s.URL
+	config.Clock = s.now
+	privatePEM, err := gsalary.MarshalPrivateKeyPEM(s.clientKey)
+	if err == nil {
+		err = config.ConfigClientPrivateKey(privatePEM, nil)
+	}
+	if err != nil {
+		panic("gsalarytest: configure client key: " + err.Error())
+	}
+	config.ConfigVerifier(gsalary.NewRSAVerifier(&s.serverKey.PublicKey))
+	return config
+}
+
+// ServerPublicKey 返回服务端公钥
+func (s *Server) ServerPublicKey() *rsa.PublicKey {
+	return &s.serverKey.PublicKey
+}
+
+// ServerSigner 返回服务端签名器，可用于对改写后的响应重新签名（如 fault.WithResigner）
+func (s *Server) ServerSigner() gsalary.Signer {
+	return s.signer
+}
+
+// Handle 注册路由，pattern 中的 {name} 匹配一个路径段，如 /v1/cards/{card_id}
+// 同一方法和 pattern 重复注册时替换原有处理函数；固定路径段优先于参数段
+func (s *Server) Handle(method, pattern string, handler HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.routes {
+		if r.method == method && r.pattern == pattern {
+			r.handler = handler
+			return
+		}
+	}
+	s.routes = append(s.routes, &route{
+		method:   method,
+		pattern:  pattern,
+		segments: strings.Split(pattern, "/"),
+		handler:  handler,
+	})
+}
+
+// Requests 返回通过验签的请求，按到达顺序排列
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Request(nil), s.requests...)
+}
+
+// serveHTTP 验签、分发路由并签名响应
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.write(w, r, ErrorStatus(http.StatusBadRequest, "BAD_REQUEST", "read body failed"))
+		return
+	}
+	if resp := s.authenticate(r, body); resp != nil {
+		s.write(w, r, resp)
+		return
+	}
+
+	request := &Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+		AppID:  r.Header.Get("X-Appid"),
+	}
+	s.mu.Lock()
+	s.requests = append(s.requests, request)
+	handler, params := s.match(r.Method, r.URL.Path)
+	s.mu.Unlock()
+
+	if handler == nil {
+		s.write(w, r, Error("NOT_FOUND", "no route for "+r.Method+" "+r.URL.Path))
+		return
+	}
+	request.Params = params
+	resp := handler(request)
+	if resp == nil {
+		resp = OK(nil)
+	}
+	s.write(w, r, resp)
+}
+
+// authenticate 检查 X-Appid、签名和时间戳，失败时返回错误响应
+func (s *Server) authenticate(r *http.Request, body []byte) *Response {
+	appID := r.Header.Get("X-Appid")
+	if appID != s.AppID {
+		return Error("FORBIDDEN", "unknown appid "+strconv.Quote(appID))
+	}
+	header := gsalary.FromHeaderValue(r.Header.Get("Authorization"))
+	err := gsalary.VerifyRequest(r.Context(), s.verifier, r.Method, gsalary.CanonicalPath(r.URL), appID, header, body)
+	if err != nil {
+		return ErrorStatus(http.StatusUnauthorized, "INVALID_SIGNATURE", "signature verification failed: "+err.Error())
+	}
+	signedAt, err := gsalary.ParseTimestamp(header.Timestamp)
+	if err != nil {
+		return ErrorStatus(http.StatusUnauthorized, "INVALID_SIGNATURE", err.Error())
+	}
+	if diff := s.now().Sub(signedAt); diff > gsalary.DefaultMaxClockSkew || diff < -gsalary.DefaultMaxClockSkew {
+		return ErrorStatus(http.StatusUnauthorized, "INVALID_SIGNATURE", "signature timestamp expired")
+	}
+	return nil
+}
+
+// write 签名并写出响应
+func (s *Server) write(w http.ResponseWriter, r *http.Request, resp *Response) {
+	for key, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	if w.Header().Get("Authorization") == "" {
+		timestamp := strconv.FormatInt(s.now().UnixMilli(), 10)
+		authorization, err := gsalary.SignResponse(r.Context(), s.signer, r.Method, gsalary.CanonicalPath(r.URL),
+			r.Header.Get("X-Appid"), timestamp, resp.Body)
+		if err == nil {
+			w.Header().Set("Authorization", authorization.ToHeaderValue())
+		}
+	}
+	status := resp.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	w.Write(resp.Body)
+}
+
+// route 路由
+type route struct {
+	method   string
+	pattern  string
+	segments []string
+	handler  HandlerFunc
+}
+
+// match 查找固定段最多的匹配路由，调用方需持有锁
+func (s *Server) match(method, path string) (HandlerFunc, map[string]string) {
+	segments := strings.Split(path, "/")
+	var best *route
+	bestLiterals := -1
+	for _, r := range s.routes {
+		if r.method != method || len(r.segments) != len(segments) {
+			continue
+		}
+		literals := 0
+		for i, segment := range r.segments {
+			if isParam(segment) {
+				if segments[i] == "" {
+					literals = -1
+					break
+				}
+				continue
+			}
+			if segment != segments[i] {
+				literals = -1
+				break
+			}
+			literals++
+		}
+		if literals > bestLiterals {
+			best, bestLiterals = r, literals
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+	params := make(map[string]string)
+	for i, segment := range best.segments {
+		if isParam(segment) {
+			params[segment[1:len(segment)-1]] = segments[i]
+		}
+	}
+	return best.handler, params
+}
+
+// isParam 是否为 {name} 形式的参数段
+func isParam(segment string) bool {
+	return len(segment) > 2 && segment[0] == '{' && segment[len(segment)-1] == '}'
+}
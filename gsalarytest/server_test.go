@@ -0,0 +1,117 @@
+package gsalarytest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+	"github.com/difyz9/gsalary-sdk-go/api"
+)
+
+// TestServerRoutes 测试路由参数、请求体解码和 S/F/U 结果
+func TestServerRoutes(t *testing.T) {
+	server := NewServer(t)
+	server.Handle("GET", "/v1/wallets/balance", func(r *Request) *Response {
+		return OK(map[string]interface{}{"currency": r.Query.Get("currency"), "available": 100.5})
+	})
+	server.Handle("PUT", "/v1/cards/{card_id}/freeze_status", func(r *Request) *Response {
+		var body struct {
+			Freeze bool `json:"freeze"`
+		}
+		if err := r.Decode(&body); err != nil || !body.Freeze {
+			return Error("INVALID_ARGUMENT", "freeze required")
+		}
+		return OK(map[string]string{"card_id": r.Params["card_id"], "status": "FROZEN"})
+	})
+	server.Handle("GET", "/v1/cards/available_quotas", func(r *Request) *Response {
+		return Fail("INVALID_STATUS", "quota unavailable")
+	})
+
+	client := api.NewClient(server.Config())
+	balance, err := client.Wallet.GetBalance(&api.WalletBalanceRequest{Currency: "USD"})
+	if err != nil {
+		t.Fatalf("GetBalance failed: %v", err)
+	}
+	if balance.Data.Currency != "USD" || balance.Data.Available.String() != "100.5" {
+		t.Errorf("Unexpected balance %+v", balance.Data)
+	}
+
+	raw := gsalary.NewClient(server.Config())
+	request := gsalary.NewRequest("PUT", "/v1/cards/card-1/freeze_status")
+	request.Body["freeze"] = true
+	result, err := raw.Request(request)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if data := result["data"].(map[string]interface{}); data["card_id"] != "card-1" {
+		t.Errorf("Expected card_id param, got %v", data)
+	}
+
+	result, err = raw.Request(gsalary.NewRequest("GET", "/v1/cards/available_quotas"))
+	if err != nil || result["result"].(map[string]interface{})["result"] != "F" {
+		t.Errorf("Expected F result, got %v %v", result, err)
+	}
+
+	server.Handle("GET", "/v1/wallets/balance", func(r *Request) *Response {
+		return Unknown("try again")
+	})
+	if _, err := client.Wallet.GetBalance(&api.WalletBalanceRequest{Currency: "USD"}); !errors.Is(err, gsalary.ErrResultUnknown) {
+		t.Errorf("Expected ErrResultUnknown, got %v", err)
+	}
+
+	if n := len(server.Requests()); n != 4 {
+		t.Errorf("Expected 4 recorded requests, got %d", n)
+	}
+}
+
+// TestServerErrorCodes 测试每个文档错误码都能映射到SDK的哨兵错误
+func TestServerErrorCodes(t *testing.T) {
+	server := NewServer(t)
+	client := gsalary.NewClient(server.Config())
+	for _, code := range ErrorCodes() {
+		code := code
+		server.Handle("GET", "/v1/cards/{card_id}", func(r *Request) *Response {
+			return Error(code, "scripted "+code)
+		})
+		_, err := client.RequestCtx(context.Background(), gsalary.NewRequest("GET", "/v1/cards/c1"))
+		var apiErr *gsalary.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != code || apiErr.HTTPStatus != StatusForCode(code) {
+			t.Errorf("%s: unexpected error %v", code, err)
+		}
+	}
+}
+
+// TestServerAuthentication 测试请求签名、AppID 校验和未注册路由
+func TestServerAuthentication(t *testing.T) {
+	server := NewServer(t)
+	client := gsalary.NewClient(server.Config())
+
+	if _, err := client.Request(gsalary.NewRequest("GET", "/v1/unknown")); !errors.Is(err, gsalary.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	other, err := gsalary.GenerateKeyPair(0)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair failed: %v", err)
+	}
+	config := server.Config()
+	config.ConfigSigner(gsalary.NewRSASigner(other))
+	_, err = gsalary.NewClient(config).Request(gsalary.NewRequest("GET", "/v1/wallets/balance"))
+	var apiErr *gsalary.APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatus != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for wrong client key, got %v", err)
+	}
+
+	config = server.Config()
+	config.AppID = "other-app"
+	_, err = gsalary.NewClient(config).Request(gsalary.NewRequest("GET", "/v1/wallets/balance"))
+	if !errors.Is(err, gsalary.ErrForbidden) || !strings.Contains(err.Error(), "other-app") {
+		t.Errorf("Expected ErrForbidden for wrong appid, got %v", err)
+	}
+	if n := len(server.Requests()); n != 1 {
+		t.Errorf("Expected only the authenticated request to be recorded, got %d", n)
+	}
+}
@@ -0,0 +1,352 @@
+package gsalarytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultQuoteTTL 换汇和付款锁汇报价的默认有效期
+const DefaultQuoteTTL = 30 * time.Second
+
+// settleInterval 后台任务检查到期的开卡申请和付款订单的间隔
+const settleInterval = 10 * time.Millisecond
+
+// Simulator 有状态的模拟业务，挂在 Server 上代替逐个脚本化的路由
+//
+//	server := gsalarytest.NewServer(t)
+//	sim := gsalarytest.NewSimulator(server)
+//	sim.Deposit("USD", "1000")
+//	sim.SetRate("USD", "EUR", "0.9")
+//	client := api.NewClient(server.Config())
+//
+// 模拟器在内存中维护按币种记账的钱包余额、持卡人、卡片、换汇订单和付款订单：
+//   - 开卡申请返回 PENDING，在 SettleDelay（按服务端时钟）之后由后台任务异步变为 ACTIVE，
+//     到期后的下一次请求也会先完成结算，因此注入的时钟推进后立即查询即可看到结果；
+//   - 卡片调额从钱包扣款（INCREASE）或退回钱包（DECREASE），同步完成；
+//   - 锁汇报价在 QuoteTTL 后过期，提交时返回 QUOTE_EXPIRE；
+//   - 付款订单提交时扣款并处于 CREATED，结算后变为 SUCCESS，或因 FailRemittance 变为 FAILED 并退款。
+//
+// 换汇金额按汇率计算后四舍五入到2位小数。设置 WithWebhookURL 后，上述状态变更以及
+// Authorize、Refund、NotifyPayment 注入的事件会以平台格式签名并推送到该地址。
+type Simulator struct {
+	server      *Server
+	settleDelay time.Duration
+	quoteTTL    time.Duration
+	webhooks    webhookSender
+
+	mu              sync.Mutex
+	seq             int
+	wallets         map[string]*big.Rat
+	rates           map[[2]string]*big.Rat
+	cardHolders     []*simCardHolder
+	cards           []*simCard
+	cardApplies     []*simCardApply
+	balanceModifies map[string]map[string]interface{}
+	fxQuotes        map[string]*simQuote
+	fxOrders        []*simFXOrder
+	remitQuotes     map[string]*simQuote
+	remitOrders     []*simRemitOrder
+	remitFailures   map[string]string
+	transactions    []*simTransaction
+}
+
+// SimulatorOption 模拟器选项
+type SimulatorOption func(*Simulator)
+
+// WithSettleDelay 设置开卡申请和付款订单从提交到完成的时长，默认为0（提交后由后台任务尽快完成）
+// 到期后由后台任务完成并推送 CARD_APPLY_RESULT、REMITTANCE_ORDER_RESULT，无需再发起请求
+func WithSettleDelay(d time.Duration) SimulatorOption {
+	return func(sim *Simulator) {
+		sim.settleDelay = d
+	}
+}
+
+// WithQuoteTTL 设置锁汇报价有效期，默认为 DefaultQuoteTTL
+func WithQuoteTTL(d time.Duration) SimulatorOption {
+	return func(sim *Simulator) {
+		sim.quoteTTL = d
+	}
+}
+
+// NewSimulator 创建模拟器并在服务端注册钱包、持卡人、卡片、换汇和付款路由
+// 之后通过 Handle 注册的同名路由会替换模拟器的处理函数
+func NewSimulator(server *Server, opts ...SimulatorOption) *Simulator {
+	sim := &Simulator{
+		server:          server,
+		quoteTTL:        DefaultQuoteTTL,
+		wallets:         make(map[string]*big.Rat),
+		rates:           make(map[[2]string]*big.Rat),
+		balanceModifies: make(map[string]map[string]interface{}),
+		fxQuotes:        make(map[string]*simQuote),
+		remitQuotes:     make(map[string]*simQuote),
+		remitFailures:   make(map[string]string),
+		webhooks: webhookSender{
+			attempts: DefaultWebhookAttempts,
+			interval: DefaultWebhookRetryInterval,
+		},
+	}
+	for _, opt := range opts {
+		opt(sim)
+	}
+	sim.webhooks.start(server)
+	sim.startSettler()
+
+	sim.handle("GET", "/v1/wallets/balance", sim.walletBalance)
+
+	sim.handle("POST", "/v1/card_holders", sim.addCardHolder)
+	sim.handle("GET", "/v1/card_holders", sim.listCardHolders)
+	sim.handle("GET", "/v1/card_holders/{card_holder_id}", sim.getCardHolder)
+	sim.handle("PUT", "/v1/card_holders/{card_holder_id}", sim.updateCardHolder)
+
+	sim.handle("POST", "/v1/card_applies", sim.applyCard)
+	sim.handle("GET", "/v1/card_applies/{request_id}", sim.getCardApply)
+	sim.handle("GET", "/v1/cards", sim.listCards)
+	sim.handle("GET", "/v1/cards/{card_id}", sim.getCard)
+	sim.handle("DELETE", "/v1/cards/{card_id}", sim.deleteCard)
+	sim.handle("PUT", "/v1/cards/{card_id}/freeze_status", sim.freezeCard)
+	sim.handle("POST", "/v1/cards/balance_modifies", sim.adjustCardBalance)
+	sim.handle("GET", "/v1/cards/balance_modifies/{request_id}", sim.getBalanceModify)
+	sim.handle("GET", "/v1/card_bill/card_transactions", sim.listTransactions)
+
+	sim.handle("GET", "/v1/exchange/current_exchange_rate", sim.currentRate)
+	sim.handle("POST", "/v1/exchange/quotes", sim.exchangeQuote)
+	sim.handle("POST", "/v1/exchange/submit_request", sim.submitExchange)
+	sim.handle("GET", "/v1/exchange/orders", sim.listExchangeOrders)
+
+	sim.handle("POST", "/remittance/quotes", sim.remittanceQuote)
+	sim.handle("POST", "/remittance/orders", sim.submitRemittance)
+	sim.handle("GET", "/remittance/orders", sim.listRemittanceOrders)
+	return sim
+}
+
+// Deposit 向钱包入账，amount 为十进制文本，非法金额时 panic
+func (sim *Simulator) Deposit(currency, amount string) {
+	value, ok := parseAmount(json.Number(amount))
+	if !ok || value.Sign() < 0 {
+		panic("gsalarytest: invalid deposit amount " + strconv.Quote(amount))
+	}
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	sim.credit(currency, value)
+}
+
+// Balance 返回钱包余额的十进制文本，未入账的币种返回 "0"
+func (sim *Simulator) Balance(currency string) string {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	return string(formatAmount(sim.wallet(currency)))
+}
+
+// CardBalance 返回卡片余额的十进制文本，卡片不存在时返回空字符串
+func (sim *Simulator) CardBalance(cardID string) string {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	if card := sim.findCard(cardID); card != nil {
+		return string(formatAmount(card.balance))
+	}
+	return ""
+}
+
+// SetRate 设置汇率：1单位 sell 币种可购入 rate 单位 buy 币种，未设置的反向汇率按倒数计算
+func (sim *Simulator) SetRate(sell, buy, rate string) {
+	value, ok := parseAmount(json.Number(rate))
+	if !ok || value.Sign() <= 0 {
+		panic("gsalarytest: invalid exchange rate " + strconv.Quote(rate))
+	}
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	sim.rates[[2]string{strings.ToUpper(sell), strings.ToUpper(buy)}] = value
+}
+
+// FailRemittance 使客户订单号为 clientOrderID 的付款订单结算为 FAILED 并退款
+// 可在提交前或结算前调用
+func (sim *Simulator) FailRemittance(clientOrderID, message string) {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	sim.remitFailures[clientOrderID] = message
+}
+
+// Settle 立即完成所有处理中的开卡申请和付款订单，不等待 SettleDelay
+func (sim *Simulator) Settle() {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	sim.settle(sim.server.now(), true)
+}
+
+// startSettler 启动后台结算任务，服务端 Close 时停止
+func (sim *Simulator) startSettler() {
+	ticker := time.NewTicker(settleInterval)
+	stop, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				sim.mu.Lock()
+				sim.settle(sim.server.now(), false)
+				sim.mu.Unlock()
+			}
+		}
+	}()
+	sim.server.onClose(func() {
+		ticker.Stop()
+		close(stop)
+		<-stopped
+	})
+}
+
+// handle 注册路由，处理前加锁并推进到期的异步状态
+func (sim *Simulator) handle(method, pattern string, handler HandlerFunc) {
+	sim.server.Handle(method, pattern, func(r *Request) *Response {
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		sim.settle(sim.server.now(), false)
+		return handler(r)
+	})
+}
+
+// settle 完成到期（force 时为全部）的开卡申请和付款订单，调用方需持有锁
+func (sim *Simulator) settle(now time.Time, force bool) {
+	due := func(created time.Time) bool {
+		return force || !now.Before(created.Add(sim.settleDelay))
+	}
+	for _, apply := range sim.cardApplies {
+		if apply.status == "PENDING" && due(apply.created) {
+			apply.status = "ACTIVE"
+			apply.card.status = "ACTIVE"
+			apply.card.updated = now
+			sim.emit("CARD_APPLY_RESULT", apply.card.id, map[string]interface{}{
+				"request_id": apply.requestID,
+				"card_id":    apply.card.id,
+				"status":     apply.status,
+			})
+		}
+	}
+	for _, order := range sim.remitOrders {
+		if order.status != "CREATED" || !due(order.created) {
+			continue
+		}
+		order.finished = now
+		if message, ok := sim.remitFailures[order.clientOrderID]; ok {
+			order.status = "FAILED"
+			order.errorMessage = message
+			sim.credit(order.quote.sell.currency, order.quote.sell.amount)
+		} else {
+			order.status = "SUCCESS"
+		}
+		sim.emit("REMITTANCE_ORDER_RESULT", order.id, order.view())
+	}
+}
+
+// walletBalance 查询钱包余额
+func (sim *Simulator) walletBalance(r *Request) *Response {
+	currency := strings.ToUpper(r.Query.Get("currency"))
+	if currency == "" {
+		return Error("MISSING_ARGUMENT", "currency is required")
+	}
+	balance := formatAmount(sim.wallet(currency))
+	return OK(map[string]interface{}{
+		"currency":                   currency,
+		"amount":                     balance,
+		"share_card_account_balance": json.Number("0"),
+		"available":                  balance,
+		"account_type":               "BALANCE",
+		"query_time":                 formatTime(sim.server.now()),
+	})
+}
+
+// nextID 生成带前缀的递增ID，调用方需持有锁
+func (sim *Simulator) nextID(prefix string) string {
+	sim.seq++
+	return fmt.Sprintf("%s%06d", prefix, sim.seq)
+}
+
+// wallet 返回钱包余额，调用方需持有锁
+func (sim *Simulator) wallet(currency string) *big.Rat {
+	if balance, ok := sim.wallets[strings.ToUpper(currency)]; ok {
+		return balance
+	}
+	return new(big.Rat)
+}
+
+// credit 钱包入账，调用方需持有锁
+func (sim *Simulator) credit(currency string, amount *big.Rat) {
+	currency = strings.ToUpper(currency)
+	sim.wallets[currency] = new(big.Rat).Add(sim.wallet(currency), amount)
+}
+
+// debit 钱包扣款，余额不足时返回 false 且不扣款，调用方需持有锁
+func (sim *Simulator) debit(currency string, amount *big.Rat) bool {
+	balance := sim.wallet(currency)
+	if balance.Cmp(amount) < 0 {
+		return false
+	}
+	sim.wallets[strings.ToUpper(currency)] = new(big.Rat).Sub(balance, amount)
+	return true
+}
+
+// decode 解码请求体，失败时返回错误响应
+func decode(r *Request, v interface{}) *Response {
+	if err := r.Decode(v); err != nil {
+		return Error("BAD_REQUEST", "invalid json body: "+err.Error())
+	}
+	return nil
+}
+
+// parseAmount 解析十进制金额，空值视为0
+func parseAmount(n json.Number) (*big.Rat, bool) {
+	if n == "" {
+		return new(big.Rat), true
+	}
+	return new(big.Rat).SetString(string(n))
+}
+
+// formatAmount 金额编码为JSON数字，保留至多8位小数并去掉末尾的0
+func formatAmount(r *big.Rat) json.Number {
+	s := r.FloatString(8)
+	s = strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		s = "0"
+	}
+	return json.Number(s)
+}
+
+// roundAmount 四舍五入到2位小数
+func roundAmount(r *big.Rat) *big.Rat {
+	rounded, _ := new(big.Rat).SetString(r.FloatString(2))
+	return rounded
+}
+
+// formatTime 时间编码为ISO-8601格式
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// pageOf 按 page/limit 查询参数分页，返回 key 为列表字段名的分页数据
+func pageOf[T any](query url.Values, key string, items []T) map[string]interface{} {
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit < 1 {
+		limit = 20
+	}
+	start := min((page-1)*limit, len(items))
+	end := min(start+limit, len(items))
+	return map[string]interface{}{
+		key:           append([]T{}, items[start:end]...),
+		"page":        page,
+		"limit":       limit,
+		"total_count": len(items),
+		"total_page":  (len(items) + limit - 1) / limit,
+	}
+}
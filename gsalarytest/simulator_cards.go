@@ -0,0 +1,556 @@
+package gsalarytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// simCardHolder 模拟器中的持卡人
+type simCardHolder struct {
+	ID          string          `json:"-"`
+	FirstName   string          `json:"first_name"`
+	LastName    string          `json:"last_name"`
+	Birth       string          `json:"birth"`
+	Email       string          `json:"email"`
+	Mobile      json.RawMessage `json:"mobile"`
+	Region      string          `json:"region"`
+	BillAddress json.RawMessage `json:"bill_address"`
+	created     time.Time
+	updated     time.Time
+}
+
+// view 持卡人响应数据，detail 为 true 时使用详情接口的字段
+func (h *simCardHolder) view(detail bool) map[string]interface{} {
+	v := map[string]interface{}{
+		"card_holder_id": h.ID,
+		"first_name":     h.FirstName,
+		"last_name":      h.LastName,
+		"birth":          h.Birth,
+		"email":          h.Email,
+		"region":         h.Region,
+		"bill_address":   rawOrNull(h.BillAddress),
+	}
+	if detail {
+		v["create_time"] = formatTime(h.created)
+		v["status"] = "ACTIVE"
+	} else {
+		v["mobile"] = rawOrNull(h.Mobile)
+		v["created_at"] = formatTime(h.created)
+		v["updated_at"] = formatTime(h.updated)
+	}
+	return v
+}
+
+// simCard 模拟器中的卡片
+type simCard struct {
+	id                  string
+	productCode         string
+	currency            string
+	holder              *simCardHolder
+	status              string
+	balance             *big.Rat
+	limitPerDay         json.Number
+	limitPerMonth       json.Number
+	limitPerTransaction json.Number
+	created             time.Time
+	updated             time.Time
+}
+
+// summary 卡列表中的卡片数据
+func (c *simCard) summary() map[string]interface{} {
+	return map[string]interface{}{
+		"card_id":        c.id,
+		"product_code":   c.productCode,
+		"brand_code":     "VISA",
+		"card_holder_id": c.holder.ID,
+		"status":         c.status,
+		"created_at":     formatTime(c.created),
+		"updated_at":     formatTime(c.updated),
+	}
+}
+
+// info 卡详情数据
+func (c *simCard) info() map[string]interface{} {
+	return map[string]interface{}{
+		"card_id":               c.id,
+		"card_name":             c.holder.FirstName + " " + c.holder.LastName,
+		"mask_card_number":      "41******" + c.id[len(c.id)-4:],
+		"card_currency":         c.currency,
+		"available_balance":     formatAmount(c.balance),
+		"brand_code":            "VISA",
+		"status":                c.status,
+		"card_type":             "VIRTUAL",
+		"accounting_type":       "RECHARGE",
+		"card_region":           "US",
+		"card_holder_id":        c.holder.ID,
+		"first_name":            c.holder.FirstName,
+		"last_name":             c.holder.LastName,
+		"mobile":                rawOrNull(c.holder.Mobile),
+		"email":                 c.holder.Email,
+		"limit_per_day":         numberOrZero(c.limitPerDay),
+		"limit_per_month":       numberOrZero(c.limitPerMonth),
+		"limit_per_transaction": numberOrZero(c.limitPerTransaction),
+		"bill_address":          rawOrNull(c.holder.BillAddress),
+		"support_tds_trans":     true,
+		"create_time":           formatTime(c.created),
+	}
+}
+
+// simCardApply 开卡申请
+type simCardApply struct {
+	requestID string
+	card      *simCard
+	status    string
+	created   time.Time
+}
+
+// addCardHolder 添加持卡人，邮箱需唯一
+func (sim *Simulator) addCardHolder(r *Request) *Response {
+	holder := &simCardHolder{}
+	if resp := decode(r, holder); resp != nil {
+		return resp
+	}
+	if holder.FirstName == "" || holder.LastName == "" || holder.Email == "" {
+		return Error("MISSING_ARGUMENT", "first_name, last_name and email are required")
+	}
+	for _, other := range sim.cardHolders {
+		if strings.EqualFold(other.Email, holder.Email) {
+			return Error("DUPLICATED", "card holder email already exists")
+		}
+	}
+	holder.ID = sim.nextID("ch_")
+	holder.created = sim.server.now()
+	holder.updated = holder.created
+	sim.cardHolders = append(sim.cardHolders, holder)
+	return OK(holder.view(false))
+}
+
+// listCardHolders 查询持卡人列表
+func (sim *Simulator) listCardHolders(r *Request) *Response {
+	views := make([]map[string]interface{}, 0, len(sim.cardHolders))
+	for _, holder := range sim.cardHolders {
+		views = append(views, holder.view(false))
+	}
+	return OK(pageOf(r.Query, "card_holders", views))
+}
+
+// getCardHolder 查看持卡人信息
+func (sim *Simulator) getCardHolder(r *Request) *Response {
+	holder := sim.findCardHolder(r.Params["card_holder_id"])
+	if holder == nil {
+		return Error("NOT_FOUND", "card holder not found")
+	}
+	return OK(holder.view(true))
+}
+
+// updateCardHolder 修改持卡人信息，只更新请求中非空的字段
+func (sim *Simulator) updateCardHolder(r *Request) *Response {
+	holder := sim.findCardHolder(r.Params["card_holder_id"])
+	if holder == nil {
+		return Error("NOT_FOUND", "card holder not found")
+	}
+	var update simCardHolder
+	if resp := decode(r, &update); resp != nil {
+		return resp
+	}
+	for _, field := range []struct{ dst, src *string }{
+		{&holder.FirstName, &update.FirstName},
+		{&holder.LastName, &update.LastName},
+		{&holder.Birth, &update.Birth},
+		{&holder.Email, &update.Email},
+		{&holder.Region, &update.Region},
+	} {
+		if *field.src != "" {
+			*field.dst = *field.src
+		}
+	}
+	if len(update.Mobile) > 0 {
+		holder.Mobile = update.Mobile
+	}
+	if len(update.BillAddress) > 0 {
+		holder.BillAddress = update.BillAddress
+	}
+	holder.updated = sim.server.now()
+	return OK(holder.view(false))
+}
+
+// applyCard 申请新卡，初始余额从卡币种钱包扣款，卡片在结算前处于 PENDING
+func (sim *Simulator) applyCard(r *Request) *Response {
+	var req struct {
+		RequestID           string      `json:"request_id"`
+		ProductCode         string      `json:"product_code"`
+		Currency            string      `json:"currency"`
+		CardHolderID        string      `json:"card_holder_id"`
+		LimitPerDay         json.Number `json:"limit_per_day"`
+		LimitPerMonth       json.Number `json:"limit_per_month"`
+		LimitPerTransaction json.Number `json:"limit_per_transaction"`
+		InitBalance         json.Number `json:"init_balance"`
+	}
+	if resp := decode(r, &req); resp != nil {
+		return resp
+	}
+	if req.RequestID == "" || req.Currency == "" || req.CardHolderID == "" {
+		return Error("MISSING_ARGUMENT", "request_id, currency and card_holder_id are required")
+	}
+	if sim.findCardApply(req.RequestID) != nil {
+		return Error("DUPLICATED", "request_id already used")
+	}
+	holder := sim.findCardHolder(req.CardHolderID)
+	if holder == nil {
+		return Error("NOT_FOUND", "card holder not found")
+	}
+	initBalance, ok := parseAmount(req.InitBalance)
+	if !ok || initBalance.Sign() < 0 {
+		return Error("INVALID_ARGUMENT", "invalid init_balance")
+	}
+	currency := strings.ToUpper(req.Currency)
+	if !sim.debit(currency, initBalance) {
+		return Error("INSUFFICIENT_BALANCE", "wallet balance is not enough for init_balance")
+	}
+
+	now := sim.server.now()
+	card := &simCard{
+		id:                  sim.nextID("card_"),
+		productCode:         req.ProductCode,
+		currency:            currency,
+		holder:              holder,
+		status:              "PENDING",
+		balance:             initBalance,
+		limitPerDay:         req.LimitPerDay,
+		limitPerMonth:       req.LimitPerMonth,
+		limitPerTransaction: req.LimitPerTransaction,
+		created:             now,
+		updated:             now,
+	}
+	sim.cards = append(sim.cards, card)
+	sim.cardApplies = append(sim.cardApplies, &simCardApply{requestID: req.RequestID, card: card, status: "PENDING", created: now})
+	return OK(map[string]interface{}{"request_id": req.RequestID, "status": "PENDING"})
+}
+
+// getCardApply 查询开卡结果，完成后返回 card_id
+func (sim *Simulator) getCardApply(r *Request) *Response {
+	apply := sim.findCardApply(r.Params["request_id"])
+	if apply == nil {
+		return Error("NOT_FOUND", "card apply not found")
+	}
+	data := map[string]interface{}{"request_id": apply.requestID, "status": apply.status}
+	if apply.status == "ACTIVE" {
+		data["card_id"] = apply.card.id
+	}
+	return OK(data)
+}
+
+// listCards 查询卡列表，支持按持卡人、产品和状态过滤
+func (sim *Simulator) listCards(r *Request) *Response {
+	views := make([]map[string]interface{}, 0, len(sim.cards))
+	for _, card := range sim.cards {
+		if !matches(r.Query.Get("card_holder_id"), card.holder.ID) ||
+			!matches(r.Query.Get("product_code"), card.productCode) ||
+			!matches(r.Query.Get("status"), card.status) {
+			continue
+		}
+		views = append(views, card.summary())
+	}
+	return OK(pageOf(r.Query, "cards", views))
+}
+
+// getCard 查看卡信息
+func (sim *Simulator) getCard(r *Request) *Response {
+	card := sim.findCard(r.Params["card_id"])
+	if card == nil {
+		return Error("NOT_FOUND", "card not found")
+	}
+	return OK(card.info())
+}
+
+// deleteCard 销卡，卡内余额退回钱包
+func (sim *Simulator) deleteCard(r *Request) *Response {
+	card := sim.findCard(r.Params["card_id"])
+	if card == nil {
+		return Error("NOT_FOUND", "card not found")
+	}
+	if card.status != "ACTIVE" && card.status != "FROZEN" {
+		return Error("INVALID_STATUS", "card is "+card.status)
+	}
+	sim.credit(card.currency, card.balance)
+	card.balance = new(big.Rat)
+	card.status = "CANCELLED"
+	card.updated = sim.server.now()
+	sim.emitCardStatus(card)
+	return OK(nil)
+}
+
+// freezeCard 冻结或解冻卡
+func (sim *Simulator) freezeCard(r *Request) *Response {
+	card := sim.findCard(r.Params["card_id"])
+	if card == nil {
+		return Error("NOT_FOUND", "card not found")
+	}
+	var req struct {
+		Freeze bool `json:"freeze"`
+	}
+	if resp := decode(r, &req); resp != nil {
+		return resp
+	}
+	from, to := "FROZEN", "ACTIVE"
+	if req.Freeze {
+		from, to = "ACTIVE", "FROZEN"
+	}
+	if card.status != from {
+		return Error("INVALID_STATUS", "card is "+card.status)
+	}
+	card.status = to
+	card.updated = sim.server.now()
+	sim.emitCardStatus(card)
+	return OK(nil)
+}
+
+// adjustCardBalance 卡片调额：INCREASE 从钱包转入卡，DECREASE 从卡退回钱包
+func (sim *Simulator) adjustCardBalance(r *Request) *Response {
+	var req struct {
+		CardID    string      `json:"card_id"`
+		Amount    json.Number `json:"amount"`
+		Type      string      `json:"type"`
+		RequestID string      `json:"request_id"`
+	}
+	if resp := decode(r, &req); resp != nil {
+		return resp
+	}
+	if req.CardID == "" || req.RequestID == "" || req.Amount == "" {
+		return Error("MISSING_ARGUMENT", "card_id, amount and request_id are required")
+	}
+	if _, ok := sim.balanceModifies[req.RequestID]; ok {
+		return Error("DUPLICATED", "request_id already used")
+	}
+	amount, ok := parseAmount(req.Amount)
+	if !ok || amount.Sign() < 0 {
+		return Error("INVALID_ARGUMENT", "invalid amount")
+	}
+	card := sim.findCard(req.CardID)
+	if card == nil {
+		return Error("NOT_FOUND", "card not found")
+	}
+	if card.status != "ACTIVE" {
+		return Error("INVALID_STATUS", "card is "+card.status)
+	}
+	switch req.Type {
+	case "INCREASE":
+		if !sim.debit(card.currency, amount) {
+			return Error("INSUFFICIENT_BALANCE", "wallet balance is not enough")
+		}
+		card.balance = new(big.Rat).Add(card.balance, amount)
+	case "DECREASE":
+		if card.balance.Cmp(amount) < 0 {
+			return Error("INSUFFICIENT_BALANCE", "card balance is not enough")
+		}
+		card.balance = new(big.Rat).Sub(card.balance, amount)
+		sim.credit(card.currency, amount)
+	default:
+		return Error("INVALID_ARGUMENT", "type must be INCREASE or DECREASE")
+	}
+
+	now := formatTime(sim.server.now())
+	result := map[string]interface{}{
+		"gsalary_request_id": sim.nextID("bm_"),
+		"request_id":         req.RequestID,
+		"card_id":            card.id,
+		"status":             "SUCCESS",
+		"create_time":        now,
+		"finish_time":        now,
+		"amount":             formatAmount(amount),
+		"type":               req.Type,
+		"post_balance":       formatAmount(card.balance),
+	}
+	sim.balanceModifies[req.RequestID] = result
+	sim.emit("CARD_ADJUST_RESULT", req.RequestID, result)
+	return OK(result)
+}
+
+// getBalanceModify 查询调额结果
+func (sim *Simulator) getBalanceModify(r *Request) *Response {
+	result, ok := sim.balanceModifies[r.Params["request_id"]]
+	if !ok {
+		return Error("NOT_FOUND", "balance modify not found")
+	}
+	return OK(result)
+}
+
+// emitCardStatus 推送卡状态变更，调用方需持有锁
+func (sim *Simulator) emitCardStatus(card *simCard) {
+	sim.emit("CARD_STATUS_UPDATE", card.id, map[string]interface{}{
+		"card_id":     card.id,
+		"status":      card.status,
+		"update_time": formatTime(card.updated),
+	})
+}
+
+// findCardHolder 按ID查找持卡人，调用方需持有锁
+func (sim *Simulator) findCardHolder(id string) *simCardHolder {
+	for _, holder := range sim.cardHolders {
+		if holder.ID == id {
+			return holder
+		}
+	}
+	return nil
+}
+
+// findCardApply 按请求ID查找开卡申请，调用方需持有锁
+func (sim *Simulator) findCardApply(requestID string) *simCardApply {
+	for _, apply := range sim.cardApplies {
+		if apply.requestID == requestID {
+			return apply
+		}
+	}
+	return nil
+}
+
+// findCard 按ID查找卡片，调用方需持有锁
+func (sim *Simulator) findCard(id string) *simCard {
+	for _, card := range sim.cards {
+		if card.id == id {
+			return card
+		}
+	}
+	return nil
+}
+
+// matches 过滤条件为空或相等
+func matches(filter, value string) bool {
+	return filter == "" || filter == value
+}
+
+// rawOrNull 未设置的JSON字段输出为 null
+func rawOrNull(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return json.RawMessage("null")
+	}
+	return raw
+}
+
+// numberOrZero 未设置的金额输出为 0
+func numberOrZero(n json.Number) json.Number {
+	if n == "" {
+		return "0"
+	}
+	return n
+}
+
+// simTransaction 卡交易，由 Authorize 和 Refund 注入
+type simTransaction struct {
+	id          string
+	card        *simCard
+	kind        string
+	amount      *big.Rat
+	refunded    *big.Rat
+	status      string
+	description string
+	merchant    string
+	created     time.Time
+}
+
+// view 卡交易数据，与 CARD_TRANSACTION 推送的数据相同
+func (tx *simTransaction) view() map[string]interface{} {
+	return map[string]interface{}{
+		"transaction_id":         tx.id,
+		"card_id":                tx.card.id,
+		"transaction_type":       tx.kind,
+		"amount":                 formatAmount(tx.amount),
+		"currency":               tx.card.currency,
+		"status":                 tx.status,
+		"status_description":     tx.description,
+		"transaction_time":       formatTime(tx.created),
+		"merchant_name":          tx.merchant,
+		"merchant_country":       "US",
+		"merchant_category_code": "5999",
+	}
+}
+
+// Authorize 注入一笔卡消费授权并推送 CARD_TRANSACTION，返回交易ID
+// 卡片不是 ACTIVE 或余额不足时交易以 FAIL 状态记录（同样推送），否则从卡余额扣款
+func (sim *Simulator) Authorize(cardID, amount, merchant string) (string, error) {
+	value, ok := parseAmount(json.Number(amount))
+	if !ok || value.Sign() <= 0 {
+		return "", fmt.Errorf("gsalarytest: invalid authorization amount %q", amount)
+	}
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	card := sim.findCard(cardID)
+	if card == nil {
+		return "", fmt.Errorf("gsalarytest: card %q not found", cardID)
+	}
+	tx := &simTransaction{
+		id:       sim.nextID("tx_"),
+		card:     card,
+		kind:     "AUTHORIZATION",
+		amount:   value,
+		refunded: new(big.Rat),
+		status:   "SUCCESS",
+		merchant: merchant,
+		created:  sim.server.now(),
+	}
+	switch {
+	case card.status != "ACTIVE":
+		tx.status, tx.description = "FAIL", "card is "+card.status
+	case card.balance.Cmp(value) < 0:
+		tx.status, tx.description = "FAIL", "insufficient balance"
+	default:
+		card.balance = new(big.Rat).Sub(card.balance, value)
+	}
+	sim.transactions = append(sim.transactions, tx)
+	sim.emit("CARD_TRANSACTION", tx.id, tx.view())
+	return tx.id, nil
+}
+
+// Refund 对成功的授权注入一笔退款并推送 CARD_TRANSACTION，退款金额退回卡余额，返回退款交易ID
+// 累计退款金额不能超过授权金额
+func (sim *Simulator) Refund(transactionID, amount string) (string, error) {
+	value, ok := parseAmount(json.Number(amount))
+	if !ok || value.Sign() <= 0 {
+		return "", fmt.Errorf("gsalarytest: invalid refund amount %q", amount)
+	}
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	var auth *simTransaction
+	for _, tx := range sim.transactions {
+		if tx.id == transactionID {
+			auth = tx
+		}
+	}
+	if auth == nil || auth.kind != "AUTHORIZATION" || auth.status != "SUCCESS" {
+		return "", fmt.Errorf("gsalarytest: no successful authorization %q", transactionID)
+	}
+	refunded := new(big.Rat).Add(auth.refunded, value)
+	if refunded.Cmp(auth.amount) > 0 {
+		return "", fmt.Errorf("gsalarytest: refund exceeds authorized amount %s", formatAmount(auth.amount))
+	}
+	auth.refunded = refunded
+	auth.card.balance = new(big.Rat).Add(auth.card.balance, value)
+	tx := &simTransaction{
+		id:       sim.nextID("tx_"),
+		card:     auth.card,
+		kind:     "REFUND",
+		amount:   value,
+		status:   "SUCCESS",
+		merchant: auth.merchant,
+		created:  sim.server.now(),
+	}
+	sim.transactions = append(sim.transactions, tx)
+	sim.emit("CARD_TRANSACTION", tx.id, tx.view())
+	return tx.id, nil
+}
+
+// listTransactions 查询卡交易列表，支持按卡和交易ID过滤
+func (sim *Simulator) listTransactions(r *Request) *Response {
+	views := make([]map[string]interface{}, 0, len(sim.transactions))
+	for _, tx := range sim.transactions {
+		if !matches(r.Query.Get("card_id"), tx.card.id) ||
+			!matches(r.Query.Get("transaction_id"), tx.id) {
+			continue
+		}
+		views = append(views, tx.view())
+	}
+	return OK(pageOf(r.Query, "transactions", views))
+}
@@ -0,0 +1,329 @@
+package gsalarytest
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// simAmount 币种和金额
+type simAmount struct {
+	currency string
+	amount   *big.Rat
+}
+
+// view 编码为 {"currency":...,"amount":...}
+func (a simAmount) view() map[string]interface{} {
+	return map[string]interface{}{"currency": a.currency, "amount": formatAmount(a.amount)}
+}
+
+// simQuote 锁汇报价，换汇中 sell/buy 为卖出/购入，付款中为付款/收款
+type simQuote struct {
+	id             string
+	sell           simAmount
+	buy            simAmount
+	rate           *big.Rat
+	payeeAccountID string
+	created        time.Time
+	expire         time.Time
+	used           bool
+}
+
+// simFXOrder 换汇订单
+type simFXOrder struct {
+	id        string
+	requestID string
+	quote     *simQuote
+	created   time.Time
+}
+
+// view 换汇订单数据，换汇提交后立即成功
+func (o *simFXOrder) view() map[string]interface{} {
+	return map[string]interface{}{
+		"order_id":      o.id,
+		"request_id":    o.requestID,
+		"create_time":   formatTime(o.created),
+		"status":        "SUCCESS",
+		"source":        "API",
+		"sell":          o.quote.sell.view(),
+		"buy":           o.quote.buy.view(),
+		"surcharge":     simAmount{o.quote.sell.currency, new(big.Rat)}.view(),
+		"exchange_rate": formatAmount(o.quote.rate),
+	}
+}
+
+// simRemitOrder 付款订单
+type simRemitOrder struct {
+	id            string
+	clientOrderID string
+	quote         *simQuote
+	status        string
+	errorMessage  string
+	created       time.Time
+	finished      time.Time
+}
+
+// view 付款订单数据
+func (o *simRemitOrder) view() map[string]interface{} {
+	finishTime := ""
+	if !o.finished.IsZero() {
+		finishTime = formatTime(o.finished)
+	}
+	return map[string]interface{}{
+		"order_id":         o.id,
+		"order_source":     "API",
+		"client_order_id":  o.clientOrderID,
+		"create_time":      formatTime(o.created),
+		"finish_time":      finishTime,
+		"status":           o.status,
+		"payee_id":         "",
+		"payee_account_id": o.quote.payeeAccountID,
+		"payment_method":   "BANK_TRANSFER",
+		"pay_amount":       o.quote.sell.view(),
+		"receive_amount":   o.quote.buy.view(),
+		"surcharge":        simAmount{o.quote.sell.currency, new(big.Rat)}.view(),
+		"exchange_rate":    formatAmount(o.quote.rate),
+		"error_message":    o.errorMessage,
+	}
+}
+
+// rate 返回1单位 sell 可购入的 buy 数量，未配置时返回 nil，调用方需持有锁
+func (sim *Simulator) rate(sell, buy string) *big.Rat {
+	if sell == buy {
+		return big.NewRat(1, 1)
+	}
+	if rate, ok := sim.rates[[2]string{sell, buy}]; ok {
+		return rate
+	}
+	if rate, ok := sim.rates[[2]string{buy, sell}]; ok {
+		return new(big.Rat).Inv(rate)
+	}
+	return nil
+}
+
+// quote 按卖出金额（sellSide 为 true）或购入金额生成锁汇报价，失败时返回错误响应
+func (sim *Simulator) quote(sell, buy string, amount json.Number, sellSide bool) (*simQuote, *Response) {
+	sell, buy = strings.ToUpper(sell), strings.ToUpper(buy)
+	if sell == "" || buy == "" {
+		return nil, Error("MISSING_ARGUMENT", "currencies are required")
+	}
+	rate := sim.rate(sell, buy)
+	if rate == nil {
+		return nil, Error("INVALID_ARGUMENT", "unsupported currency pair "+sell+"/"+buy)
+	}
+	value, ok := parseAmount(amount)
+	if !ok || value.Sign() <= 0 {
+		return nil, Error("INVALID_ARGUMENT", "amount must be positive")
+	}
+	q := &simQuote{rate: rate, created: sim.server.now()}
+	q.expire = q.created.Add(sim.quoteTTL)
+	if sellSide {
+		q.sell = simAmount{sell, value}
+		q.buy = simAmount{buy, roundAmount(new(big.Rat).Mul(value, rate))}
+	} else {
+		q.buy = simAmount{buy, value}
+		q.sell = simAmount{sell, roundAmount(new(big.Rat).Quo(value, rate))}
+	}
+	return q, nil
+}
+
+// redeem 校验报价可用并从钱包扣除卖出金额，失败时返回错误响应，调用方需持有锁
+func (sim *Simulator) redeem(q *simQuote) *Response {
+	if q.used {
+		return Error("INVALID_STATUS", "quote already used")
+	}
+	if sim.server.now().After(q.expire) {
+		return Error("QUOTE_EXPIRE", "quote expired at "+formatTime(q.expire))
+	}
+	if !sim.debit(q.sell.currency, q.sell.amount) {
+		return Error("INSUFFICIENT_BALANCE", q.sell.currency+" wallet balance is not enough")
+	}
+	q.used = true
+	return nil
+}
+
+// currentRate 查询汇率
+func (sim *Simulator) currentRate(r *Request) *Response {
+	buy := strings.ToUpper(r.Query.Get("buy_currency"))
+	sell := strings.ToUpper(r.Query.Get("sell_currency"))
+	rate := sim.rate(sell, buy)
+	if rate == nil {
+		return Error("INVALID_ARGUMENT", "unsupported currency pair "+sell+"/"+buy)
+	}
+	return OK(map[string]interface{}{
+		"buy_currency":  buy,
+		"sell_currency": sell,
+		"rate":          formatAmount(rate),
+		"update_time":   formatTime(sim.server.now()),
+	})
+}
+
+// exchangeQuote 请求锁汇报价，同时提供两个金额时以卖出金额为准
+func (sim *Simulator) exchangeQuote(r *Request) *Response {
+	var req struct {
+		BuyCurrency  string      `json:"buy_currency"`
+		SellCurrency string      `json:"sell_currency"`
+		BuyAmount    json.Number `json:"buy_amount"`
+		SellAmount   json.Number `json:"sell_amount"`
+	}
+	if resp := decode(r, &req); resp != nil {
+		return resp
+	}
+	amount, sellSide := req.SellAmount, true
+	if amount == "" {
+		amount, sellSide = req.BuyAmount, false
+	}
+	q, resp := sim.quote(req.SellCurrency, req.BuyCurrency, amount, sellSide)
+	if resp != nil {
+		return resp
+	}
+	q.id = sim.nextID("fxq_")
+	sim.fxQuotes[q.id] = q
+	return OK(map[string]interface{}{
+		"quote_id":    q.id,
+		"buy":         q.buy.view(),
+		"sell":        q.sell.view(),
+		"surcharge":   simAmount{q.sell.currency, new(big.Rat)}.view(),
+		"total_cost":  q.sell.view(),
+		"update_time": formatTime(q.created),
+		"expire_time": formatTime(q.expire),
+	})
+}
+
+// submitExchange 提交换汇：扣除卖出币种、入账购入币种，订单立即成功
+func (sim *Simulator) submitExchange(r *Request) *Response {
+	var req struct {
+		RequestID string `json:"request_id"`
+		QuoteID   string `json:"quote_id"`
+	}
+	if resp := decode(r, &req); resp != nil {
+		return resp
+	}
+	if req.RequestID == "" || req.QuoteID == "" {
+		return Error("MISSING_ARGUMENT", "request_id and quote_id are required")
+	}
+	for _, order := range sim.fxOrders {
+		if order.requestID == req.RequestID {
+			return Error("DUPLICATED", "request_id already used")
+		}
+	}
+	q, ok := sim.fxQuotes[req.QuoteID]
+	if !ok {
+		return Error("NOT_FOUND", "quote not found")
+	}
+	if resp := sim.redeem(q); resp != nil {
+		return resp
+	}
+	sim.credit(q.buy.currency, q.buy.amount)
+	order := &simFXOrder{id: sim.nextID("fxo_"), requestID: req.RequestID, quote: q, created: sim.server.now()}
+	sim.fxOrders = append(sim.fxOrders, order)
+	sim.emit("EXCHANGE_ORDER_RESULT", order.id, order.view())
+	return OK(order.view())
+}
+
+// listExchangeOrders 查询换汇订单列表
+func (sim *Simulator) listExchangeOrders(r *Request) *Response {
+	views := make([]map[string]interface{}, 0, len(sim.fxOrders))
+	for _, order := range sim.fxOrders {
+		if !matches(r.Query.Get("status"), "SUCCESS") ||
+			!matches(r.Query.Get("buy_currency"), order.quote.buy.currency) ||
+			!matches(r.Query.Get("sell_currency"), order.quote.sell.currency) {
+			continue
+		}
+		views = append(views, order.view())
+	}
+	return OK(pageOf(r.Query, "orders", views))
+}
+
+// remittanceQuote 申请付款锁汇，收款币种默认与付款币种相同
+func (sim *Simulator) remittanceQuote(r *Request) *Response {
+	var req struct {
+		PayeeAccountID  string      `json:"payee_account_id"`
+		PayCurrency     string      `json:"pay_currency"`
+		ReceiveCurrency string      `json:"receive_currency"`
+		Amount          json.Number `json:"amount"`
+		AmountType      string      `json:"amount_type"`
+	}
+	if resp := decode(r, &req); resp != nil {
+		return resp
+	}
+	if req.PayeeAccountID == "" {
+		return Error("MISSING_ARGUMENT", "payee_account_id is required")
+	}
+	if req.ReceiveCurrency == "" {
+		req.ReceiveCurrency = req.PayCurrency
+	}
+	var sellSide bool
+	switch req.AmountType {
+	case "", "PAY_AMOUNT":
+		sellSide = true
+	case "RECEIVE_AMOUNT":
+	default:
+		return Error("INVALID_ARGUMENT", "amount_type must be PAY_AMOUNT or RECEIVE_AMOUNT")
+	}
+	q, resp := sim.quote(req.PayCurrency, req.ReceiveCurrency, req.Amount, sellSide)
+	if resp != nil {
+		return resp
+	}
+	q.id = sim.nextID("rq_")
+	q.payeeAccountID = req.PayeeAccountID
+	sim.remitQuotes[q.id] = q
+	return OK(map[string]interface{}{
+		"quote_id":       q.id,
+		"payment_method": "BANK_TRANSFER",
+		"pay_amount":     q.sell.view(),
+		"receive_amount": q.buy.view(),
+		"surcharge":      simAmount{q.sell.currency, new(big.Rat)}.view(),
+		"exchange_rate":  formatAmount(q.rate),
+		"expire_at":      formatTime(q.expire),
+	})
+}
+
+// submitRemittance 提交付款订单：扣除付款金额，订单处于 CREATED 直到结算
+func (sim *Simulator) submitRemittance(r *Request) *Response {
+	var req struct {
+		QuoteID       string `json:"quote_id"`
+		ClientOrderID string `json:"client_order_id"`
+	}
+	if resp := decode(r, &req); resp != nil {
+		return resp
+	}
+	if req.QuoteID == "" || req.ClientOrderID == "" {
+		return Error("MISSING_ARGUMENT", "quote_id and client_order_id are required")
+	}
+	for _, order := range sim.remitOrders {
+		if order.clientOrderID == req.ClientOrderID {
+			return Error("DUPLICATED", "client_order_id already used")
+		}
+	}
+	q, ok := sim.remitQuotes[req.QuoteID]
+	if !ok {
+		return Error("NOT_FOUND", "quote not found")
+	}
+	if resp := sim.redeem(q); resp != nil {
+		return resp
+	}
+	order := &simRemitOrder{
+		id:            sim.nextID("ro_"),
+		clientOrderID: req.ClientOrderID,
+		quote:         q,
+		status:        "CREATED",
+		created:       sim.server.now(),
+	}
+	sim.remitOrders = append(sim.remitOrders, order)
+	return OK(order.view())
+}
+
+// listRemittanceOrders 查询付款单列表，支持按平台单号和客户单号过滤
+func (sim *Simulator) listRemittanceOrders(r *Request) *Response {
+	views := make([]map[string]interface{}, 0, len(sim.remitOrders))
+	for _, order := range sim.remitOrders {
+		if !matches(r.Query.Get("order_id"), order.id) ||
+			!matches(r.Query.Get("client_order_id"), order.clientOrderID) {
+			continue
+		}
+		views = append(views, order.view())
+	}
+	return OK(pageOf(r.Query, "orders", views))
+}
@@ -0,0 +1,214 @@
+package gsalarytest
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+	"github.com/difyz9/gsalary-sdk-go/api"
+)
+
+// fakeClock 可手动推进的时钟
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// TestSimulatorCards 测试持卡人、异步开卡和卡片调额对钱包的影响
+func TestSimulatorCards(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)}
+	server := NewServer(t, WithClock(clock.Now))
+	sim := NewSimulator(server, WithSettleDelay(time.Minute))
+	sim.Deposit("USD", "1000")
+	client := api.NewClient(server.Config())
+
+	holder, err := client.CardHolder.AddCardHolder(&api.CardHolderRequest{
+		FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com",
+		Mobile: api.MobileNumber{CountryCode: "1", Number: "5550100"}, Region: "US",
+	})
+	if err != nil {
+		t.Fatalf("AddCardHolder failed: %v", err)
+	}
+	holderID := holder.Data.CardHolderID
+	if _, err := client.CardHolder.AddCardHolder(&api.CardHolderRequest{FirstName: "A", LastName: "B", Email: "ADA@example.com"}); !errors.Is(err, gsalary.ErrDuplicated) {
+		t.Errorf("Expected ErrDuplicated for same email, got %v", err)
+	}
+
+	apply, err := client.Card.ApplyCard(&api.CardApplyRequest{
+		RequestID: "apply-1", ProductCode: "P1", Currency: "USD", CardHolderID: holderID,
+		InitBalance: api.MustParseDecimal("100.50"),
+	})
+	if err != nil || apply.Data.Status != "PENDING" {
+		t.Fatalf("ApplyCard: %v %+v", err, apply)
+	}
+	if got := sim.Balance("USD"); got != "899.5" {
+		t.Errorf("Expected init balance to be debited, got %s", got)
+	}
+	result, err := client.Card.GetCardApplyResult("apply-1")
+	if err != nil || result.Data["status"] != "PENDING" {
+		t.Fatalf("Expected apply to stay PENDING before settle delay: %v %v", err, result)
+	}
+
+	clock.Advance(time.Minute)
+	result, err = client.Card.GetCardApplyResult("apply-1")
+	if err != nil || result.Data["status"] != "ACTIVE" {
+		t.Fatalf("Expected apply to be ACTIVE: %v %v", err, result)
+	}
+	cardID, _ := result.Data["card_id"].(string)
+	cards, err := client.Card.GetCardList(&api.CardListRequest{CardHolderID: holderID, Status: "ACTIVE"})
+	if err != nil || len(cards.Data.Cards) != 1 || cards.Data.Cards[0].CardID != cardID {
+		t.Fatalf("Unexpected card list: %v %+v", err, cards)
+	}
+
+	adjusted, err := client.Card.AdjustCardBalance(&api.AdjustCardBalanceRequest{
+		CardID: cardID, Amount: api.MustParseDecimal("49.5"), Type: "INCREASE", RequestID: "adjust-1",
+	})
+	if err != nil || adjusted.Data.PostBalance.String() != "150" {
+		t.Fatalf("AdjustCardBalance: %v %+v", err, adjusted)
+	}
+	_, err = client.Card.AdjustCardBalance(&api.AdjustCardBalanceRequest{
+		CardID: cardID, Amount: api.MustParseDecimal("151"), Type: "DECREASE", RequestID: "adjust-2",
+	})
+	if !errors.Is(err, gsalary.ErrInsufficientBalance) {
+		t.Errorf("Expected ErrInsufficientBalance, got %v", err)
+	}
+	if _, err := client.Card.AdjustCardBalance(&api.AdjustCardBalanceRequest{
+		CardID: cardID, Amount: api.MustParseDecimal("30"), Type: "DECREASE", RequestID: "adjust-3",
+	}); err != nil {
+		t.Fatalf("AdjustCardBalance DECREASE failed: %v", err)
+	}
+
+	info, err := client.Card.GetCardInfo(cardID)
+	if err != nil || info.Data.AvailableBalance.String() != "120" || info.Data.FirstName != "Ada" {
+		t.Errorf("Unexpected card info: %v %+v", err, info)
+	}
+	balance, err := client.Wallet.GetBalance(&api.WalletBalanceRequest{Currency: "USD"})
+	if err != nil || balance.Data.Available.String() != "880" {
+		t.Errorf("Expected wallet 880, got %v %+v", err, balance)
+	}
+	modify, err := client.Card.GetBalanceModifyResult("adjust-1")
+	if err != nil || modify.Data.Status != "SUCCESS" || modify.Data.Amount.String() != "49.5" {
+		t.Errorf("Unexpected balance modify result: %v %+v", err, modify)
+	}
+}
+
+// TestSimulatorExchange 测试锁汇报价、过期和换汇入账
+func TestSimulatorExchange(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)}
+	server := NewServer(t, WithClock(clock.Now))
+	sim := NewSimulator(server, WithQuoteTTL(10*time.Second))
+	sim.Deposit("USD", "100")
+	sim.SetRate("USD", "EUR", "0.9")
+	client := api.NewClient(server.Config())
+
+	quote, err := client.Exchange.RequestQuote(&api.ExchangeQuoteRequest{
+		SellCurrency: "USD", BuyCurrency: "EUR", BuyAmount: api.MustParseDecimal("45"),
+	})
+	if err != nil || quote.Data.Sell.Amount.String() != "50" {
+		t.Fatalf("RequestQuote: %v %+v", err, quote)
+	}
+	clock.Advance(11 * time.Second)
+	_, err = client.Exchange.SubmitExchangeRequest(&api.ExchangeSubmitRequest{RequestID: "fx-1", QuoteID: quote.Data.QuoteID})
+	if !errors.Is(err, gsalary.ErrQuoteExpired) {
+		t.Fatalf("Expected ErrQuoteExpired, got %v", err)
+	}
+
+	quote, err = client.Exchange.RequestQuote(&api.ExchangeQuoteRequest{
+		SellCurrency: "USD", BuyCurrency: "EUR", SellAmount: api.MustParseDecimal("60"),
+	})
+	if err != nil {
+		t.Fatalf("RequestQuote failed: %v", err)
+	}
+	order, err := client.Exchange.SubmitExchangeRequest(&api.ExchangeSubmitRequest{RequestID: "fx-2", QuoteID: quote.Data.QuoteID})
+	if err != nil || order.Data.Status != "SUCCESS" || order.Data.Buy.Amount.String() != "54" {
+		t.Fatalf("SubmitExchangeRequest: %v %+v", err, order)
+	}
+	if sim.Balance("USD") != "40" || sim.Balance("EUR") != "54" {
+		t.Errorf("Unexpected balances USD=%s EUR=%s", sim.Balance("USD"), sim.Balance("EUR"))
+	}
+
+	rate, err := client.Exchange.GetCurrentExchangeRate(&api.ExchangeRateRequest{SellCurrency: "EUR", BuyCurrency: "USD"})
+	if err != nil {
+		t.Fatalf("GetCurrentExchangeRate failed: %v", err)
+	}
+	if rounded, err := rate.Data.Rate.Round(4); err != nil || rounded.String() != "1.1111" {
+		t.Errorf("Expected inverse rate, got %v %+v", err, rate)
+	}
+	orders, err := client.Exchange.GetExchangeOrders(&api.ExchangeOrdersRequest{Page: 1, Limit: 10})
+	if err != nil || orders.Data.TotalCount != 1 {
+		t.Errorf("Expected 1 exchange order, got %v %+v", err, orders)
+	}
+}
+
+// TestSimulatorRemittance 测试付款订单 CREATED→SUCCESS/FAILED 的流转和失败退款
+func TestSimulatorRemittance(t *testing.T) {
+	server := NewServer(t)
+	// 结算由后台任务异步完成，延长 SettleDelay 以便在 Settle 之前检查扣款
+	sim := NewSimulator(server, WithSettleDelay(time.Hour))
+	sim.Deposit("USD", "500")
+	sim.SetRate("USD", "CNY", "7.1")
+	sim.FailRemittance("pay-2", "payee account closed")
+	client := api.NewClient(server.Config())
+
+	submit := func(clientOrderID, amount string) *api.RemittanceOrder {
+		t.Helper()
+		quote, err := client.Remittance.CreateQuote(&api.QuoteRequest{
+			PayeeAccountID: "acc-1", PayCurrency: "USD", ReceiveCurrency: "CNY",
+			Amount: api.MustParseDecimal(amount), AmountType: "PAY_AMOUNT",
+		})
+		if err != nil {
+			t.Fatalf("CreateQuote failed: %v", err)
+		}
+		order, err := client.Remittance.SubmitOrder(&api.OrderRequest{QuoteID: quote.Data.QuoteID, ClientOrderID: clientOrderID})
+		if err != nil {
+			t.Fatalf("SubmitOrder failed: %v", err)
+		}
+		return &order.Data
+	}
+	first := submit("pay-1", "100")
+	if first.Status != "CREATED" || first.ReceiveAmount.Amount.String() != "710" {
+		t.Errorf("Unexpected order %+v", first)
+	}
+	submit("pay-2", "200")
+	if got := sim.Balance("USD"); got != "200" {
+		t.Errorf("Expected both orders to be debited, got %s", got)
+	}
+
+	sim.Settle()
+	list, err := client.Remittance.GetOrderList(&api.OrderListRequest{Page: 1, Limit: 10})
+	if err != nil || len(list.Data.Orders) != 2 {
+		t.Fatalf("GetOrderList: %v %+v", err, list)
+	}
+	if list.Data.Orders[0].Status != "SUCCESS" || list.Data.Orders[1].Status != "FAILED" ||
+		list.Data.Orders[1].ErrorMessage != "payee account closed" {
+		t.Errorf("Unexpected statuses %+v", list.Data.Orders)
+	}
+	if got := sim.Balance("USD"); got != "400" {
+		t.Errorf("Expected failed order to be refunded, got %s", got)
+	}
+
+	quote, err := client.Remittance.CreateQuote(&api.QuoteRequest{
+		PayeeAccountID: "acc-1", PayCurrency: "USD", Amount: api.MustParseDecimal("500"), AmountType: "PAY_AMOUNT",
+	})
+	if err != nil {
+		t.Fatalf("CreateQuote failed: %v", err)
+	}
+	_, err = client.Remittance.SubmitOrder(&api.OrderRequest{QuoteID: quote.Data.QuoteID, ClientOrderID: "pay-3"})
+	if !errors.Is(err, gsalary.ErrInsufficientBalance) {
+		t.Errorf("Expected ErrInsufficientBalance, got %v", err)
+	}
+}
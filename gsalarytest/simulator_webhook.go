@@ -0,0 +1,291 @@
+package gsalarytest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	gsalary "github.com/difyz9/gsalary-sdk-go"
+)
+
+const (
+	// DefaultWebhookAttempts 每个Webhook默认最多推送的次数
+	DefaultWebhookAttempts = 3
+	// DefaultWebhookRetryInterval 推送失败后默认的重试间隔
+	DefaultWebhookRetryInterval = 100 * time.Millisecond
+)
+
+// Webhook 模拟器产生的Webhook推送及其投递结果
+type Webhook struct {
+	BusinessType string
+	Body         []byte // 签名覆盖的请求体：{"business_type","event_time","business_id","data"}，另带 app_id 和毫秒 timestamp
+	Attempts     int    // 已推送次数
+	Delivered    bool   // 接收方是否返回了 result=S
+	Err          error  // 最后一次推送失败的原因
+
+	done chan struct{}
+}
+
+// WithWebhookURL 状态变更时向 url 推送签名的Webhook，未设置时只记录不推送
+func WithWebhookURL(url string) SimulatorOption {
+	return func(sim *Simulator) {
+		sim.webhooks.url = url
+	}
+}
+
+// WithWebhookRetry 设置每个Webhook最多推送的次数和重试间隔
+// 网络错误、非200状态码或响应的 result 不是 S 时重试
+func WithWebhookRetry(attempts int, interval time.Duration) SimulatorOption {
+	return func(sim *Simulator) {
+		sim.webhooks.attempts = max(attempts, 1)
+		sim.webhooks.interval = interval
+	}
+}
+
+// Webhooks 返回模拟器产生的Webhook副本，按产生顺序排列
+func (sim *Simulator) Webhooks() []Webhook {
+	return sim.webhooks.snapshot()
+}
+
+// WaitWebhooks 等待已产生的Webhook全部推送完成（成功或用完重试次数）
+func (sim *Simulator) WaitWebhooks(ctx context.Context) error {
+	last := sim.webhooks.last()
+	if last == nil {
+		return nil
+	}
+	select {
+	case <-last.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Emit 推送任意业务类型的Webhook，如 PAYEE_DEACTIVATED、ACQUIRING_AUTH_TOKEN
+func (sim *Simulator) Emit(businessType string, data interface{}) {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	sim.emit(businessType, sim.nextID("evt_"), data)
+}
+
+// NotifyPayment 推送收单支付结果 ACQUIRING_PAYMENT_RESULT，返回生成的 payment_id
+// status 为 SUCCESS/FAIL/PROCESSING
+func (sim *Simulator) NotifyPayment(paymentRequestID, currency, amount, status string) (string, error) {
+	value, ok := parseAmount(json.Number(amount))
+	if !ok || value.Sign() <= 0 {
+		return "", fmt.Errorf("gsalarytest: invalid payment amount %q", amount)
+	}
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	paymentID := sim.nextID("pay_")
+	resultCode := "SUCCESS"
+	if status != "SUCCESS" {
+		resultCode = status
+	}
+	sim.emit("ACQUIRING_PAYMENT_RESULT", paymentID, map[string]interface{}{
+		"payment_request_id":  paymentRequestID,
+		"payment_id":          paymentID,
+		"payment_amount":      formatAmount(value),
+		"payment_currency":    currency,
+		"payment_status":      status,
+		"payment_result_code": resultCode,
+		"payment_result_info": map[string]interface{}{},
+		"payment_time":        formatTime(sim.server.now()),
+	})
+	return paymentID, nil
+}
+
+// emit 按 webhook.md 的通用请求体记录并排队推送Webhook，调用方需持有锁
+// 毫秒级的 timestamp 和 app_id 为兼容旧版SDK保留
+func (sim *Simulator) emit(businessType, businessID string, data interface{}) {
+	now := sim.server.now()
+	body, err := json.Marshal(struct {
+		BusinessType string      `json:"business_type"`
+		EventTime    string      `json:"event_time"`
+		BusinessID   string      `json:"business_id"`
+		AppID        string      `json:"app_id"`
+		Timestamp    int64       `json:"timestamp"`
+		Data         interface{} `json:"data"`
+	}{businessType, formatTime(now), businessID, sim.server.AppID, now.UnixMilli(), data})
+	if err != nil {
+		panic("gsalarytest: encode webhook: " + err.Error())
+	}
+	sim.webhooks.enqueue(&Webhook{BusinessType: businessType, Body: body, done: make(chan struct{})})
+}
+
+// webhookSender 按产生顺序串行推送Webhook的后台任务
+type webhookSender struct {
+	url      string
+	attempts int
+	interval time.Duration
+	client   *http.Client
+	signer   gsalary.Signer
+	now      func() time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wake   chan struct{}
+	closed chan struct{}
+
+	mu      sync.Mutex
+	hooks   []*Webhook
+	pending []*Webhook
+}
+
+// start 启动推送任务，未设置URL时不启动
+func (w *webhookSender) start(server *Server) {
+	w.signer = server.signer
+	w.now = server.now
+	w.client = &http.Client{Timeout: 5 * time.Second}
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.wake = make(chan struct{}, 1)
+	w.closed = make(chan struct{})
+	if w.url == "" {
+		close(w.closed)
+		return
+	}
+	go w.run()
+	server.onClose(w.close)
+}
+
+// close 停止推送任务，未推送完的Webhook以 context.Canceled 结束
+func (w *webhookSender) close() {
+	w.cancel()
+	<-w.closed
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, hook := range w.pending {
+		hook.Err = w.ctx.Err()
+		close(hook.done)
+	}
+	w.pending = nil
+}
+
+// enqueue 记录Webhook，设置了URL时排队推送
+func (w *webhookSender) enqueue(hook *Webhook) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.hooks = append(w.hooks, hook)
+	if w.url == "" || w.ctx.Err() != nil {
+		close(hook.done)
+		return
+	}
+	w.pending = append(w.pending, hook)
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run 依次推送排队的Webhook
+func (w *webhookSender) run() {
+	defer close(w.closed)
+	for {
+		w.mu.Lock()
+		var hook *Webhook
+		if len(w.pending) > 0 {
+			hook = w.pending[0]
+		}
+		w.mu.Unlock()
+		if hook == nil {
+			select {
+			case <-w.wake:
+				continue
+			case <-w.ctx.Done():
+				return
+			}
+		}
+		if !w.deliver(hook) {
+			return
+		}
+		w.mu.Lock()
+		w.pending = w.pending[1:]
+		close(hook.done)
+		w.mu.Unlock()
+	}
+}
+
+// deliver 推送一个Webhook直到成功或用完次数，任务停止时返回 false
+func (w *webhookSender) deliver(hook *Webhook) bool {
+	for attempt := 1; attempt <= w.attempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(w.interval):
+			case <-w.ctx.Done():
+				return false
+			}
+		}
+		err := w.post(hook.Body)
+		w.mu.Lock()
+		hook.Attempts = attempt
+		hook.Err = err
+		hook.Delivered = err == nil
+		w.mu.Unlock()
+		if err == nil {
+			break
+		}
+	}
+	return true
+}
+
+// post 签名并推送一次，接收方返回200且 result 为 S 时成功
+func (w *webhookSender) post(body []byte) error {
+	authorization, err := gsalary.SignWebhook(w.ctx, w.signer, strconv.FormatInt(w.now().UnixMilli(), 10), body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(w.ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", authorization)
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+	}
+	var result struct {
+		Result string `json:"result"`
+		Code   string `json:"code"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("webhook returned invalid response: %w", err)
+	}
+	if result.Result != gsalary.ResultSuccess {
+		return fmt.Errorf("webhook returned result %q (%s)", result.Result, result.Code)
+	}
+	return nil
+}
+
+// snapshot 返回所有Webhook的副本
+func (w *webhookSender) snapshot() []Webhook {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	hooks := make([]Webhook, len(w.hooks))
+	for i, hook := range w.hooks {
+		hooks[i] = *hook
+		hooks[i].done = nil
+	}
+	return hooks
+}
+
+// last 返回最后产生的Webhook
+func (w *webhookSender) last() *Webhook {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.hooks) == 0 {
+		return nil
+	}
+	return w.hooks[len(w.hooks)-1]
+}
@@ -0,0 +1,246 @@
+package gsalarytest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/difyz9/gsalary-sdk-go/api"
+)
+
+// webhookReceiver 用 api.WebhookHandler 验签的Webhook接收端，第一次推送返回 F
+type webhookReceiver struct {
+	mu       sync.Mutex
+	handler  *api.WebhookHandler
+	received []api.WebhookRequest
+	calls    int
+	errs     []error
+}
+
+func (rc *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.calls++
+	if rc.calls == 1 {
+		json.NewEncoder(w).Encode(api.WebhookResponse{Result: "F", Code: "BUSY"})
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := rc.handler.HandleWebhook(r)
+	if err != nil {
+		rc.errs = append(rc.errs, err)
+	} else {
+		var req api.WebhookRequest
+		json.Unmarshal(body, &req)
+		rc.received = append(rc.received, req)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// TestSimulatorWebhooks 测试状态变更和注入事件的签名推送，以及 result 非 S 时的重试
+func TestSimulatorWebhooks(t *testing.T) {
+	server := NewServer(t)
+	receiver := &webhookReceiver{handler: api.NewWebhookHandler(server.Config())}
+	target := httptest.NewServer(receiver)
+	defer target.Close()
+	sim := NewSimulator(server, WithWebhookURL(target.URL), WithWebhookRetry(3, time.Millisecond))
+	sim.Deposit("USD", "1000")
+	sim.SetRate("USD", "EUR", "0.9")
+	client := api.NewClient(server.Config())
+
+	holder, err := client.CardHolder.AddCardHolder(&api.CardHolderRequest{FirstName: "Ada", LastName: "L", Email: "ada@example.com"})
+	if err != nil {
+		t.Fatalf("AddCardHolder failed: %v", err)
+	}
+	if _, err := client.Card.ApplyCard(&api.CardApplyRequest{
+		RequestID: "apply-1", Currency: "USD", CardHolderID: holder.Data.CardHolderID, InitBalance: api.MustParseDecimal("100"),
+	}); err != nil {
+		t.Fatalf("ApplyCard failed: %v", err)
+	}
+	result, err := client.Card.GetCardApplyResult("apply-1")
+	if err != nil {
+		t.Fatalf("GetCardApplyResult failed: %v", err)
+	}
+	cardID, _ := result.Data["card_id"].(string)
+	if _, err := client.Card.AdjustCardBalance(&api.AdjustCardBalanceRequest{
+		CardID: cardID, Amount: api.MustParseDecimal("20"), Type: "INCREASE", RequestID: "adjust-1",
+	}); err != nil {
+		t.Fatalf("AdjustCardBalance failed: %v", err)
+	}
+
+	authID, err := sim.Authorize(cardID, "70", "ACME STORE")
+	if err != nil {
+		t.Fatalf("Authorize failed: %v", err)
+	}
+	if _, err := sim.Refund(authID, "25"); err != nil {
+		t.Fatalf("Refund failed: %v", err)
+	}
+	if _, err := sim.Refund(authID, "50"); err == nil {
+		t.Error("Expected refund above the authorized amount to fail")
+	}
+	if got := sim.CardBalance(cardID); got != "75" {
+		t.Errorf("Expected card balance 75, got %s", got)
+	}
+	declined, _ := sim.Authorize(cardID, "1000", "ACME STORE")
+	transactions, err := client.Card.GetCardTransactions(&api.CardTransactionsRequest{Page: 1, Limit: 10, TransactionID: declined})
+	if err != nil || len(transactions.Data.Transactions) != 1 || transactions.Data.Transactions[0].Status != "FAIL" {
+		t.Errorf("Expected declined transaction, got %v %+v", err, transactions)
+	}
+
+	if _, err := client.Card.SetCardFreezeStatus(&api.SetCardFreezeStatusRequest{CardID: cardID, Freeze: true}); err != nil {
+		t.Fatalf("SetCardFreezeStatus failed: %v", err)
+	}
+	quote, err := client.Exchange.RequestQuote(&api.ExchangeQuoteRequest{SellCurrency: "USD", BuyCurrency: "EUR", SellAmount: api.MustParseDecimal("10")})
+	if err != nil {
+		t.Fatalf("RequestQuote failed: %v", err)
+	}
+	if _, err := client.Exchange.SubmitExchangeRequest(&api.ExchangeSubmitRequest{RequestID: "fx-1", QuoteID: quote.Data.QuoteID}); err != nil {
+		t.Fatalf("SubmitExchangeRequest failed: %v", err)
+	}
+	remitQuote, err := client.Remittance.CreateQuote(&api.QuoteRequest{PayeeAccountID: "acc-1", PayCurrency: "USD", Amount: api.MustParseDecimal("5"), AmountType: "PAY_AMOUNT"})
+	if err != nil {
+		t.Fatalf("CreateQuote failed: %v", err)
+	}
+	if _, err := client.Remittance.SubmitOrder(&api.OrderRequest{QuoteID: remitQuote.Data.QuoteID, ClientOrderID: "pay-1"}); err != nil {
+		t.Fatalf("SubmitOrder failed: %v", err)
+	}
+	sim.Settle()
+	paymentID, err := sim.NotifyPayment("payment-1", "USD", "9.99", "SUCCESS")
+	if err != nil {
+		t.Fatalf("NotifyPayment failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := sim.WaitWebhooks(ctx); err != nil {
+		t.Fatalf("WaitWebhooks failed: %v", err)
+	}
+
+	want := []string{
+		api.EventCardApplyResult, api.EventCardAdjustResult,
+		api.EventCardTransaction, api.EventCardTransaction, api.EventCardTransaction,
+		api.EventCardStatusUpdate, api.EventExchangeOrderResult,
+		api.EventRemittanceOrderResult, api.EventAcquiringPaymentResult,
+	}
+	hooks := sim.Webhooks()
+	if len(hooks) != len(want) {
+		t.Fatalf("Expected %d webhooks, got %d", len(want), len(hooks))
+	}
+	for i, hook := range hooks {
+		if hook.BusinessType != want[i] || !hook.Delivered {
+			t.Errorf("webhook %d: got %s delivered=%v err=%v", i, hook.BusinessType, hook.Delivered, hook.Err)
+		}
+	}
+	if hooks[0].Attempts != 2 {
+		t.Errorf("Expected the first webhook to be retried once, got %d attempts", hooks[0].Attempts)
+	}
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	if len(receiver.errs) > 0 {
+		t.Fatalf("Webhook verification failed: %v", receiver.errs)
+	}
+	refund, err := receiver.handler.ParseCardTransaction(&receiver.received[3])
+	if err != nil || refund.TransactionType != "REFUND" || refund.Amount.String() != "25" {
+		t.Errorf("Unexpected refund webhook: %v %+v", err, refund)
+	}
+	payment, err := receiver.handler.ParsePaymentResult(&receiver.received[8])
+	if err != nil || payment.PaymentRequestID != "payment-1" || payment.PaymentStatus != "SUCCESS" {
+		t.Errorf("Unexpected payment webhook: %v %+v", err, payment)
+	}
+	for i, req := range receiver.received {
+		if _, err := time.Parse(time.RFC3339, req.EventTime); err != nil || req.BusinessID == "" {
+			t.Errorf("webhook %d: expected documented envelope, got event_time=%q business_id=%q", i, req.EventTime, req.BusinessID)
+		}
+	}
+	if got := receiver.received[8].BusinessID; got != paymentID {
+		t.Errorf("Expected payment business_id %s, got %s", paymentID, got)
+	}
+}
+
+// TestSimulatorWebhookRetryExhausted 测试接收端持续失败时用完重试次数
+func TestSimulatorWebhookRetryExhausted(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer target.Close()
+
+	server := NewServer(t)
+	sim := NewSimulator(server, WithWebhookURL(target.URL), WithWebhookRetry(3, time.Millisecond))
+	sim.Emit(api.EventPayeeDeactivated, map[string]string{"payee_id": "payee-1"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := sim.WaitWebhooks(ctx); err != nil {
+		t.Fatalf("WaitWebhooks failed: %v", err)
+	}
+	hook := sim.Webhooks()[0]
+	if hook.Delivered || hook.Attempts != 3 || hook.Err == nil {
+		t.Errorf("Expected 3 failed attempts, got %+v", hook)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 3 {
+		t.Errorf("Expected 3 deliveries, got %d", calls)
+	}
+}
+
+// TestSimulatorAsyncSettle 测试开卡和付款订单到期后由后台任务完成并推送，无需再发起请求
+func TestSimulatorAsyncSettle(t *testing.T) {
+	server := NewServer(t)
+	receiver := &webhookReceiver{handler: api.NewWebhookHandler(server.Config()), calls: 1}
+	target := httptest.NewServer(receiver)
+	defer target.Close()
+	sim := NewSimulator(server, WithSettleDelay(50*time.Millisecond), WithWebhookURL(target.URL))
+	sim.Deposit("USD", "1000")
+	client := api.NewClient(server.Config())
+
+	holder, err := client.CardHolder.AddCardHolder(&api.CardHolderRequest{FirstName: "Ada", LastName: "L", Email: "ada@example.com"})
+	if err != nil {
+		t.Fatalf("AddCardHolder failed: %v", err)
+	}
+	if _, err := client.Card.ApplyCard(&api.CardApplyRequest{
+		RequestID: "apply-1", Currency: "USD", CardHolderID: holder.Data.CardHolderID, InitBalance: api.MustParseDecimal("100"),
+	}); err != nil {
+		t.Fatalf("ApplyCard failed: %v", err)
+	}
+	quote, err := client.Remittance.CreateQuote(&api.QuoteRequest{PayeeAccountID: "acc-1", PayCurrency: "USD", Amount: api.MustParseDecimal("5"), AmountType: "PAY_AMOUNT"})
+	if err != nil {
+		t.Fatalf("CreateQuote failed: %v", err)
+	}
+	if _, err := client.Remittance.SubmitOrder(&api.OrderRequest{QuoteID: quote.Data.QuoteID, ClientOrderID: "pay-1"}); err != nil {
+		t.Fatalf("SubmitOrder failed: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(sim.Webhooks()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := sim.WaitWebhooks(ctx); err != nil {
+		t.Fatalf("WaitWebhooks failed: %v", err)
+	}
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	var types []string
+	for _, req := range receiver.received {
+		types = append(types, req.BusinessType)
+	}
+	if len(types) != 2 || types[0] != api.EventCardApplyResult || types[1] != api.EventRemittanceOrderResult {
+		t.Errorf("Expected apply and remittance results without further requests, got %v (errors %v)", types, receiver.errs)
+	}
+}
@@ -0,0 +1,219 @@
+package gsalary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Instrumentation 请求追踪和指标钩子
+//
+// 每次 Request、RequestCtx、Do 调用开始时调用 StartSpan，结束时调用返回的 Span 的 End，
+// 一次调用内的重试属于同一个 Span。可以桥接到 OpenTelemetry，也可以直接使用 PrometheusExporter。
+type Instrumentation interface {
+	// StartSpan 返回的 ctx 会传递给拦截器链和HTTP请求
+	StartSpan(ctx context.Context, start SpanStart) (context.Context, Span)
+}
+
+// Span 一次API调用
+type Span interface {
+	End(end SpanEnd)
+}
+
+// SpanStart 调用开始时的属性
+type SpanStart struct {
+	Method string    // HTTP方法
+	Path   string    // 模板化的路径，如 /v1/cards/{card_id}，见 PathTemplate
+	AppID  string    // 商户AppID
+	Time   time.Time // 开始时间
+}
+
+// SpanEnd 调用结束时的属性
+type SpanEnd struct {
+	// HTTPStatus HTTP状态码，未收到响应时为0
+	HTTPStatus int
+	// Result 业务结果 S/F/U：200 响应取 result.result，错误响应取 biz_result
+	Result string
+	// Code 错误码：200 响应取 result.code，错误响应取 error_code
+	Code     string
+	Attempts int           // 实际发送次数（含重试），被熔断或限流拦下时可能为0
+	Duration time.Duration // 总耗时，含重试等待
+	Err      error         // 调用返回的错误
+}
+
+// WithInstrumentation 设置追踪和指标钩子
+func WithInstrumentation(instrumentation Instrumentation) Option {
+	return func(o *clientOptions) {
+		o.instrumentation = instrumentation
+	}
+}
+
+// OtherPath 不属于 SDK 封装接口的路径在指标标签中统一使用的模板
+const OtherPath = "other"
+
+// routeTemplates SDK封装的全部接口路由，路径参数用 {name} 表示
+var routeTemplates = compileRoutes(
+	"/attachments",
+	"/gateway/v1/acquiring/auth_refresh_token",
+	"/gateway/v1/acquiring/auth_revoke_token",
+	"/gateway/v1/acquiring/cancel",
+	"/gateway/v1/acquiring/card_auto_debit/pay",
+	"/gateway/v1/acquiring/card_auto_debit/pay_session",
+	"/gateway/v1/acquiring/easy_safe_pay/pay",
+	"/gateway/v1/acquiring/easy_safe_pay/pay_session",
+	"/gateway/v1/acquiring/pay_consult",
+	"/gateway/v1/acquiring/pay_session",
+	"/gateway/v1/acquiring/query",
+	"/remittance/available_payment_methods",
+	"/remittance/clearing_networks",
+	"/remittance/orders",
+	"/remittance/payee_accounts/{account_id}",
+	"/remittance/payees",
+	"/remittance/payees/{payee_id}",
+	"/remittance/payees/{payee_id}/account_register_format",
+	"/remittance/payees/{payee_id}/account_registry",
+	"/remittance/payees/{payee_id}/accounts",
+	"/remittance/payees/{payee_id}/payee_accounts/{account_id}",
+	"/remittance/payers",
+	"/remittance/payers/{payer_id}",
+	"/remittance/payout_currencies",
+	"/remittance/quotes",
+	"/v1/card_applies",
+	"/v1/card_applies/{request_id}",
+	"/v1/card_bill/balance_history",
+	"/v1/card_bill/card_transactions",
+	"/v1/card_holders",
+	"/v1/card_holders/{card_holder_id}",
+	"/v1/card_support/products",
+	"/v1/cards",
+	"/v1/cards/available_quotas",
+	"/v1/cards/balance_modifies",
+	"/v1/cards/balance_modifies/{request_id}",
+	"/v1/cards/{card_id}",
+	"/v1/cards/{card_id}/contact",
+	"/v1/cards/{card_id}/freeze_status",
+	"/v1/cards/{card_id}/secure_info",
+	"/v1/exchange/current_exchange_rate",
+	"/v1/exchange/orders",
+	"/v1/exchange/quotes",
+	"/v1/exchange/submit_request",
+	"/v1/wallets/balance",
+)
+
+// compileRoutes 将路由拆分为路径段
+func compileRoutes(routes ...string) [][]string {
+	compiled := make([][]string, len(routes))
+	for i, route := range routes {
+		compiled[i] = strings.Split(route, "/")
+	}
+	return compiled
+}
+
+// PathTemplate 将请求路径中的ID替换为参数名，如 /v1/cards/abc123 返回 /v1/cards/{card_id}
+// 用作指标标签以避免基数爆炸；不属于 SDK 封装接口的路径返回 OtherPath
+func PathTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	var best []string
+	bestLiterals := -1
+	for _, route := range routeTemplates {
+		if len(route) != len(segments) {
+			continue
+		}
+		literals := 0
+		for i, segment := range route {
+			if strings.HasPrefix(segment, "{") {
+				if segments[i] == "" {
+					literals = -1
+					break
+				}
+				continue
+			}
+			if segment != segments[i] {
+				literals = -1
+				break
+			}
+			literals++
+		}
+		if literals > bestLiterals {
+			best, bestLiterals = route, literals
+		}
+	}
+	if best == nil {
+		return OtherPath
+	}
+	return strings.Join(best, "/")
+}
+
+// spanAttemptsKey ctx 中记录发送次数的键
+type spanAttemptsKey struct{}
+
+// startSpan 开始追踪一次调用，未配置 Instrumentation 时返回 nil
+func (c *GSalaryClient) startSpan(ctx context.Context, request *GSalaryRequest) (context.Context, *spanRecorder) {
+	if c.instrumentation == nil {
+		return ctx, nil
+	}
+	recorder := &spanRecorder{start: time.Now()}
+	ctx = context.WithValue(ctx, spanAttemptsKey{}, &recorder.attempts)
+	ctx, recorder.span = c.instrumentation.StartSpan(ctx, SpanStart{
+		Method: request.Method,
+		Path:   PathTemplate(request.Path),
+		AppID:  c.config.AppID,
+		Time:   recorder.start,
+	})
+	return ctx, recorder
+}
+
+// countAttempt 记录一次实际发送
+func countAttempt(ctx context.Context) {
+	if attempts, ok := ctx.Value(spanAttemptsKey{}).(*int32); ok {
+		atomic.AddInt32(attempts, 1)
+	}
+}
+
+// spanRecorder 记录一次调用的 Span 和发送次数
+type spanRecorder struct {
+	span     Span
+	start    time.Time
+	attempts int32
+}
+
+// end 结束 Span，resp 为最后一次响应，err 为调用返回的错误
+func (r *spanRecorder) end(resp *Response, err error) {
+	if r == nil {
+		return
+	}
+	end := SpanEnd{
+		Attempts: int(atomic.LoadInt32(&r.attempts)),
+		Duration: time.Since(r.start),
+		Err:      err,
+	}
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &apiErr):
+		end.HTTPStatus, end.Result, end.Code = apiErr.HTTPStatus, apiErr.Result, apiErr.Code
+	case resp != nil:
+		end.HTTPStatus = resp.StatusCode
+		if resp.StatusCode == http.StatusOK {
+			end.Result, end.Code = resultOf(resp.Body)
+		}
+	}
+	r.span.End(end)
+}
+
+// resultOf 提取200响应中的 result.result 和 result.code
+func resultOf(body []byte) (string, string) {
+	var envelope struct {
+		Result struct {
+			Result string `json:"result"`
+			Code   string `json:"code"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return "", ""
+	}
+	return envelope.Result.Result, envelope.Result.Code
+}
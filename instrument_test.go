@@ -0,0 +1,85 @@
+package gsalary
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// recordingInstrumentation 记录 Span 属性的测试钩子
+type recordingInstrumentation struct {
+	starts []SpanStart
+	ends   []SpanEnd
+}
+
+func (r *recordingInstrumentation) StartSpan(ctx context.Context, start SpanStart) (context.Context, Span) {
+	r.starts = append(r.starts, start)
+	return ctx, r
+}
+
+func (r *recordingInstrumentation) End(end SpanEnd) {
+	r.ends = append(r.ends, end)
+}
+
+// TestPathTemplate 测试路径模板化，固定路由优先于路径参数
+func TestPathTemplate(t *testing.T) {
+	for path, want := range map[string]string{
+		"/v1/cards/abc123":                       "/v1/cards/{card_id}",
+		"/v1/cards/abc123/secure_info":           "/v1/cards/{card_id}/secure_info",
+		"/v1/cards/available_quotas":             "/v1/cards/available_quotas",
+		"/v1/cards/balance_modifies/req-1":       "/v1/cards/balance_modifies/{request_id}",
+		"/remittance/payees/p1/payee_accounts/a": "/remittance/payees/{payee_id}/payee_accounts/{account_id}",
+		"/v1/wallets/balance":                    "/v1/wallets/balance",
+		"/v1/cards/":                             OtherPath,
+		"/v1/unknown/abc123":                     OtherPath,
+		"/v1/cards/abc123/unknown":               OtherPath,
+	} {
+		if got := PathTemplate(path); got != want {
+			t.Errorf("PathTemplate(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+// TestInstrumentation 测试 Span 的属性、重试次数和错误码
+func TestInstrumentation(t *testing.T) {
+	var calls int32
+	var config *GSalaryConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusLocked)
+		case 2:
+			signedHandler(t, config, http.StatusOK, `{"result":{"result":"S","code":""}}`)(w, r)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"biz_result":"F","error_code":"CARD_NOT_FOUND","message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+	config = newTestConfig(t, server.URL)
+
+	recorder := &recordingInstrumentation{}
+	client := NewClient(config, WithInstrumentation(recorder), WithRetryPolicy(fastRetryPolicy()))
+	if _, err := client.Request(NewRequest("GET", "/v1/cards/c1")); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if _, err := client.Request(NewRequest("GET", "/v1/cards/c2")); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+
+	if len(recorder.starts) != 2 || len(recorder.ends) != 2 {
+		t.Fatalf("Expected 2 spans, got %d/%d", len(recorder.starts), len(recorder.ends))
+	}
+	if start := recorder.starts[0]; start.Method != "GET" || start.Path != "/v1/cards/{card_id}" || start.AppID != "test_app" {
+		t.Errorf("Unexpected span start %+v", start)
+	}
+	if end := recorder.ends[0]; end.HTTPStatus != 200 || end.Result != "S" || end.Attempts != 2 || end.Err != nil {
+		t.Errorf("Unexpected first span end %+v", end)
+	}
+	if end := recorder.ends[1]; end.HTTPStatus != 404 || end.Result != "F" || end.Code != "CARD_NOT_FOUND" || end.Attempts != 1 {
+		t.Errorf("Unexpected second span end %+v", end)
+	}
+}
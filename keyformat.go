@@ -0,0 +1,507 @@
+package gsalary
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"strings"
+)
+
+var (
+	// ErrPassphraseRequired 私钥已加密，但没有提供密码
+	ErrPassphraseRequired = errors.New("gsalary: encrypted private key requires a passphrase")
+	// ErrIncorrectPassphrase 私钥密码错误
+	ErrIncorrectPassphrase = errors.New("gsalary: incorrect private key passphrase")
+)
+
+// KeyFormatError 密钥解析错误，Format 为识别出的格式
+type KeyFormatError struct {
+	Format string
+	Err    error
+}
+
+// Error 实现error接口
+func (e *KeyFormatError) Error() string {
+	return fmt.Sprintf("gsalary: detected %s: %v", e.Format, e.Err)
+}
+
+// Unwrap 返回底层错误
+func (e *KeyFormatError) Unwrap() error {
+	return e.Err
+}
+
+// keyFormatError 构造 *KeyFormatError
+func keyFormatError(format string, err error) error {
+	return &KeyFormatError{Format: format, Err: err}
+}
+
+// ParsePrivateKey 自动识别并解析RSA私钥
+//
+// 支持的格式：PKCS#8（BEGIN PRIVATE KEY）、PKCS#1（BEGIN RSA PRIVATE KEY）、
+// 加密的 PKCS#8（BEGIN ENCRYPTED PRIVATE KEY，PBES2）、传统加密PEM（Proc-Type: 4,ENCRYPTED）、
+// DER 二进制、不带头尾的 base64 DER 以及 RSA JWK。passphrase 仅用于加密私钥。
+func ParsePrivateKey(data, passphrase []byte) (*rsa.PrivateKey, error) {
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0:
+		return nil, errors.New("gsalary: private key is empty")
+	case data[0] == '{':
+		return parseJWKPrivateKey(data)
+	case bytes.Contains(data, []byte("-----BEGIN")):
+		return parsePEMPrivateKey(data, passphrase)
+	case data[0] == 0x30:
+		return parseDERPrivateKey("DER", data)
+	}
+	der, err := decodeBase64Key(data)
+	if err != nil {
+		return nil, err
+	}
+	return parseDERPrivateKey("base64 DER without PEM header", der)
+}
+
+// ParsePublicKey 自动识别并解析RSA公钥
+//
+// 支持的格式：PKIX（BEGIN PUBLIC KEY）、PKCS#1（BEGIN RSA PUBLIC KEY）、X.509 证书、
+// DER 二进制、不带头尾的 base64 DER 以及 RSA JWK。
+func ParsePublicKey(data []byte) (*rsa.PublicKey, error) {
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0:
+		return nil, errors.New("gsalary: public key is empty")
+	case data[0] == '{':
+		return parseJWKPublicKey(data)
+	case bytes.Contains(data, []byte("-----BEGIN")):
+		return parsePEMPublicKey(data)
+	case data[0] == 0x30:
+		return parseDERPublicKey("DER", data)
+	}
+	der, err := decodeBase64Key(data)
+	if err != nil {
+		return nil, err
+	}
+	return parseDERPublicKey("base64 DER without PEM header", der)
+}
+
+// decodeBase64Key 解码不带PEM头尾的base64密钥，忽略空白字符
+func decodeBase64Key(data []byte) ([]byte, error) {
+	text := strings.Join(strings.Fields(string(data)), "")
+	der, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return nil, errors.New("gsalary: unrecognized key format: expected PEM, DER, base64 DER or JWK")
+	}
+	return der, nil
+}
+
+// parsePEMPrivateKey 按PEM块类型解析私钥
+func parsePEMPrivateKey(data, passphrase []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, keyFormatError("PEM", errors.New("malformed PEM block"))
+	}
+	format := fmt.Sprintf("PEM %q", block.Type)
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		return parsePKCS8PrivateKey(format+" (PKCS#8)", block.Bytes)
+	case "ENCRYPTED PRIVATE KEY":
+		format += " (encrypted PKCS#8)"
+		if len(passphrase) == 0 {
+			return nil, keyFormatError(format, ErrPassphraseRequired)
+		}
+		der, err := decryptPKCS8(block.Bytes, passphrase)
+		if err != nil {
+			return nil, keyFormatError(format, err)
+		}
+		return parsePKCS8PrivateKey(format, der)
+	case "RSA PRIVATE KEY":
+		format += " (PKCS#1)"
+		der := block.Bytes
+		// 传统加密PEM（openssl genrsa -aes256 等）已被标准库标记为弃用，但仍然常见
+		if x509.IsEncryptedPEMBlock(block) {
+			format = fmt.Sprintf("PEM %q (legacy encrypted PKCS#1)", block.Type)
+			if len(passphrase) == 0 {
+				return nil, keyFormatError(format, ErrPassphraseRequired)
+			}
+			var err error
+			der, err = x509.DecryptPEMBlock(block, passphrase)
+			if err != nil {
+				if errors.Is(err, x509.IncorrectPasswordError) {
+					err = ErrIncorrectPassphrase
+				}
+				return nil, keyFormatError(format, err)
+			}
+		}
+		key, err := x509.ParsePKCS1PrivateKey(der)
+		if err != nil {
+			return nil, keyFormatError(format, err)
+		}
+		return key, nil
+	case "PUBLIC KEY", "RSA PUBLIC KEY", "CERTIFICATE":
+		return nil, keyFormatError(format, errors.New("this is a public key, expected a private key"))
+	case "EC PRIVATE KEY":
+		return nil, keyFormatError(format, errors.New("only RSA keys are supported"))
+	case "OPENSSH PRIVATE KEY":
+		return nil, keyFormatError(format, errors.New("OpenSSH format is not supported, convert with: ssh-keygen -p -m PKCS8 -f <file>"))
+	default:
+		return nil, keyFormatError(format, errors.New("unsupported PEM type for a private key"))
+	}
+}
+
+// parsePKCS8PrivateKey 解析PKCS#8私钥并确认是RSA密钥
+func parsePKCS8PrivateKey(format string, der []byte) (*rsa.PrivateKey, error) {
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, keyFormatError(format, err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, keyFormatError(format, fmt.Errorf("contains %T, only RSA keys are supported", key))
+	}
+	return rsaKey, nil
+}
+
+// parseDERPrivateKey 依次尝试 PKCS#8 和 PKCS#1 解析DER私钥
+func parseDERPrivateKey(format string, der []byte) (*rsa.PrivateKey, error) {
+	key, pkcs8Err := x509.ParsePKCS8PrivateKey(der)
+	if pkcs8Err == nil {
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, keyFormatError(format+" (PKCS#8)", fmt.Errorf("contains %T, only RSA keys are supported", key))
+		}
+		return rsaKey, nil
+	}
+	if rsaKey, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return rsaKey, nil
+	}
+	if _, err := parseDERPublicKey(format, der); err == nil {
+		return nil, keyFormatError(format, errors.New("this is a public key, expected a private key"))
+	}
+	return nil, keyFormatError(format, fmt.Errorf("neither PKCS#8 nor PKCS#1 private key: %v", pkcs8Err))
+}
+
+// parsePEMPublicKey 按PEM块类型解析公钥
+func parsePEMPublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, keyFormatError("PEM", errors.New("malformed PEM block"))
+	}
+	format := fmt.Sprintf("PEM %q", block.Type)
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		return parsePKIXPublicKey(format+" (PKIX)", block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, keyFormatError(format+" (PKCS#1)", err)
+		}
+		return key, nil
+	case "CERTIFICATE":
+		return parseCertificatePublicKey(format, block.Bytes)
+	case "PRIVATE KEY", "RSA PRIVATE KEY", "ENCRYPTED PRIVATE KEY", "EC PRIVATE KEY", "OPENSSH PRIVATE KEY":
+		return nil, keyFormatError(format, errors.New("this is a private key, expected a public key"))
+	default:
+		return nil, keyFormatError(format, errors.New("unsupported PEM type for a public key"))
+	}
+}
+
+// parsePKIXPublicKey 解析PKIX公钥并确认是RSA密钥
+func parsePKIXPublicKey(format string, der []byte) (*rsa.PublicKey, error) {
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, keyFormatError(format, err)
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, keyFormatError(format, fmt.Errorf("contains %T, only RSA keys are supported", key))
+	}
+	return rsaKey, nil
+}
+
+// parseCertificatePublicKey 从X.509证书中取出RSA公钥
+func parseCertificatePublicKey(format string, der []byte) (*rsa.PublicKey, error) {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, keyFormatError(format, err)
+	}
+	rsaKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, keyFormatError(format, fmt.Errorf("certificate contains %T, only RSA keys are supported", cert.PublicKey))
+	}
+	return rsaKey, nil
+}
+
+// parseDERPublicKey 依次尝试 PKIX、PKCS#1 和证书解析DER公钥
+func parseDERPublicKey(format string, der []byte) (*rsa.PublicKey, error) {
+	key, pkixErr := x509.ParsePKIXPublicKey(der)
+	if pkixErr == nil {
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, keyFormatError(format+" (PKIX)", fmt.Errorf("contains %T, only RSA keys are supported", key))
+		}
+		return rsaKey, nil
+	}
+	if rsaKey, err := x509.ParsePKCS1PublicKey(der); err == nil {
+		return rsaKey, nil
+	}
+	if cert, err := x509.ParseCertificate(der); err == nil {
+		return parseCertificatePublicKey(format+" (certificate)", cert.Raw)
+	}
+	if _, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return nil, keyFormatError(format, errors.New("this is a private key, expected a public key"))
+	}
+	if _, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return nil, keyFormatError(format, errors.New("this is a private key, expected a public key"))
+	}
+	return nil, keyFormatError(format, fmt.Errorf("neither PKIX nor PKCS#1 public key: %v", pkixErr))
+}
+
+// jwk RSA JSON Web Key（RFC 7517/7518）
+type jwk struct {
+	Kty  string          `json:"kty"`
+	N    string          `json:"n"`
+	E    string          `json:"e"`
+	D    string          `json:"d"`
+	P    string          `json:"p"`
+	Q    string          `json:"q"`
+	Keys json.RawMessage `json:"keys"`
+}
+
+// parseJWK 解析JWK并校验公共字段
+func parseJWK(data []byte) (*jwk, *rsa.PublicKey, error) {
+	var key jwk
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, nil, keyFormatError("JWK", err)
+	}
+	if key.Keys != nil {
+		return nil, nil, keyFormatError("JWK Set", errors.New("load a single key instead of a key set"))
+	}
+	if key.Kty != "RSA" {
+		return nil, nil, keyFormatError("JWK", fmt.Errorf("kty %q, only RSA is supported", key.Kty))
+	}
+	n, err := decodeJWKInt("n", key.N)
+	if err != nil {
+		return nil, nil, err
+	}
+	e, err := decodeJWKInt("e", key.E)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !e.IsInt64() || e.Int64() > 1<<31-1 {
+		return nil, nil, keyFormatError("JWK", errors.New("exponent e is too large"))
+	}
+	return &key, &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+// parseJWKPrivateKey 解析RSA私钥JWK，需要包含 d、p、q
+func parseJWKPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	key, publicKey, err := parseJWK(data)
+	if err != nil {
+		return nil, err
+	}
+	if key.D == "" {
+		return nil, keyFormatError("JWK", errors.New("this is a public key (no \"d\"), expected a private key"))
+	}
+	if key.P == "" || key.Q == "" {
+		return nil, keyFormatError("JWK", errors.New("private key without primes \"p\" and \"q\" is not supported"))
+	}
+	d, err := decodeJWKInt("d", key.D)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decodeJWKInt("p", key.P)
+	if err != nil {
+		return nil, err
+	}
+	q, err := decodeJWKInt("q", key.Q)
+	if err != nil {
+		return nil, err
+	}
+	privateKey := &rsa.PrivateKey{PublicKey: *publicKey, D: d, Primes: []*big.Int{p, q}}
+	if err := privateKey.Validate(); err != nil {
+		return nil, keyFormatError("JWK", err)
+	}
+	privateKey.Precompute()
+	return privateKey, nil
+}
+
+// parseJWKPublicKey 解析RSA公钥JWK
+func parseJWKPublicKey(data []byte) (*rsa.PublicKey, error) {
+	key, publicKey, err := parseJWK(data)
+	if err != nil {
+		return nil, err
+	}
+	if key.D != "" {
+		return nil, keyFormatError("JWK", errors.New("this is a private key (has \"d\"), expected a public key"))
+	}
+	return publicKey, nil
+}
+
+// decodeJWKInt 解码base64url编码的大整数
+func decodeJWKInt(name, value string) (*big.Int, error) {
+	if value == "" {
+		return nil, keyFormatError("JWK", fmt.Errorf("missing %q", name))
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, keyFormatError("JWK", fmt.Errorf("invalid base64url in %q: %v", name, err))
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
+
+var (
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+	oidAES128CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3CBC     = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+)
+
+// encryptedPrivateKeyInfo PKCS#8 EncryptedPrivateKeyInfo
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// pbes2Params PBES2-params（RFC 8018）
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params PBKDF2-params（RFC 8018），PRF 缺省为 hmacWithSHA1
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// maxPBKDF2Iterations 加密私钥允许的最大 PBKDF2 迭代次数
+// 迭代次数来自密钥文件本身，不加限制时构造的密钥文件可以长时间占用CPU
+const maxPBKDF2Iterations = 10_000_000
+
+// decryptPKCS8 解密 PBES2（PBKDF2 + AES-CBC/3DES-CBC）加密的PKCS#8私钥
+func decryptPKCS8(der, passphrase []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("malformed EncryptedPrivateKeyInfo: %v", err)
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("unsupported encryption %v, only PBES2 is supported (re-encrypt with: openssl pkcs8 -topk8 -v2 aes-256-cbc)", info.Algorithm.Algorithm)
+	}
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("malformed PBES2 parameters: %v", err)
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("unsupported key derivation %v, only PBKDF2 is supported", params.KeyDerivationFunc.Algorithm)
+	}
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, fmt.Errorf("malformed PBKDF2 parameters: %v", err)
+	}
+
+	var prf func() hash.Hash
+	switch {
+	case len(kdf.PRF.Algorithm) == 0, kdf.PRF.Algorithm.Equal(oidHMACWithSHA1):
+		prf = sha1.New
+	case kdf.PRF.Algorithm.Equal(oidHMACWithSHA256):
+		prf = sha256.New
+	case kdf.PRF.Algorithm.Equal(oidHMACWithSHA512):
+		prf = sha512.New
+	default:
+		return nil, fmt.Errorf("unsupported PBKDF2 PRF %v", kdf.PRF.Algorithm)
+	}
+
+	var keyLen int
+	var newCipher func([]byte) (cipher.Block, error)
+	switch scheme := params.EncryptionScheme.Algorithm; {
+	case scheme.Equal(oidAES128CBC):
+		keyLen, newCipher = 16, aes.NewCipher
+	case scheme.Equal(oidAES192CBC):
+		keyLen, newCipher = 24, aes.NewCipher
+	case scheme.Equal(oidAES256CBC):
+		keyLen, newCipher = 32, aes.NewCipher
+	case scheme.Equal(oidDESEDE3CBC):
+		keyLen, newCipher = 24, des.NewTripleDESCipher
+	default:
+		return nil, fmt.Errorf("unsupported cipher %v", scheme)
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("malformed cipher IV: %v", err)
+	}
+	if kdf.IterationCount <= 0 {
+		return nil, errors.New("invalid PBKDF2 iteration count")
+	}
+	if kdf.IterationCount > maxPBKDF2Iterations {
+		return nil, fmt.Errorf("PBKDF2 iteration count %d exceeds the limit of %d", kdf.IterationCount, maxPBKDF2Iterations)
+	}
+
+	block, err := newCipher(pbkdf2(prf, passphrase, kdf.Salt, kdf.IterationCount, keyLen))
+	if err != nil {
+		return nil, err
+	}
+	data := info.EncryptedData
+	if len(iv) != block.BlockSize() || len(data) == 0 || len(data)%block.BlockSize() != 0 {
+		return nil, errors.New("malformed encrypted data")
+	}
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data)
+
+	// 去除 PKCS#7 填充；填充错误通常意味着密码错误
+	pad := int(plain[len(plain)-1])
+	if pad == 0 || pad > block.BlockSize() || !bytes.Equal(plain[len(plain)-pad:], bytes.Repeat([]byte{byte(pad)}, pad)) {
+		return nil, ErrIncorrectPassphrase
+	}
+	return plain[:len(plain)-pad], nil
+}
+
+// pbkdf2 按 RFC 8018 派生密钥
+func pbkdf2(prf func() hash.Hash, password, salt []byte, iterations, keyLen int) []byte {
+	mac := hmac.New(prf, password)
+	size := mac.Size()
+	var derived []byte
+	var counter [4]byte
+	u := make([]byte, size)
+	for block := uint32(1); len(derived) < keyLen; block++ {
+		binary.BigEndian.PutUint32(counter[:], block)
+		mac.Reset()
+		mac.Write(salt)
+		mac.Write(counter[:])
+		u = mac.Sum(u[:0])
+		t := append([]byte(nil), u...)
+		for i := 1; i < iterations; i++ {
+			mac.Reset()
+			mac.Write(u)
+			u = mac.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		derived = append(derived, t...)
+	}
+	return derived[:keyLen]
+}
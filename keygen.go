@@ -0,0 +1,136 @@
+package gsalary
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultKeyBits 默认生成的RSA密钥长度
+const DefaultKeyBits = 2048
+
+// minKeyBits 允许生成的最小RSA密钥长度
+const minKeyBits = 2048
+
+// GenerateKeyPair 生成指定长度的RSA密钥对，bits 为 0 时使用 DefaultKeyBits
+func GenerateKeyPair(bits int) (*rsa.PrivateKey, error) {
+	if bits == 0 {
+		bits = DefaultKeyBits
+	}
+	if bits < minKeyBits {
+		return nil, fmt.Errorf("RSA key size must be at least %d bits, got %d", minKeyBits, bits)
+	}
+	return rsa.GenerateKey(rand.Reader, bits)
+}
+
+// MarshalPrivateKeyPEM 将私钥编码为 PKCS#8 PEM（BEGIN PRIVATE KEY）
+func MarshalPrivateKeyPEM(key *rsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// MarshalPublicKeyPEM 将公钥编码为 PKIX PEM（BEGIN PUBLIC KEY）
+func MarshalPublicKeyPEM(key *rsa.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// PublicKeyBase64 返回公钥的单行 base64（PKIX DER），即 GSalary 商户后台填写的格式
+func PublicKeyBase64(key *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(der), nil
+}
+
+// PublicKeyFingerprint 返回公钥 PKIX DER 的 SHA-256 指纹，格式为冒号分隔的小写十六进制
+func PublicKeyFingerprint(key *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	pairs := make([]string, len(sum))
+	for i, b := range sum {
+		pairs[i] = hex.EncodeToString([]byte{b})
+	}
+	return "SHA256:" + strings.Join(pairs, ":"), nil
+}
+
+// WriteKeyPair 将私钥和公钥分别写入 PKCS#8 和 PKIX PEM 文件，权限均为 0600
+// 文件已存在时返回错误，避免覆盖正在使用的私钥
+func WriteKeyPair(key *rsa.PrivateKey, privatePath, publicPath string) error {
+	privatePEM, err := MarshalPrivateKeyPEM(key)
+	if err != nil {
+		return err
+	}
+	publicPEM, err := MarshalPublicKeyPEM(&key.PublicKey)
+	if err != nil {
+		return err
+	}
+	if err := writeNewFile(privatePath, privatePEM); err != nil {
+		return err
+	}
+	if err := writeNewFile(publicPath, publicPEM); err != nil {
+		os.Remove(privatePath)
+		return err
+	}
+	return nil
+}
+
+// writeNewFile 以 0600 权限创建并写入新文件
+func writeNewFile(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
+
+// ClientPublicKey 从已加载的客户端私钥推导公钥
+// 通过 ConfigSigner 配置的签名器需要提供 Public() crypto.PublicKey 方法
+func (c *GSalaryConfig) ClientPublicKey() (*rsa.PublicKey, error) {
+	signer := c.GetSigner()
+	if signer == nil {
+		return nil, ErrSignerNotConfigured
+	}
+	withPublic, ok := signer.(interface{ Public() crypto.PublicKey })
+	if !ok {
+		return nil, errors.New("client signer does not expose its public key")
+	}
+	publicKey, ok := withPublic.Public().(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("client signer holds %T, expected an RSA public key", withPublic.Public())
+	}
+	return publicKey, nil
+}
+
+// ExportClientPublicKeyPEM 导出客户端公钥（PKIX PEM），用于上传到GSalary商户后台
+func (c *GSalaryConfig) ExportClientPublicKeyPEM() ([]byte, error) {
+	publicKey, err := c.ClientPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return MarshalPublicKeyPEM(publicKey)
+}
@@ -0,0 +1,159 @@
+package gsalary
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNoActiveServerKey 密钥环中没有处于有效期内的服务端公钥
+var ErrNoActiveServerKey = errors.New("gsalary: no active server public key")
+
+// ServerKey 密钥环中的一个服务端公钥
+type ServerKey struct {
+	// ID 密钥标识，用于替换和移除；为空时不能按ID管理
+	ID        string
+	PublicKey *rsa.PublicKey
+	// NotBefore 生效时间，零值表示立即生效
+	NotBefore time.Time
+	// NotAfter 失效时间，零值表示长期有效
+	NotAfter time.Time
+}
+
+// activeAt 判断密钥在 now 时是否处于有效期内
+func (k ServerKey) activeAt(now time.Time) bool {
+	if !k.NotBefore.IsZero() && now.Before(k.NotBefore) {
+		return false
+	}
+	if !k.NotAfter.IsZero() && !now.Before(k.NotAfter) {
+		return false
+	}
+	return true
+}
+
+// KeyRing 服务端公钥环，支持服务端轮换签名密钥
+//
+// 任一有效期内的公钥验签通过即视为通过。KeyRing 实现了 Verifier，
+// 并发安全，可以在运行中通过 Add 热添加新公钥，无需重建客户端或Webhook处理器。
+type KeyRing struct {
+	mu    sync.RWMutex
+	keys  []ServerKey
+	clock func() time.Time
+}
+
+// NewKeyRing 创建公钥环
+func NewKeyRing(keys ...ServerKey) (*KeyRing, error) {
+	ring := &KeyRing{}
+	for _, key := range keys {
+		if err := ring.Add(key); err != nil {
+			return nil, err
+		}
+	}
+	return ring, nil
+}
+
+// Add 添加公钥，ID 与已有公钥相同时替换该公钥
+func (k *KeyRing) Add(key ServerKey) error {
+	if key.PublicKey == nil {
+		return errors.New("server key has no public key")
+	}
+	if !key.NotBefore.IsZero() && !key.NotAfter.IsZero() && !key.NotAfter.After(key.NotBefore) {
+		return fmt.Errorf("server key %q: not_after must be after not_before", key.ID)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if key.ID != "" {
+		for i := range k.keys {
+			if k.keys[i].ID == key.ID {
+				k.keys[i] = key
+				return nil
+			}
+		}
+	}
+	k.keys = append(k.keys, key)
+	return nil
+}
+
+// AddPEM 解析公钥并添加，长期有效；支持 ParsePublicKey 识别的所有格式
+func (k *KeyRing) AddPEM(id, pemStr string) error {
+	publicKey, err := ParsePublicKey([]byte(pemStr))
+	if err != nil {
+		return err
+	}
+	return k.Add(ServerKey{ID: id, PublicKey: publicKey})
+}
+
+// Remove 按ID移除公钥，返回是否存在
+func (k *KeyRing) Remove(id string) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for i := range k.keys {
+		if k.keys[i].ID == id {
+			k.keys = append(k.keys[:i], k.keys[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Keys 返回所有公钥的快照
+func (k *KeyRing) Keys() []ServerKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return append([]ServerKey(nil), k.keys...)
+}
+
+// Active 返回 now 时处于有效期内的公钥，后添加的在前
+func (k *KeyRing) Active(now time.Time) []ServerKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	var active []ServerKey
+	for i := len(k.keys) - 1; i >= 0; i-- {
+		if k.keys[i].activeAt(now) {
+			active = append(active, k.keys[i])
+		}
+	}
+	return active
+}
+
+// SetClock 设置判断有效期使用的时钟，nil 时使用 time.Now
+func (k *KeyRing) SetClock(clock func() time.Time) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.clock = clock
+}
+
+// now 返回当前时间
+func (k *KeyRing) now() time.Time {
+	k.mu.RLock()
+	clock := k.clock
+	k.mu.RUnlock()
+	if clock != nil {
+		return clock()
+	}
+	return time.Now()
+}
+
+// Verify 实现 Verifier，依次尝试有效期内的公钥（新添加的优先）
+func (k *KeyRing) Verify(ctx context.Context, digest, signature []byte) error {
+	active := k.Active(k.now())
+	if len(active) == 0 {
+		return ErrNoActiveServerKey
+	}
+	for _, key := range active {
+		if rsa.VerifyPKCS1v15(key.PublicKey, crypto.SHA256, digest, signature) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("signature does not match any of %d active server keys", len(active))
+}
+
+// Algorithm 实现 Verifier
+func (k *KeyRing) Algorithm() string {
+	return AlgorithmRSA2
+}
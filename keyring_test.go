@@ -0,0 +1,104 @@
+package gsalary
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestKeyRingRotation 测试服务端轮换密钥时热添加新公钥即可通过验签
+func TestKeyRingRotation(t *testing.T) {
+	_, oldKey := testKeys(t)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	var config *GSalaryConfig
+	var rotated int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := oldKey
+		if atomic.LoadInt32(&rotated) == 1 {
+			key = newKey
+		}
+		writeSignedResponse(t, w, r, config, key, http.StatusOK, `{"result":{"result":"S"}}`)
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	config = newTestConfig(t, server.URL)
+	config.Clock = func() time.Time { return now }
+	ring, err := NewKeyRing(ServerKey{ID: "2024", PublicKey: &oldKey.PublicKey})
+	if err != nil {
+		t.Fatalf("NewKeyRing failed: %v", err)
+	}
+	config.ConfigServerKeyRing(ring)
+	client := NewClient(config)
+
+	if _, err := client.Request(NewRequest("GET", "/v1/cards")); err != nil {
+		t.Fatalf("Request with old key failed: %v", err)
+	}
+
+	atomic.StoreInt32(&rotated, 1)
+	if _, err := client.Request(NewRequest("GET", "/v1/cards")); err == nil {
+		t.Fatal("Expected unknown key to fail verification")
+	}
+
+	// 热添加新公钥，旧公钥一小时后失效
+	if err := ring.Add(ServerKey{ID: "2025", PublicKey: &newKey.PublicKey}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := ring.Add(ServerKey{ID: "2024", PublicKey: &oldKey.PublicKey, NotAfter: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+	if _, err := client.Request(NewRequest("GET", "/v1/cards")); err != nil {
+		t.Fatalf("Request with new key failed: %v", err)
+	}
+	if len(ring.Keys()) != 2 {
+		t.Errorf("Expected 2 keys after replacing by ID, got %d", len(ring.Keys()))
+	}
+
+	atomic.StoreInt32(&rotated, 0)
+	now = now.Add(2 * time.Hour)
+	if _, err := client.Request(NewRequest("GET", "/v1/cards")); err == nil {
+		t.Error("Expected expired key to fail verification")
+	}
+}
+
+// TestKeyRingWindows 测试有效期判断和参数校验
+func TestKeyRingWindows(t *testing.T) {
+	_, serverKey := testKeys(t)
+	now := time.Date(2024, 5, 28, 0, 0, 0, 0, time.UTC)
+	ring, _ := NewKeyRing(
+		ServerKey{ID: "current", PublicKey: &serverKey.PublicKey, NotAfter: now.Add(time.Hour)},
+		ServerKey{ID: "next", PublicKey: &serverKey.PublicKey, NotBefore: now.Add(time.Hour)},
+	)
+
+	if active := ring.Active(now); len(active) != 1 || active[0].ID != "current" {
+		t.Errorf("Expected only current key to be active, got %+v", active)
+	}
+	if active := ring.Active(now.Add(time.Hour)); len(active) != 1 || active[0].ID != "next" {
+		t.Errorf("Expected only next key to be active at the boundary, got %+v", active)
+	}
+
+	if !ring.Remove("current") {
+		t.Error("Expected current key to be removed")
+	}
+	ring.SetClock(func() time.Time { return now })
+	if err := ring.Verify(context.Background(), make([]byte, 32), []byte("sig")); !errors.Is(err, ErrNoActiveServerKey) {
+		t.Errorf("Expected ErrNoActiveServerKey, got %v", err)
+	}
+
+	if err := ring.Add(ServerKey{ID: "empty"}); err == nil {
+		t.Error("Expected error for key without public key")
+	}
+	if err := ring.Add(ServerKey{PublicKey: &serverKey.PublicKey, NotBefore: now, NotAfter: now}); err == nil {
+		t.Error("Expected error for empty validity window")
+	}
+}
@@ -0,0 +1,90 @@
+package gsalary
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLoggedBody 日志中请求体和响应体的最大长度（字节）
+const maxLoggedBody = 4096
+
+// WithLogger 使用 slog 在 Debug 级别记录每次发送的请求和响应摘要
+//
+// 请求体、响应体和查询参数在输出前经过 RedactJSON 脱敏；
+// 签名和鉴权头不会被记录。logger 未开启 Debug 级别时不做任何额外处理。
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+// debugEnabled 是否需要输出 Debug 日志
+func (c *GSalaryClient) debugEnabled(ctx context.Context) bool {
+	return c.logger != nil && c.logger.Enabled(ctx, slog.LevelDebug)
+}
+
+// logRequest 记录发送的请求
+func (c *GSalaryClient) logRequest(ctx context.Context, request *GSalaryRequest) {
+	if !c.debugEnabled(ctx) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", request.Method),
+		slog.String("path", request.Path),
+		slog.String("app_id", c.config.AppID),
+	}
+	if query := RedactQuery(request.CanonicalQuery()); query != "" {
+		attrs = append(attrs, slog.String("query", query))
+	}
+	if request.HasBody() {
+		if body, err := request.bodyBytes(); err == nil {
+			attrs = append(attrs, slog.String("body", loggedBody(body)))
+		}
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "gsalary request", attrs...)
+}
+
+// logResponse 记录响应或发送错误
+func (c *GSalaryClient) logResponse(ctx context.Context, request *GSalaryRequest, resp *Response, err error, elapsed time.Duration) {
+	if !c.debugEnabled(ctx) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", request.Method),
+		slog.String("path", request.Path),
+		slog.Duration("elapsed", elapsed),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", RedactText(err.Error())))
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "gsalary request failed", attrs...)
+		return
+	}
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	if result, code := resultOf(resp.Body); result != "" {
+		attrs = append(attrs, slog.String("result", result), slog.String("code", code))
+	}
+	attrs = append(attrs, slog.String("body", loggedBody(resp.Body)))
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "gsalary response", attrs...)
+}
+
+// loggedBody 脱敏并截断过长的内容
+func loggedBody(body []byte) string {
+	text := string(RedactJSON(body))
+	if len(text) > maxLoggedBody {
+		return truncateUTF8(text, maxLoggedBody) + "...(truncated)"
+	}
+	return text
+}
+
+// truncateUTF8 截断到不超过 n 字节，不拆分多字节字符（如中文姓名、地址）
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,68 @@
+package gsalary
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// TestClientDebugLogging 测试 Debug 日志记录请求和响应摘要且已脱敏
+func TestClientDebugLogging(t *testing.T) {
+	var config *GSalaryConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signedHandler(t, config, http.StatusOK,
+			`{"result":{"result":"S","code":""},"data":{"pan":"4111111111111111","cvv":"123"}}`)(w, r)
+	}))
+	defer server.Close()
+	config = newTestConfig(t, server.URL)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(config, WithLogger(logger))
+
+	request := NewRequest("POST", "/v1/cards/c1/active_card")
+	request.Body["activation_code"] = "A1B2C3"
+	request.Body["pin"] = "654321"
+	request.Query.Set("email", "bob@example.com")
+	if _, err := client.Request(request); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	text := buf.String()
+	for _, secret := range []string{"A1B2C3", "654321", "4111111111111111", `\"123\"`, "bob@example.com", "signature="} {
+		if strings.Contains(text, secret) {
+			t.Errorf("Log contains %q:\n%s", secret, text)
+		}
+	}
+	for _, want := range []string{"gsalary request", "gsalary response", "status=200", "result=S", "****1111", "b***@example.com"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected log to contain %q:\n%s", want, text)
+		}
+	}
+
+	// 未开启 Debug 时不输出
+	buf.Reset()
+	client = NewClient(config, WithLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+	client.Request(NewRequest("GET", "/v1/wallets/balance"))
+	if buf.Len() != 0 {
+		t.Errorf("Expected no log output at Info level, got:\n%s", buf.String())
+	}
+}
+
+// TestLoggedBodyTruncatesOnRuneBoundary 测试截断超长请求体时不拆分中文等多字节字符
+func TestLoggedBodyTruncatesOnRuneBoundary(t *testing.T) {
+	for offset := 0; offset < 3; offset++ {
+		body := `{"name":"` + strings.Repeat("a", offset) + strings.Repeat("张", maxLoggedBody) + `"}`
+		text := loggedBody([]byte(body))
+		if !utf8.ValidString(text) {
+			t.Errorf("offset %d: truncated body is not valid UTF-8", offset)
+		}
+		if !strings.HasSuffix(text, "...(truncated)") || len(text) > maxLoggedBody+len("...(truncated)") {
+			t.Errorf("offset %d: unexpected truncation to %d bytes", offset, len(text))
+		}
+	}
+}
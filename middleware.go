@@ -0,0 +1,38 @@
+package gsalary
+
+import "context"
+
+// Handler 请求处理函数
+// 拦截器拿到的 GSalaryRequest 尚未签名，返回的 Response 若为200则已完成验签
+type Handler func(ctx context.Context, request *GSalaryRequest) (*Response, error)
+
+// Middleware 请求拦截器，可用于日志、监控、审计和故障注入
+// 不调用 next 而直接返回 Response 即可短路请求（例如缓存）
+type Middleware func(next Handler) Handler
+
+// Use 注册拦截器，先注册的拦截器位于最外层
+func (c *GSalaryClient) Use(middlewares ...Middleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// WithMiddleware 在创建客户端时注册拦截器
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(o *clientOptions) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
+
+// handler 组装拦截器链，最内层为签名、发送和验签（含重试）
+func (c *GSalaryClient) handler() Handler {
+	c.mu.RLock()
+	middlewares := c.middlewares
+	c.mu.RUnlock()
+
+	var h Handler = c.sendWithRetry
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
@@ -0,0 +1,68 @@
+package gsalary
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestMiddlewareOrder 测试拦截器顺序以及拦截器能看到请求和响应
+func TestMiddlewareOrder(t *testing.T) {
+	var config *GSalaryConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signedHandler(t, config, http.StatusOK, `{"result":{"result":"S","code":"","message":""}}`)(w, r)
+	}))
+	defer server.Close()
+	config = newTestConfig(t, server.URL)
+
+	var trace []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, request *GSalaryRequest) (*Response, error) {
+				trace = append(trace, name+":before:"+request.Path)
+				resp, err := next(ctx, request)
+				if resp != nil {
+					trace = append(trace, name+":after:"+http.StatusText(resp.StatusCode))
+				}
+				return resp, err
+			}
+		}
+	}
+
+	client := NewClient(config, WithMiddleware(record("outer")))
+	client.Use(record("inner"))
+
+	if _, err := client.Request(NewRequest("GET", "/v1/cards")); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	expected := "outer:before:/v1/cards,inner:before:/v1/cards,inner:after:OK,outer:after:OK"
+	if got := strings.Join(trace, ","); got != expected {
+		t.Errorf("Unexpected middleware trace:\n got %s\nwant %s", got, expected)
+	}
+}
+
+// TestMiddlewareShortCircuit 测试拦截器短路请求
+func TestMiddlewareShortCircuit(t *testing.T) {
+	client := NewClient(newTestConfig(t, "https://example.invalid"))
+	client.Use(func(next Handler) Handler {
+		return func(ctx context.Context, request *GSalaryRequest) (*Response, error) {
+			return &Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       []byte(`{"result":{"result":"S"},"data":{"cached":true}}`),
+			}, nil
+		}
+	})
+
+	resp, err := client.Request(NewRequest("GET", "/v1/cards"))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	data, _ := resp["data"].(map[string]interface{})
+	if data["cached"] != true {
+		t.Errorf("Expected cached response, got %v", resp)
+	}
+}
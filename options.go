@@ -0,0 +1,133 @@
+package gsalary
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultTimeout 默认单次请求超时时间
+const DefaultTimeout = 30 * time.Second
+
+// DefaultUserAgent 默认User-Agent
+const DefaultUserAgent = "gsalary-sdk-go"
+
+// Option 客户端配置选项
+type Option func(*clientOptions)
+
+// clientOptions 客户端可选配置
+type clientOptions struct {
+	httpClient *http.Client
+	transport  http.RoundTripper
+	timeout    time.Duration
+	proxy      func(*http.Request) (*url.URL, error)
+	tlsConfig  *tls.Config
+	headers    http.Header
+	userAgent  string
+
+	retryPolicy RetryPolicy
+	rateLimiter *RateLimiter
+	breaker     *CircuitBreaker
+	middlewares []Middleware
+
+	instrumentation Instrumentation
+	logger          *slog.Logger
+}
+
+// defaultClientOptions 返回默认配置
+func defaultClientOptions() *clientOptions {
+	return &clientOptions{
+		timeout:   DefaultTimeout,
+		headers:   make(http.Header),
+		userAgent: DefaultUserAgent,
+	}
+}
+
+// WithHTTPClient 使用自定义的 http.Client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTransport 使用自定义的 http.RoundTripper
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *clientOptions) {
+		o.transport = transport
+	}
+}
+
+// WithTimeout 设置单次发送（签名和HTTP往返）的超时时间，传入0表示不设置超时
+// 开启重试时每次发送分别计时，限流等待和重试间隔不计入；需要限制整个调用的总耗时时使用 ctx 的截止时间，
+// 调用方 ctx 上更早的截止时间仍然生效
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithProxy 设置代理，例如 http.ProxyURL(u) 或 http.ProxyFromEnvironment
+// 仅在底层传输为 *http.Transport 时生效
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) Option {
+	return func(o *clientOptions) {
+		o.proxy = proxy
+	}
+}
+
+// WithTLSConfig 设置自定义TLS配置
+// 仅在底层传输为 *http.Transport 时生效
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(o *clientOptions) {
+		o.tlsConfig = tlsConfig
+	}
+}
+
+// WithHeader 为每个请求附加额外的请求头
+// X-Appid、Authorization、Content-Type 由SDK设置，不会被覆盖
+func WithHeader(key, value string) Option {
+	return func(o *clientOptions) {
+		o.headers.Add(key, value)
+	}
+}
+
+// WithUserAgent 设置User-Agent
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// buildHTTPClient 根据配置构造 http.Client
+func (o *clientOptions) buildHTTPClient() *http.Client {
+	httpClient := &http.Client{}
+	if o.httpClient != nil {
+		// 复制一份，避免修改调用方传入的客户端
+		copied := *o.httpClient
+		httpClient = &copied
+	}
+
+	if o.transport != nil {
+		httpClient.Transport = o.transport
+	}
+
+	if o.proxy != nil || o.tlsConfig != nil {
+		base := httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		if t, ok := base.(*http.Transport); ok {
+			t = t.Clone()
+			if o.proxy != nil {
+				t.Proxy = o.proxy
+			}
+			if o.tlsConfig != nil {
+				t.TLSClientConfig = o.tlsConfig
+			}
+			httpClient.Transport = t
+		}
+	}
+
+	return httpClient
+}
@@ -0,0 +1,194 @@
+package gsalary
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultDurationBuckets 请求耗时直方图的默认分桶（秒）
+var DefaultDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// PrometheusExporter 以Prometheus文本格式导出API调用指标，无需外部依赖或采集器
+//
+// 同时实现 Instrumentation 和 http.Handler：
+//
+//	exporter := gsalary.NewPrometheusExporter()
+//	client := gsalary.NewClient(config, gsalary.WithInstrumentation(exporter))
+//	http.Handle("/metrics", exporter)
+//
+// 导出的指标（标签 app_id、method、path，path 为模板化路径）：
+//   - gsalary_requests_total：调用次数，额外带 status（HTTP状态码，未收到响应时为 error）、result、code 标签
+//   - gsalary_request_duration_seconds：调用耗时直方图，含重试等待
+//   - gsalary_request_retries_total：重试次数
+//   - gsalary_requests_in_flight：进行中的调用数
+type PrometheusExporter struct {
+	buckets []float64
+
+	mu        sync.Mutex
+	requests  map[requestLabels]uint64
+	durations map[routeLabels]*histogram
+	retries   map[routeLabels]uint64
+	inFlight  map[routeLabels]int64
+}
+
+// routeLabels 接口维度的标签
+type routeLabels struct {
+	appID, method, path string
+}
+
+// requestLabels 调用结果维度的标签
+type requestLabels struct {
+	routeLabels
+	status, result, code string
+}
+
+// histogram 累积直方图
+type histogram struct {
+	counts []uint64 // 与 buckets 对应，最后一个为 +Inf
+	sum    float64
+	count  uint64
+}
+
+// NewPrometheusExporter 创建导出器，buckets 为空时使用 DefaultDurationBuckets
+func NewPrometheusExporter(buckets ...float64) *PrometheusExporter {
+	if len(buckets) == 0 {
+		buckets = DefaultDurationBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &PrometheusExporter{
+		buckets:   buckets,
+		requests:  make(map[requestLabels]uint64),
+		durations: make(map[routeLabels]*histogram),
+		retries:   make(map[routeLabels]uint64),
+		inFlight:  make(map[routeLabels]int64),
+	}
+}
+
+// StartSpan 实现 Instrumentation
+func (e *PrometheusExporter) StartSpan(ctx context.Context, start SpanStart) (context.Context, Span) {
+	route := routeLabels{appID: start.AppID, method: start.Method, path: start.Path}
+	e.mu.Lock()
+	e.inFlight[route]++
+	e.mu.Unlock()
+	return ctx, &prometheusSpan{exporter: e, route: route}
+}
+
+// prometheusSpan 记录单次调用
+type prometheusSpan struct {
+	exporter *PrometheusExporter
+	route    routeLabels
+}
+
+// End 实现 Span
+func (s *prometheusSpan) End(end SpanEnd) {
+	e := s.exporter
+	status := "error"
+	if end.HTTPStatus != 0 {
+		status = strconv.Itoa(end.HTTPStatus)
+	}
+	seconds := end.Duration.Seconds()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.inFlight[s.route]--
+	e.requests[requestLabels{routeLabels: s.route, status: status, result: end.Result, code: end.Code}]++
+	if end.Attempts > 1 {
+		e.retries[s.route] += uint64(end.Attempts - 1)
+	}
+	h := e.durations[s.route]
+	if h == nil {
+		h = &histogram{counts: make([]uint64, len(e.buckets)+1)}
+		e.durations[s.route] = h
+	}
+	i := sort.SearchFloat64s(e.buckets, seconds)
+	h.counts[i]++
+	h.sum += seconds
+	h.count++
+}
+
+// ServeHTTP 以Prometheus文本格式（0.0.4）输出当前指标
+func (e *PrometheusExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	out := bufio.NewWriter(w)
+	e.write(out)
+	out.Flush()
+}
+
+// write 按指标名和标签排序输出，保证结果稳定
+func (e *PrometheusExporter) write(w *bufio.Writer) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	writeHeader(w, "gsalary_requests_total", "counter", "GSalary API calls by HTTP status and result code.")
+	var lines []string
+	for labels, value := range e.requests {
+		lines = append(lines, fmt.Sprintf("gsalary_requests_total{%s,status=%s,result=%s,code=%s} %d",
+			labels.routeLabels, quoteLabel(labels.status), quoteLabel(labels.result), quoteLabel(labels.code), value))
+	}
+	writeSorted(w, lines)
+
+	writeHeader(w, "gsalary_request_duration_seconds", "histogram", "GSalary API call latency including retries.")
+	lines = lines[:0]
+	for labels, h := range e.durations {
+		var b strings.Builder
+		var cumulative uint64
+		for i, le := range e.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(&b, "gsalary_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n",
+				labels, strconv.FormatFloat(le, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(&b, "gsalary_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, h.count)
+		fmt.Fprintf(&b, "gsalary_request_duration_seconds_sum{%s} %s\n", labels, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "gsalary_request_duration_seconds_count{%s} %d", labels, h.count)
+		lines = append(lines, b.String())
+	}
+	writeSorted(w, lines)
+
+	writeHeader(w, "gsalary_request_retries_total", "counter", "GSalary API retries.")
+	lines = lines[:0]
+	for labels, value := range e.retries {
+		lines = append(lines, fmt.Sprintf("gsalary_request_retries_total{%s} %d", labels, value))
+	}
+	writeSorted(w, lines)
+
+	writeHeader(w, "gsalary_requests_in_flight", "gauge", "GSalary API calls in progress.")
+	lines = lines[:0]
+	for labels, value := range e.inFlight {
+		lines = append(lines, fmt.Sprintf("gsalary_requests_in_flight{%s} %d", labels, value))
+	}
+	writeSorted(w, lines)
+}
+
+// String 输出接口维度的标签
+func (l routeLabels) String() string {
+	return fmt.Sprintf("app_id=%s,method=%s,path=%s", quoteLabel(l.appID), quoteLabel(l.method), quoteLabel(l.path))
+}
+
+// writeHeader 输出 HELP 和 TYPE 行
+func writeHeader(w *bufio.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// writeSorted 排序后逐行输出
+func writeSorted(w *bufio.Writer, lines []string) {
+	sort.Strings(lines)
+	for _, line := range lines {
+		w.WriteString(line)
+		w.WriteByte('\n')
+	}
+}
+
+// quoteLabel 按Prometheus文本格式转义标签值
+func quoteLabel(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
@@ -0,0 +1,50 @@
+package gsalary
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestPrometheusExporter 测试导出的计数器、直方图和标签转义
+func TestPrometheusExporter(t *testing.T) {
+	exporter := NewPrometheusExporter(0.1, 1)
+	route := SpanStart{Method: "GET", Path: "/v1/cards/{card_id}", AppID: `app"1`}
+
+	_, span := exporter.StartSpan(context.Background(), route)
+	span.End(SpanEnd{HTTPStatus: 200, Result: "S", Attempts: 3, Duration: 50 * time.Millisecond})
+	_, span = exporter.StartSpan(context.Background(), route)
+	span.End(SpanEnd{HTTPStatus: 404, Result: "F", Code: "NOT_FOUND", Attempts: 1, Duration: 2 * time.Second})
+	_, span = exporter.StartSpan(context.Background(), route)
+	span.End(SpanEnd{Duration: 500 * time.Millisecond})
+	exporter.StartSpan(context.Background(), route)
+
+	recorder := httptest.NewRecorder()
+	exporter.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := recorder.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Unexpected content type %q", ct)
+	}
+	text := recorder.Body.String()
+	labels := `app_id="app\"1",method="GET",path="/v1/cards/{card_id}"`
+	for _, want := range []string{
+		"# TYPE gsalary_requests_total counter",
+		`gsalary_requests_total{` + labels + `,status="200",result="S",code=""} 1`,
+		`gsalary_requests_total{` + labels + `,status="404",result="F",code="NOT_FOUND"} 1`,
+		`gsalary_requests_total{` + labels + `,status="error",result="",code=""} 1`,
+		"# TYPE gsalary_request_duration_seconds histogram",
+		`gsalary_request_duration_seconds_bucket{` + labels + `,le="0.1"} 1`,
+		`gsalary_request_duration_seconds_bucket{` + labels + `,le="1"} 2`,
+		`gsalary_request_duration_seconds_bucket{` + labels + `,le="+Inf"} 3`,
+		`gsalary_request_duration_seconds_sum{` + labels + `} 2.55`,
+		`gsalary_request_duration_seconds_count{` + labels + `} 3`,
+		`gsalary_request_retries_total{` + labels + `} 2`,
+		`gsalary_requests_in_flight{` + labels + `} 1`,
+	} {
+		if !strings.Contains(text, want+"\n") {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", want, text)
+		}
+	}
+}
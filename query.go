@@ -0,0 +1,66 @@
+package gsalary
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// EncodeQuery 按规范规则编码查询参数，签名串和实际请求URL共用同一顺序：
+//   - 键按字典序升序排列，同名键的多个值保持添加顺序
+//   - escape 为 true 时键和值都使用 url.QueryEscape 转义（空格编码为+），用于请求URL
+//   - escape 为 false 时输出相同顺序的原文，用于签名串（api.md：请求路径含查询参数，无需转义）
+func EncodeQuery(values url.Values, escape bool) string {
+	if len(values) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf strings.Builder
+	for _, k := range keys {
+		key := k
+		if escape {
+			key = url.QueryEscape(k)
+		}
+		for _, v := range values[k] {
+			if buf.Len() > 0 {
+				buf.WriteByte('&')
+			}
+			buf.WriteString(key)
+			buf.WriteByte('=')
+			if escape {
+				buf.WriteString(url.QueryEscape(v))
+			} else {
+				buf.WriteString(v)
+			}
+		}
+	}
+	return buf.String()
+}
+
+// CanonicalQuery 合并 Query 和 QueryArgs，返回参与签名和发送的查询参数
+// 同名键时 QueryArgs 的值排在 Query 之后
+func (r *GSalaryRequest) CanonicalQuery() url.Values {
+	values := make(url.Values, len(r.Query)+len(r.QueryArgs))
+	for k, vs := range r.Query {
+		values[k] = append([]string(nil), vs...)
+	}
+	for k, v := range r.QueryArgs {
+		values[k] = append(values[k], v)
+	}
+	return values
+}
+
+// CanonicalPath 返回请求URL的签名路径：路径加上按 EncodeQuery 规则排序、未转义的查询串
+// 服务端验签和测试服务器签名响应时使用，与客户端签名串中的 PATH 一致
+func CanonicalPath(u *url.URL) string {
+	query := EncodeQuery(u.Query(), false)
+	if query == "" {
+		return u.Path
+	}
+	return u.Path + "?" + query
+}
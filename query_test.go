@@ -0,0 +1,140 @@
+package gsalary
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+// TestEncodeQuery 测试规范查询串的排序、多值和转义规则
+func TestEncodeQuery(t *testing.T) {
+	cases := []struct {
+		name    string
+		values  url.Values
+		signed  string
+		escaped string
+	}{
+		{"empty", url.Values{}, "", ""},
+		{"sorted keys", url.Values{"page": {"1"}, "limit": {"20"}, "create_start": {"2024-02-01"}},
+			"create_start=2024-02-01&limit=20&page=1",
+			"create_start=2024-02-01&limit=20&page=1"},
+		{"repeated keys keep order", url.Values{"status": {"SUCCESS", "PENDING"}, "card_id": {"c1"}},
+			"card_id=c1&status=SUCCESS&status=PENDING",
+			"card_id=c1&status=SUCCESS&status=PENDING"},
+		{"escaping", url.Values{"create_start": {"2024-02-01T00:00:00+00:00"}, "name": {"a b&c=d"}},
+			"create_start=2024-02-01T00:00:00+00:00&name=a b&c=d",
+			"create_start=2024-02-01T00%3A00%3A00%2B00%3A00&name=a+b%26c%3Dd"},
+		{"escaped key", url.Values{"a b": {"1"}}, "a b=1", "a+b=1"},
+	}
+	for _, tc := range cases {
+		if got := EncodeQuery(tc.values, false); got != tc.signed {
+			t.Errorf("%s: signed form = %q, want %q", tc.name, got, tc.signed)
+		}
+		if got := EncodeQuery(tc.values, true); got != tc.escaped {
+			t.Errorf("%s: escaped form = %q, want %q", tc.name, got, tc.escaped)
+		}
+	}
+}
+
+// TestPathWithArgsDeterministic 测试签名串和请求URL的参数顺序稳定一致
+func TestPathWithArgsDeterministic(t *testing.T) {
+	request := NewRequest("GET", "/v1/cards")
+	for _, k := range []string{"page", "limit", "product_code", "brand_code", "card_holder_id", "status"} {
+		request.QueryArgs[k] = k + " value"
+	}
+	want := "/v1/cards?brand_code=brand_code+value&card_holder_id=card_holder_id+value&limit=limit+value" +
+		"&page=page+value&product_code=product_code+value&status=status+value"
+	for i := 0; i < 20; i++ {
+		if got := request.PathWithArgs(true); got != want {
+			t.Fatalf("PathWithArgs(true) = %q, want %q", got, want)
+		}
+		unescaped, err := url.QueryUnescape(request.PathWithArgs(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := request.PathWithArgs(false); got != unescaped {
+			t.Fatalf("Signed path %q disagrees with wire path %q", got, unescaped)
+		}
+	}
+}
+
+// TestCanonicalQueryMerge 测试 Query 和 QueryArgs 的合并
+func TestCanonicalQueryMerge(t *testing.T) {
+	request := NewRequest("GET", "/v1/card_bill/card_transactions")
+	request.Query.Add("status", "SUCCESS")
+	request.Query.Add("status", "PENDING")
+	request.QueryArgs["page"] = "1"
+	request.QueryArgs["status"] = "FAILED"
+
+	want := "/v1/card_bill/card_transactions?page=1&status=SUCCESS&status=PENDING&status=FAILED"
+	if got := request.PathWithArgs(false); got != want {
+		t.Errorf("PathWithArgs(false) = %q, want %q", got, want)
+	}
+	if len(request.Query["status"]) != 2 {
+		t.Error("CanonicalQuery must not modify request.Query")
+	}
+}
+
+// TestSignatureGoldenVectors 按 api.md 的签名算法核对固定密钥下的签名结果
+// 期望值由 openssl dgst -sha256 -sign testdata/golden_private_key.pem 独立生成
+func TestSignatureGoldenVectors(t *testing.T) {
+	config := NewConfig()
+	config.AppID = "golden_app"
+	if err := config.ConfigClientPrivateKeyPEMFile("testdata/golden_private_key.pem"); err != nil {
+		t.Fatalf("Failed to load golden key: %v", err)
+	}
+
+	getReq := NewRequest("GET", "/v1/card_bill/card_transactions")
+	getReq.QueryArgs["page"] = "1"
+	getReq.QueryArgs["limit"] = "20"
+	getReq.QueryArgs["card_id"] = "card 001"
+	getReq.Query.Add("status", "SUCCESS")
+	getReq.Query.Add("status", "PENDING")
+
+	postReq := NewRequest("POST", "/v1/exchange/quotes")
+	postReq.Body = map[string]interface{}{
+		"sell_currency": "USD",
+		"buy_currency":  "CNY",
+		"sell_amount":   0.1,
+	}
+
+	cases := []struct {
+		name      string
+		request   *GSalaryRequest
+		signBase  string
+		signature string
+	}{
+		{
+			name:     "GET with repeated and escaped query",
+			request:  getReq,
+			signBase: "GET /v1/card_bill/card_transactions?card_id=card 001&limit=20&page=1&status=SUCCESS&status=PENDING\ngolden_app\n1716888888888\n\n",
+			signature: "VrmQ66Lfzy4TsckH8OBRfWK0yU3qwVHRduV6M/z3bWRL4EVHArONzsuV6sVNWfEpKmZdXj5C3N4QLIufqUUaUxN9UIfzvfXbNEHEXr4DMnf4fE0S" +
+				"LJFBaVMQj2AhDq7jHkCRQub4YIoFhwMgr3lJ9v2NgbjqhbDd7QLQWPQPYr1fzq6HyQMPJQ3A2AVbODqFTBWo/7ScJvDDpECWh1RXmjCHb5o1r3e8" +
+				"qs4xqg56OeX+SpERxNpeNJBoHzy4AInol/9HlbjCiBF/AbEWGPkd4dHuW5A0jQ7w91q8JWsp9hopljotM12K2Y4YzPi8lfySqY59licl28Ja58wj613ZFw==",
+		},
+		{
+			name:     "POST with body hash",
+			request:  postReq,
+			signBase: "POST /v1/exchange/quotes\ngolden_app\n1716888888888\nuxVn4Y2g/TIVK3C2wKP93+km4tFxQoV/GLQXO/eMXT8=\n",
+			signature: "UkuDdXdO0amcP6jF9wzJciR/1g/GAj/VQ7X3ayXVDOk2hzesqXWOU4zxA+fkUyK/9vosQBg8oSz4GX3BBXC5OMpvPYSQWJNGu+IvWioAlyXA2b1b" +
+				"MdZ8Yr5XDorzLCICZYKh00+Y9rXvuNYpTzCU/MXH16KxCaubiOg7eEIxpIcxuYMls8NmGfsQDQPcmhigpYseknVnZACva7He1HYP4dcmSnBC/a/G" +
+				"VYKuff2yTJRYezs88/YlM7gfUDi+EdKV7gEnBHF0350bTsiHk3VkQJWUCqvyN7o3qluf2Mc5CoUUJQZGJ2hpieYgK05RyLnexhAhyEEclYwTy/OQ9atonw==",
+		},
+	}
+	for _, tc := range cases {
+		bodyHash, err := tc.request.getBodyHash()
+		if err != nil {
+			t.Fatalf("%s: body hash failed: %v", tc.name, err)
+		}
+		if got := tc.request.signBase(config.AppID, "1716888888888", bodyHash); got != tc.signBase {
+			t.Errorf("%s: sign base = %q, want %q", tc.name, got, tc.signBase)
+		}
+		authInfo, err := tc.request.signRequestAt(context.Background(), config, "1716888888888")
+		if err != nil {
+			t.Fatalf("%s: sign failed: %v", tc.name, err)
+		}
+		if authInfo.Signature != tc.signature {
+			t.Errorf("%s: signature = %s, want %s", tc.name, authInfo.Signature, tc.signature)
+		}
+	}
+}
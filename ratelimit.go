@@ -0,0 +1,197 @@
+package gsalary
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// rateDecreaseFactor 收到423（SYSTEM_BUSY）后速率乘以的系数
+	rateDecreaseFactor = 0.5
+	// rateMinFraction 自适应降速的下限，为配置速率的比例
+	rateMinFraction = 1.0 / 16
+	// rateRecoveryFraction 每个非423响应恢复的速率，为配置速率的比例
+	rateRecoveryFraction = 0.05
+)
+
+// RateLimit 一组接口的令牌桶配置
+type RateLimit struct {
+	// Prefix 请求路径前缀，按最长前缀匹配；空字符串匹配所有未命中其他前缀的请求
+	Prefix string
+	// Rate 每秒发放的令牌数，即稳定状态下每秒最多发送的请求数
+	Rate float64
+	// Burst 桶容量，即空闲后允许的突发请求数，小于1时按1处理
+	Burst int
+}
+
+// DefaultRateLimits 返回按接口族划分的默认限流配置
+//
+// 数值是保守的经验值，并非GSalary公布的限额，批量任务可按实际情况调整：
+// 卡片相关（/v1/card，含开卡申请、持卡人、账单）、汇款（/remittance）、
+// 收单（/gateway/v1/acquiring）和换汇（/v1/exchange）各自独立计数。
+func DefaultRateLimits() []RateLimit {
+	return []RateLimit{
+		{Prefix: "/v1/card", Rate: 10, Burst: 10},
+		{Prefix: "/remittance", Rate: 5, Burst: 5},
+		{Prefix: "/gateway/v1/acquiring", Rate: 10, Burst: 10},
+		{Prefix: "/v1/exchange", Rate: 5, Burst: 5},
+	}
+}
+
+// RateLimiter 按路径前缀划分的客户端令牌桶限流器
+//
+// 发送请求（包括每次重试）前需要取得对应前缀的令牌，没有令牌时阻塞等待，
+// 直到取得令牌或 ctx 取消。收到 HTTP 423 时该前缀的速率减半（不低于配置的1/16），
+// 之后每个非423响应逐步恢复到配置速率。
+// 同一个 RateLimiter 可以在多个客户端之间共享，合并计算同一个AppID的请求量。
+type RateLimiter struct {
+	buckets []*tokenBucket // 按前缀长度从长到短排序
+	now     func() time.Time
+}
+
+// tokenBucket 单个前缀的令牌桶
+type tokenBucket struct {
+	prefix   string
+	baseRate float64
+	burst    float64
+
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter 创建限流器，前缀重复或速率不为正数时返回错误
+func NewRateLimiter(limits ...RateLimit) (*RateLimiter, error) {
+	limiter := &RateLimiter{now: time.Now}
+	seen := make(map[string]bool)
+	for _, limit := range limits {
+		if limit.Rate <= 0 {
+			return nil, fmt.Errorf("rate limit %q: rate must be positive", limit.Prefix)
+		}
+		if seen[limit.Prefix] {
+			return nil, fmt.Errorf("rate limit %q: duplicate prefix", limit.Prefix)
+		}
+		seen[limit.Prefix] = true
+		burst := float64(limit.Burst)
+		if burst < 1 {
+			burst = 1
+		}
+		limiter.buckets = append(limiter.buckets, &tokenBucket{
+			prefix:   limit.Prefix,
+			baseRate: limit.Rate,
+			burst:    burst,
+			rate:     limit.Rate,
+			tokens:   burst,
+		})
+	}
+	sort.SliceStable(limiter.buckets, func(i, j int) bool {
+		return len(limiter.buckets[i].prefix) > len(limiter.buckets[j].prefix)
+	})
+	return limiter, nil
+}
+
+// WithRateLimiter 为客户端设置限流器，默认不限流
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *clientOptions) {
+		o.rateLimiter = limiter
+	}
+}
+
+// Rate 返回前缀当前生效的速率（每秒请求数），未配置该前缀时返回0
+func (l *RateLimiter) Rate(prefix string) float64 {
+	for _, b := range l.buckets {
+		if b.prefix == prefix {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			return b.rate
+		}
+	}
+	return 0
+}
+
+// Wait 阻塞直到 path 对应的前缀有可用令牌，未命中任何前缀时立即返回
+// ctx 取消，或 ctx 的截止时间早于可取得令牌的时间时，返回错误且不消耗令牌
+func (l *RateLimiter) Wait(ctx context.Context, path string) error {
+	b := l.bucket(path)
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	now := l.now()
+	b.refill(now)
+	b.tokens--
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+	if delay == 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(delay)) {
+		b.refund()
+		return fmt.Errorf("gsalary: rate limit %q: wait %s exceeds context deadline: %w",
+			b.prefix, delay.Round(time.Millisecond), context.DeadlineExceeded)
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		b.refund()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// observe 根据响应调整 path 对应前缀的速率
+func (l *RateLimiter) observe(path string, resp *Response) {
+	b := l.bucket(path)
+	if b == nil || resp == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(l.now())
+	if resp.StatusCode == http.StatusLocked {
+		b.rate = max(b.rate*rateDecreaseFactor, b.baseRate*rateMinFraction)
+		return
+	}
+	b.rate = min(b.rate+b.baseRate*rateRecoveryFraction, b.baseRate)
+}
+
+// bucket 返回 path 最长匹配前缀的令牌桶
+func (l *RateLimiter) bucket(path string) *tokenBucket {
+	if l == nil {
+		return nil
+	}
+	for _, b := range l.buckets {
+		if strings.HasPrefix(path, b.prefix) {
+			return b
+		}
+	}
+	return nil
+}
+
+// refill 按当前速率补充令牌，调用方需持有锁
+func (b *tokenBucket) refill(now time.Time) {
+	if !b.last.IsZero() && now.After(b.last) {
+		b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*b.rate, b.burst)
+	}
+	b.last = now
+}
+
+// refund 归还未使用的令牌
+func (b *tokenBucket) refund() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.tokens+1, b.burst)
+}
@@ -0,0 +1,91 @@
+package gsalary
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestRateLimiterWait 测试按最长前缀匹配、阻塞等待和 ctx 截止时间
+func TestRateLimiterWait(t *testing.T) {
+	limiter, err := NewRateLimiter(
+		RateLimit{Prefix: "/v1/card", Rate: 20, Burst: 2},
+		RateLimit{Prefix: "/v1/cards/balance_modifies", Rate: 1, Burst: 1},
+	)
+	if err != nil {
+		t.Fatalf("NewRateLimiter failed: %v", err)
+	}
+	ctx := context.Background()
+
+	// 前两个请求使用突发容量，第三个需要等待约50ms
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx, "/v1/cards"); err != nil {
+			t.Fatalf("Wait failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("Expected third call to wait, took %s", elapsed)
+	}
+
+	// 更长的前缀独立计数
+	if err := limiter.Wait(ctx, "/v1/cards/balance_modifies"); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(short, "/v1/cards/balance_modifies"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline error, got %v", err)
+	}
+
+	// 未配置的前缀不限流
+	for i := 0; i < 100; i++ {
+		if err := limiter.Wait(short, "/remittance/orders"); err != nil {
+			t.Fatalf("Expected unlimited path, got %v", err)
+		}
+	}
+
+	if _, err := NewRateLimiter(RateLimit{Prefix: "/x", Rate: 0}); err == nil {
+		t.Error("Expected error for zero rate")
+	}
+	if _, err := NewRateLimiter(RateLimit{Prefix: "/x", Rate: 1}, RateLimit{Prefix: "/x", Rate: 2}); err == nil {
+		t.Error("Expected error for duplicate prefix")
+	}
+}
+
+// TestRateLimiterAdaptive 测试423降低速率，成功响应逐步恢复
+func TestRateLimiterAdaptive(t *testing.T) {
+	busy := true
+	var config *GSalaryConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if busy {
+			w.WriteHeader(http.StatusLocked)
+			w.Write([]byte(`{"biz_result":"F","error_code":"SYSTEM_BUSY","message":"busy"}`))
+			return
+		}
+		signedHandler(t, config, http.StatusOK, `{"result":{"result":"S"}}`)(w, r)
+	}))
+	defer server.Close()
+	config = newTestConfig(t, server.URL)
+
+	limiter, _ := NewRateLimiter(RateLimit{Prefix: "/remittance", Rate: 1000, Burst: 100})
+	client := NewClient(config, WithRateLimiter(limiter))
+
+	for i := 0; i < 10; i++ {
+		client.Request(NewRequest("GET", "/remittance/orders"))
+	}
+	if rate := limiter.Rate("/remittance"); rate != 1000*rateMinFraction {
+		t.Errorf("Expected rate to drop to the floor, got %v", rate)
+	}
+
+	busy = false
+	if _, err := client.Request(NewRequest("GET", "/remittance/orders")); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if rate := limiter.Rate("/remittance"); rate != 1000*(rateMinFraction+rateRecoveryFraction) {
+		t.Errorf("Expected rate to recover, got %v", rate)
+	}
+}
@@ -0,0 +1,200 @@
+package gsalary
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// redacted 完全脱敏后的值
+const redacted = "[REDACTED]"
+
+// redactionRules 按JSON字段名的脱敏规则，"父字段.字段" 的规则优先于只有字段名的规则
+//
+// 覆盖卡机密信息（CardSecureInfo 的 pan、cvv、有效期）、激活卡和重置PIN请求体
+// （activation_code、pin）、证件号码（PayerRequest.CertNumber）、邮箱和手机号（MobileNumber，
+// 以及收款人列表查询参数中字符串形式的 mobile）。
+var redactionRules = map[string]func(string) string{
+	"pan":             maskKeepLast4,
+	"card_number":     maskKeepLast4,
+	"card_no":         maskKeepLast4,
+	"cvv":             maskAll,
+	"cvv2":            maskAll,
+	"cvc":             maskAll,
+	"expire_year":     maskAll,
+	"expire_month":    maskAll,
+	"pin":             maskAll,
+	"activation_code": maskAll,
+	"cert_number":     maskKeepLast4,
+	"email":           maskEmail,
+	"phone":           maskKeepLast4,
+	"phone_number":    maskKeepLast4,
+	"mobile_number":   maskKeepLast4,
+	"mobile.number":   maskKeepLast4,
+	"mobile":          maskKeepLast4,
+}
+
+// scalarOnlyRules 只对字符串和数字生效的规则，对象值继续按子字段脱敏
+// 如 mobile 既可能是手机号字符串，也可能是 {"nation_code","number"} 对象
+var scalarOnlyRules = map[string]bool{
+	"mobile": true,
+}
+
+var (
+	// panPattern 自由文本中可能是卡号的13~19位数字，命中后还要通过Luhn校验
+	panPattern = regexp.MustCompile(`\b\d{13,19}\b`)
+	// emailPattern 自由文本中的邮箱地址
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+)
+
+// RedactJSON 返回脱敏后的JSON，用于日志输出
+//
+// 命中脱敏规则的字段按字段级规则处理（卡号和证件号保留后4位，邮箱保留首字母和域名，
+// CVV、PIN、激活码完全隐藏），其余字符串中的卡号（Luhn校验）和邮箱地址也会被替换。
+// 输入不是合法JSON时按自由文本处理。
+func RedactJSON(data []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return []byte(RedactText(string(data)))
+	}
+	redactedJSON, err := json.Marshal(redactValue("", value))
+	if err != nil {
+		return []byte(redacted)
+	}
+	return redactedJSON
+}
+
+// RedactText 替换自由文本中的卡号和邮箱地址
+func RedactText(text string) string {
+	text = panPattern.ReplaceAllStringFunc(text, func(digits string) string {
+		if !luhnValid(digits) {
+			return digits
+		}
+		return maskKeepLast4(digits)
+	})
+	return emailPattern.ReplaceAllStringFunc(text, maskEmail)
+}
+
+// RedactQuery 按字段规则脱敏查询参数，返回未转义的规范查询串（见 EncodeQuery）
+// 没有字段规则的参数按 RedactText 处理
+func RedactQuery(query url.Values) string {
+	redactedQuery := make(url.Values, len(query))
+	for key, values := range query {
+		mask := redactionRule("", key)
+		for _, value := range values {
+			if mask != nil && value != "" {
+				value = mask(value)
+			} else {
+				value = RedactText(value)
+			}
+			redactedQuery.Add(key, value)
+		}
+	}
+	return EncodeQuery(redactedQuery, false)
+}
+
+// redactValue 递归脱敏，parent 为所在对象的字段名
+func redactValue(parent string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if mask := redactionRule(parent, key); mask != nil && !isNestedScalarOnly(key, child) {
+				v[key] = maskScalar(child, mask)
+				continue
+			}
+			v[key] = redactValue(key, child)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactValue(parent, child)
+		}
+		return v
+	case string:
+		return RedactText(v)
+	}
+	return value
+}
+
+// redactionRule 查找字段的脱敏规则
+func redactionRule(parent, key string) func(string) string {
+	key = strings.ToLower(key)
+	if mask, ok := redactionRules[strings.ToLower(parent)+"."+key]; ok {
+		return mask
+	}
+	return redactionRules[key]
+}
+
+// isNestedScalarOnly 字段规则只作用于标量，而值是对象或数组
+func isNestedScalarOnly(key string, value interface{}) bool {
+	if !scalarOnlyRules[strings.ToLower(key)] {
+		return false
+	}
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	}
+	return false
+}
+
+// maskScalar 对字符串和数字脱敏，对象和数组整体隐藏
+func maskScalar(value interface{}, mask func(string) string) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return v
+		}
+		return mask(v)
+	case json.Number:
+		return mask(v.String())
+	}
+	return redacted
+}
+
+// maskAll 完全隐藏
+func maskAll(string) string {
+	return redacted
+}
+
+// maskKeepLast4 只保留后4位，长度不足8位时完全隐藏
+func maskKeepLast4(value string) string {
+	runes := []rune(value)
+	if len(runes) < 8 {
+		return "****"
+	}
+	return "****" + string(runes[len(runes)-4:])
+}
+
+// maskEmail 保留首字母和域名，如 j***@example.com
+func maskEmail(value string) string {
+	at := strings.LastIndex(value, "@")
+	if at <= 0 {
+		return redacted
+	}
+	_, size := utf8.DecodeRuneInString(value)
+	return value[:size] + "***" + value[at:]
+}
+
+// luhnValid Luhn校验
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,68 @@
+package gsalary
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestRedactJSON 测试卡机密信息、PIN、证件号、邮箱和手机号的脱敏
+func TestRedactJSON(t *testing.T) {
+	input := `{
+		"result": {"result": "S", "code": "", "message": "sent to alice@example.com"},
+		"data": {"pan": "4111111111111111", "cvv": "123", "expire_year": "2030", "expire_month": "12"},
+		"activation_code": "A1B2C3", "pin": 654321,
+		"cert_number": "G12345678", "email": "bob@example.com",
+		"mobile": {"country_code": "86", "number": "13800138000"},
+		"remark": "card 4111111111111111, order 1234567890123",
+		"list": [{"pan": "5500000000000004"}]
+	}`
+	var out map[string]interface{}
+	if err := json.Unmarshal(RedactJSON([]byte(input)), &out); err != nil {
+		t.Fatalf("Redacted output is not JSON: %v", err)
+	}
+	data := out["data"].(map[string]interface{})
+	mobile := out["mobile"].(map[string]interface{})
+	for name, got := range map[string]interface{}{
+		"pan":             data["pan"],
+		"cvv":             data["cvv"],
+		"expire_year":     data["expire_year"],
+		"activation_code": out["activation_code"],
+		"pin":             out["pin"],
+		"cert_number":     out["cert_number"],
+		"email":           out["email"],
+		"mobile.number":   mobile["number"],
+		"country_code":    mobile["country_code"],
+		"remark":          out["remark"],
+		"message":         out["result"].(map[string]interface{})["message"],
+		"list.pan":        out["list"].([]interface{})[0].(map[string]interface{})["pan"],
+	} {
+		want := map[string]string{
+			"pan":             "****1111",
+			"cvv":             "[REDACTED]",
+			"expire_year":     "[REDACTED]",
+			"activation_code": "[REDACTED]",
+			"pin":             "[REDACTED]",
+			"cert_number":     "****5678",
+			"email":           "b***@example.com",
+			"mobile.number":   "****8000",
+			"country_code":    "86",
+			"remark":          "card ****1111, order 1234567890123",
+			"message":         "sent to a***@example.com",
+			"list.pan":        "****0004",
+		}[name]
+		if got != want {
+			t.Errorf("%s: expected %q, got %v", name, want, got)
+		}
+	}
+
+	if got := maskEmail("张三@example.com"); got != "张***@example.com" {
+		t.Errorf("Unexpected multi-byte email redaction %q", got)
+	}
+	if got := maskKeepLast4("上海市浦东新区世纪大道100号"); got != "****100号" {
+		t.Errorf("Unexpected multi-byte redaction %q", got)
+	}
+
+	if got := string(RedactJSON([]byte("not json 4111111111111111"))); got != "not json ****1111" {
+		t.Errorf("Unexpected free text redaction %q", got)
+	}
+}
@@ -1,17 +1,13 @@
 package gsalary
 
 import (
-	"crypto"
-	"crypto/rand"
-	"crypto/rsa"
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
-	"strings"
-	"time"
 )
 
 // GSalaryRequest 请求对象
@@ -19,7 +15,11 @@ type GSalaryRequest struct {
 	Method    string
 	Path      string
 	QueryArgs map[string]string
-	Body      map[string]interface{}
+	// Query 查询参数，支持同名键多值，与 QueryArgs 合并后按 EncodeQuery 的规则编码
+	Query url.Values
+	Body  map[string]interface{}
+	// RawBody 已编码的JSON请求体，设置后优先于 Body，签名和发送使用同一份字节
+	RawBody []byte
 }
 
 // NewRequest 创建新的请求
@@ -28,6 +28,7 @@ func NewRequest(method, path string) *GSalaryRequest {
 		Method:    method,
 		Path:      path,
 		QueryArgs: make(map[string]string),
+		Query:     make(url.Values),
 		Body:      make(map[string]interface{}),
 	}
 }
@@ -47,26 +48,60 @@ func (r *GSalaryRequest) Valid() error {
 // HasBody 检查请求是否有body
 func (r *GSalaryRequest) HasBody() bool {
 	if r.Method == "POST" || r.Method == "PUT" {
-		return len(r.Body) > 0
+		return len(r.RawBody) > 0 || len(r.Body) > 0
 	}
 	return false
 }
 
-// PathWithArgs 返回带查询参数的路径
-func (r *GSalaryRequest) PathWithArgs(escape bool) string {
-	if len(r.QueryArgs) == 0 {
-		return r.Path
+// SetJSONBody 按 json 标签将 v 编码为请求体
+// 编码结果为空对象时与空的 Body 一致，不发送请求体
+func (r *GSalaryRequest) SetJSONBody(v interface{}) error {
+	bodyBytes, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if string(bodyBytes) == "{}" {
+		bodyBytes = nil
 	}
+	r.RawBody = bodyBytes
+	return nil
+}
 
-	var pairs []string
-	for k, v := range r.QueryArgs {
-		if escape {
-			pairs = append(pairs, fmt.Sprintf("%s=%s", k, url.QueryEscape(v)))
-		} else {
-			pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+// bodyBytes 返回实际发送和参与签名的请求体
+func (r *GSalaryRequest) bodyBytes() ([]byte, error) {
+	if len(r.RawBody) > 0 {
+		return r.RawBody, nil
+	}
+	return json.Marshal(r.Body)
+}
+
+// Idempotent 判断请求是否可以安全重试
+// POST 请求只有在请求体带有 request_id 时才视为幂等
+func (r *GSalaryRequest) Idempotent() bool {
+	if r.Method != "POST" {
+		return true
+	}
+	if len(r.RawBody) > 0 {
+		var body struct {
+			RequestID string `json:"request_id"`
 		}
+		if err := json.Unmarshal(r.RawBody, &body); err != nil {
+			return false
+		}
+		return body.RequestID != ""
 	}
-	return r.Path + "?" + strings.Join(pairs, "&")
+	requestID, _ := r.Body["request_id"].(string)
+	return requestID != ""
+}
+
+// PathWithArgs 返回带查询参数的路径
+// escape 为 true 时用于请求URL，为 false 时用于签名串，两者参数顺序一致
+func (r *GSalaryRequest) PathWithArgs(escape bool) string {
+	query := EncodeQuery(r.CanonicalQuery(), escape)
+	if query == "" {
+		return r.Path
+	}
+	return r.Path + "?" + query
 }
 
 // getBodyHash 计算body的SHA256哈希值
@@ -75,7 +110,7 @@ func (r *GSalaryRequest) getBodyHash() (string, error) {
 		return "", nil
 	}
 
-	bodyBytes, err := json.Marshal(r.Body)
+	bodyBytes, err := r.bodyBytes()
 	if err != nil {
 		return "", err
 	}
@@ -86,66 +121,115 @@ func (r *GSalaryRequest) getBodyHash() (string, error) {
 
 // SignRequest 对请求进行签名
 func (r *GSalaryRequest) SignRequest(config *GSalaryConfig) (*AuthorizeHeaderInfo, error) {
-	timestamp := fmt.Sprintf("%d", time.Now().UnixMilli())
-	
+	return r.SignRequestCtx(context.Background(), config)
+}
+
+// SignRequestCtx 使用配置的 Signer 对请求进行签名，ctx 会传递给 Signer
+func (r *GSalaryRequest) SignRequestCtx(ctx context.Context, config *GSalaryConfig) (*AuthorizeHeaderInfo, error) {
+	return r.signRequestAt(ctx, config, fmt.Sprintf("%d", config.Now().UnixMilli()))
+}
+
+// signRequestAt 使用指定的毫秒时间戳对请求进行签名
+func (r *GSalaryRequest) signRequestAt(ctx context.Context, config *GSalaryConfig, timestamp string) (*AuthorizeHeaderInfo, error) {
 	bodyHash, err := r.getBodyHash()
 	if err != nil {
 		return nil, err
 	}
 
-	// 构造签名基础字符串
-	signBase := fmt.Sprintf("%s %s\n%s\n%s\n%s\n",
-		r.Method,
-		r.PathWithArgs(false),
-		config.AppID,
-		timestamp,
-		bodyHash)
-
-	// 生成RSA签名
-	signature, err := generateRSASignature(config.GetClientPrivateKey(), signBase)
+	signer := config.GetSigner()
+	if signer == nil {
+		return nil, ErrSignerNotConfigured
+	}
+	signature, err := signMessage(ctx, signer, r.signBase(config.AppID, timestamp, bodyHash))
 	if err != nil {
 		return nil, err
 	}
 
-	return NewAuthorizeHeaderInfo("RSA2", timestamp, signature), nil
+	return NewAuthorizeHeaderInfo(signer.Algorithm(), timestamp, signature), nil
 }
 
 // VerifySignature 验证响应签名
 func (r *GSalaryRequest) VerifySignature(config *GSalaryConfig, headerInfo *AuthorizeHeaderInfo, responseBody string) bool {
+	return r.verifyResponse(context.Background(), config, headerInfo, []byte(responseBody)) == nil
+}
+
+// verifyResponse 使用配置的 Verifier 验证响应签名
+func (r *GSalaryRequest) verifyResponse(ctx context.Context, config *GSalaryConfig, headerInfo *AuthorizeHeaderInfo, responseBody []byte) error {
+	verifier := config.GetVerifier()
+	if verifier == nil {
+		return ErrVerifierNotConfigured
+	}
+	if headerInfo.Algorithm != verifier.Algorithm() {
+		return fmt.Errorf("unsupported algorithm: %s", headerInfo.Algorithm)
+	}
+
 	// 计算响应body的哈希
-	hash := sha256.Sum256([]byte(responseBody))
+	hash := sha256.Sum256(responseBody)
 	bodyHash := base64.StdEncoding.EncodeToString(hash[:])
 
-	// 构造签名基础字符串
-	signBase := fmt.Sprintf("%s %s\n%s\n%s\n%s\n",
-		r.Method,
-		r.PathWithArgs(false),
-		config.AppID,
-		headerInfo.Timestamp,
-		bodyHash)
+	return verifyMessage(ctx, verifier, r.signBase(config.AppID, headerInfo.Timestamp, bodyHash), headerInfo.Signature)
+}
 
-	// 验证签名
-	return verifyRSASignature(config.GetServerPublicKey(), signBase, headerInfo.Signature)
+// signBase 构造签名基础字符串：METHOD PATH\nAPPID\nTIMESTAMP\nBODY_HASH\n
+// PATH 为未转义的规范查询串，与请求URL的参数顺序一致
+func (r *GSalaryRequest) signBase(appID, timestamp, bodyHash string) string {
+	return formatSignBase(r.Method, r.PathWithArgs(false), appID, timestamp, bodyHash)
 }
 
-// generateRSASignature 生成RSA签名
-func generateRSASignature(privateKey *rsa.PrivateKey, signBase string) (string, error) {
-	hash := sha256.Sum256([]byte(signBase))
-	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hash[:])
+// formatSignBase 按签名规则拼接签名串
+func formatSignBase(method, path, appID, timestamp, bodyHash string) string {
+	return fmt.Sprintf("%s %s\n%s\n%s\n%s\n", method, path, appID, timestamp, bodyHash)
+}
+
+// SignResponse 以服务端身份对响应签名，返回响应的 Authorization 头
+//
+// 用于测试服务器、录制回放等需要模拟GSalary响应的场景。path 为请求路径和未转义的规范查询串
+// （见 CanonicalPath），appID 为请求头 X-Appid，timestamp 为毫秒时间戳。
+func SignResponse(ctx context.Context, signer Signer, method, path, appID, timestamp string, body []byte) (*AuthorizeHeaderInfo, error) {
+	if signer == nil {
+		return nil, ErrSignerNotConfigured
+	}
+	hash := sha256.Sum256(body)
+	bodyHash := base64.StdEncoding.EncodeToString(hash[:])
+	signature, err := signMessage(ctx, signer, formatSignBase(method, path, appID, timestamp, bodyHash))
 	if err != nil {
-		return "", fmt.Errorf("failed to generate RSA signature: %w", err)
+		return nil, err
 	}
-	return base64.StdEncoding.EncodeToString(signature), nil
+	return NewAuthorizeHeaderInfo(signer.Algorithm(), timestamp, signature), nil
 }
 
-// verifyRSASignature 验证RSA签名
-func verifyRSASignature(publicKey *rsa.PublicKey, signBase, signatureBase64 string) bool {
-	signature, err := base64.StdEncoding.DecodeString(signatureBase64)
-	if err != nil {
-		return false
+// VerifyRequest 以服务端身份验证请求签名，用于测试服务器
+//
+// path 为请求路径和未转义的规范查询串（见 CanonicalPath），appID 为请求头 X-Appid，
+// body 为原始请求体，为空时 BODY_HASH 留空。不检查时间戳，需要时另行调用 CheckTimestamp。
+func VerifyRequest(ctx context.Context, verifier Verifier, method, path, appID string, header *AuthorizeHeaderInfo, body []byte) error {
+	if verifier == nil {
+		return ErrVerifierNotConfigured
+	}
+	if !header.Valid() {
+		return errors.New("invalid authorization header")
+	}
+	if header.Algorithm != verifier.Algorithm() {
+		return fmt.Errorf("unsupported algorithm: %s", header.Algorithm)
+	}
+	var bodyHash string
+	if len(body) > 0 {
+		hash := sha256.Sum256(body)
+		bodyHash = base64.StdEncoding.EncodeToString(hash[:])
 	}
+	return verifyMessage(ctx, verifier, formatSignBase(method, path, appID, header.Timestamp, bodyHash), header.Signature)
+}
 
-	hash := sha256.Sum256([]byte(signBase))
-	err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], signature)
-	return err == nil
+// SignWebhook 以服务端身份对Webhook推送签名，返回 authorization 头：algorithm=RSA2,time=...,signature=...
+//
+// 签名覆盖原始请求体的 SHA-256 摘要，timestamp 为毫秒时间戳，用于测试服务器模拟平台推送。
+func SignWebhook(ctx context.Context, signer Signer, timestamp string, body []byte) (string, error) {
+	if signer == nil {
+		return "", ErrSignerNotConfigured
+	}
+	signature, err := signMessage(ctx, signer, string(body))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("algorithm=%s,time=%s,signature=%s", signer.Algorithm(), timestamp, signature), nil
 }
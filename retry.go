@@ -0,0 +1,182 @@
+package gsalary
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy 重试策略
+//
+// 以下情况会触发重试：网络传输错误（连接失败、超时、连接重置、响应体不完整）、HTTP 423（SYSTEM_BUSY）、
+// HTTP 5xx，以及 RetryOnUnknown 开启时 result 为 U 的 200 响应。签名、验签等本地错误不重试。
+// 响应带 Retry-After 时按其等待，但不超过 MaxBackoff；等待会超过 ctx 截止时间时直接返回本次结果。
+// 每次重试都会使用新的时间戳重新签名。
+// 非幂等的 POST 请求只有在请求体带有 request_id 时才会重试。
+type RetryPolicy struct {
+	MaxAttempts    int           // 最大尝试次数（含首次），小于等于1表示不重试
+	InitialBackoff time.Duration // 首次重试前的等待时间
+	MaxBackoff     time.Duration // 单次等待时间上限
+	Multiplier     float64       // 指数退避倍数
+	Jitter         float64       // 随机抖动比例，取值0~1
+	RetryOnUnknown bool          // result 为 U 时是否重试
+}
+
+// DefaultRetryPolicy 返回推荐的重试策略
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryOnUnknown: true,
+	}
+}
+
+// WithRetryPolicy 设置重试策略，默认不重试
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retryPolicy = policy
+	}
+}
+
+// backoff 计算第 retry 次重试（从0开始）前的等待时间
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay = delay * (1 - jitter + 2*jitter*rand.Float64())
+	}
+	return time.Duration(delay)
+}
+
+// sendWithRetry 按重试策略发送请求
+func (c *GSalaryClient) sendWithRetry(ctx context.Context, request *GSalaryRequest) (*Response, error) {
+	policy := c.retryPolicy
+	attempts := policy.MaxAttempts
+	if attempts < 1 || !request.Idempotent() {
+		attempts = 1
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, request)
+		if attempt+1 >= attempts || !policy.shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay := policy.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				delay = retryAfter
+				if policy.MaxBackoff > 0 {
+					delay = min(delay, policy.MaxBackoff)
+				}
+			}
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if err == nil {
+				err = ctx.Err()
+			}
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry 判断本次结果是否需要重试
+func (p RetryPolicy) shouldRetry(ctx context.Context, resp *Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	// 调用方 ctx 未结束时，DeadlineExceeded 来自 WithTimeout 的单次发送超时，可以重试
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && isTransportError(err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusLocked:
+		return true
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return true
+	case resp.StatusCode == http.StatusOK && p.RetryOnUnknown:
+		return bizResultOf(resp.Body) == "U"
+	}
+	return false
+}
+
+// isTransportError 是否为网络传输错误：连接失败、超时、连接重置或响应体不完整
+// 签名失败、密钥未配置、验签和解码错误等本地错误重试也不会成功，不属于传输错误；
+// 不支持的协议、证书校验失败、代理地址错误等配置错误同样不重试
+func isTransportError(err error) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	// *url.Error 自身实现了 net.Error，需要按它包装的底层错误判断
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if urlErr.Timeout() {
+			return true
+		}
+		err = urlErr.Err
+		// 服务端在返回响应前关闭了连接
+		if errors.Is(err, io.EOF) {
+			return true
+		}
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op == "dial" || opErr.Op == "read" || opErr.Op == "write"
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// bizResultOf 提取响应中的 result.result 字段
+func bizResultOf(body []byte) string {
+	result, _ := resultOf(body)
+	return result
+}
+
+// parseRetryAfter 解析 Retry-After 头，支持秒数和HTTP日期两种格式
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		delay := at.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
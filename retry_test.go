@@ -0,0 +1,214 @@
+package gsalary
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetryPolicy 测试使用的快速重试策略
+func fastRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Multiplier:     2,
+		RetryOnUnknown: true,
+	}
+}
+
+// TestRetryOnSystemBusy 测试423后重试成功
+func TestRetryOnSystemBusy(t *testing.T) {
+	var calls int32
+	var config *GSalaryConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusLocked)
+			w.Write([]byte(`{"biz_result":"F","error_code":"SYSTEM_BUSY","message":"busy"}`))
+			return
+		}
+		signedHandler(t, config, http.StatusOK, `{"result":{"result":"S","code":"","message":""},"data":{}}`)(w, r)
+	}))
+	defer server.Close()
+
+	config = newTestConfig(t, server.URL)
+	client := NewClient(config, WithRetryPolicy(fastRetryPolicy()))
+
+	resp, err := client.Request(NewRequest("GET", "/v1/wallets/balance"))
+	if err != nil {
+		t.Fatalf("Expected success after retry, got %v", err)
+	}
+	if resp == nil {
+		t.Fatal("Response is nil")
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Expected 2 attempts, got %d", got)
+	}
+}
+
+// TestRetryOnUnknownResult 测试 result=U 时重试
+func TestRetryOnUnknownResult(t *testing.T) {
+	var calls int32
+	var config *GSalaryConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := `{"result":{"result":"U","code":"","message":"unknown"}}`
+		if atomic.AddInt32(&calls, 1) == 3 {
+			body = `{"result":{"result":"S","code":"","message":""}}`
+		}
+		signedHandler(t, config, http.StatusOK, body)(w, r)
+	}))
+	defer server.Close()
+
+	config = newTestConfig(t, server.URL)
+	client := NewClient(config, WithRetryPolicy(fastRetryPolicy()))
+
+	req := NewRequest("POST", "/v1/cards/balance_modifies")
+	req.Body["request_id"] = "REQ_1"
+	req.Body["amount"] = 10
+
+	if _, err := client.Request(req); err != nil {
+		t.Fatalf("Expected success, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("Expected 3 attempts, got %d", got)
+	}
+}
+
+// TestNoRetryForPostWithoutRequestID 测试不带request_id的POST不会重试
+func TestNoRetryForPostWithoutRequestID(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"biz_result":"F","error_code":"SYSTEM_ERROR","message":"error"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(newTestConfig(t, server.URL), WithRetryPolicy(fastRetryPolicy()))
+
+	req := NewRequest("POST", "/v1/exchange/quotes")
+	req.Body["sell_currency"] = "USD"
+
+	if _, err := client.Request(req); err == nil {
+		t.Fatal("Expected error")
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected 1 attempt, got %d", got)
+	}
+}
+
+// TestParseRetryAfter 测试 Retry-After 解析
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	if d, ok := parseRetryAfter("3", now); !ok || d != 3*time.Second {
+		t.Errorf("Expected 3s, got %v %v", d, ok)
+	}
+	if d, ok := parseRetryAfter(now.Add(2*time.Second).Format(http.TimeFormat), now); !ok || d != 2*time.Second {
+		t.Errorf("Expected 2s, got %v %v", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon", now); ok {
+		t.Error("Expected invalid Retry-After to be ignored")
+	}
+}
+
+// TestRetryOnlyTransportErrors 测试验签失败等本地错误不重试，连接中断会重试
+func TestRetryOnlyTransportErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"result":{"result":"S","code":"","message":""}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(newTestConfig(t, server.URL), WithRetryPolicy(fastRetryPolicy()))
+	if _, err := client.Request(NewRequest("GET", "/v1/wallets/balance")); err == nil {
+		t.Fatal("Expected signature verification error")
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected verification failure not to be retried, got %d attempts", got)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	dropped := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer dropped.Close()
+	client = NewClient(newTestConfig(t, dropped.URL), WithRetryPolicy(fastRetryPolicy()))
+	if _, err := client.Request(NewRequest("GET", "/v1/wallets/balance")); err == nil {
+		t.Fatal("Expected transport error")
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("Expected dropped connections to be retried, got %d attempts", got)
+	}
+
+	// 不支持的协议是配置错误，*url.Error 虽然实现了 net.Error 也不重试
+	var attempts int32
+	counting := WithTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&attempts, 1)
+		return http.DefaultTransport.RoundTrip(r)
+	}))
+	client = NewClient(newTestConfig(t, "ftp://127.0.0.1"), WithRetryPolicy(fastRetryPolicy()), counting)
+	_, err := client.Request(NewRequest("GET", "/v1/wallets/balance"))
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) || !strings.Contains(err.Error(), "unsupported protocol scheme") {
+		t.Fatalf("Expected unsupported protocol scheme error, got %v", err)
+	}
+	if isTransportError(err) {
+		t.Errorf("Expected %v not to be a transport error", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("Expected bad scheme not to be retried, got %d attempts", got)
+	}
+}
+
+// TestRetryAfterCapped 测试 Retry-After 不超过 MaxBackoff，且不会等待超过 ctx 截止时间
+func TestRetryAfterCapped(t *testing.T) {
+	var calls int32
+	var config *GSalaryConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusLocked)
+			w.Write([]byte(`{"biz_result":"F","error_code":"SYSTEM_BUSY","message":"busy"}`))
+			return
+		}
+		signedHandler(t, config, http.StatusOK, `{"result":{"result":"S","code":"","message":""},"data":{}}`)(w, r)
+	}))
+	defer server.Close()
+	config = newTestConfig(t, server.URL)
+
+	start := time.Now()
+	client := NewClient(config, WithRetryPolicy(fastRetryPolicy()))
+	if _, err := client.Request(NewRequest("GET", "/v1/wallets/balance")); err != nil {
+		t.Fatalf("Expected success after capped Retry-After, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected Retry-After to be capped by MaxBackoff, waited %v", elapsed)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	policy := fastRetryPolicy()
+	policy.MaxBackoff = 0
+	client = NewClient(config, WithRetryPolicy(policy))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start = time.Now()
+	_, err := client.RequestCtx(ctx, NewRequest("GET", "/v1/wallets/balance"))
+	if !errors.Is(err, ErrSystemBusy) || time.Since(start) > time.Second {
+		t.Errorf("Expected the 423 to be returned before the deadline, got %v after %v", err, time.Since(start))
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected 1 attempt, got %d", got)
+	}
+}
@@ -0,0 +1,147 @@
+package gsalary
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// AlgorithmRSA2 SHA256withRSA（PKCS#1 v1.5），即鉴权头中的 algorithm=RSA2
+const AlgorithmRSA2 = "RSA2"
+
+var (
+	// ErrSignerNotConfigured 未配置客户端私钥或 Signer
+	ErrSignerNotConfigured = errors.New("gsalary: client signer not configured")
+	// ErrVerifierNotConfigured 未配置服务端公钥或 Verifier
+	ErrVerifierNotConfigured = errors.New("gsalary: server verifier not configured")
+)
+
+// Signer 请求签名器
+//
+// Sign 的参数是签名串的 SHA-256 摘要，返回原始签名字节（不做 base64）。
+// 私钥可以保存在 KMS、HSM 或本地签名服务中，SDK 只接触摘要和签名。
+type Signer interface {
+	Sign(ctx context.Context, digest []byte) ([]byte, error)
+	// Algorithm 返回鉴权头中的算法名，如 AlgorithmRSA2
+	Algorithm() string
+}
+
+// Verifier 响应和Webhook验签器
+//
+// Verify 的参数是签名串的 SHA-256 摘要和原始签名字节，验签失败时返回错误。
+type Verifier interface {
+	Verify(ctx context.Context, digest, signature []byte) error
+	// Algorithm 返回支持的算法名，与鉴权头中的 algorithm 比对
+	Algorithm() string
+}
+
+// RSASigner 使用内存中的RSA私钥签名
+type RSASigner struct {
+	key *rsa.PrivateKey
+}
+
+// NewRSASigner 创建内存RSA签名器
+func NewRSASigner(key *rsa.PrivateKey) *RSASigner {
+	return &RSASigner{key: key}
+}
+
+// Sign 实现 Signer
+func (s *RSASigner) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	return rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest)
+}
+
+// Algorithm 实现 Signer
+func (s *RSASigner) Algorithm() string {
+	return AlgorithmRSA2
+}
+
+// Public 返回对应的公钥
+func (s *RSASigner) Public() crypto.PublicKey {
+	return &s.key.PublicKey
+}
+
+// cryptoSigner 将 crypto.Signer 适配为 Signer
+type cryptoSigner struct {
+	signer crypto.Signer
+}
+
+// NewCryptoSigner 将标准库 crypto.Signer 适配为 Signer
+// PKCS#11、云KMS等库通常都提供 crypto.Signer 实现；目前只支持RSA密钥
+func NewCryptoSigner(signer crypto.Signer) (Signer, error) {
+	if signer == nil {
+		return nil, errors.New("crypto signer is nil")
+	}
+	if _, ok := signer.Public().(*rsa.PublicKey); !ok {
+		return nil, fmt.Errorf("crypto signer must hold an RSA key, got %T", signer.Public())
+	}
+	return &cryptoSigner{signer: signer}, nil
+}
+
+// Sign 实现 Signer，crypto.Signer 不支持 ctx，签名前检查 ctx 是否已取消
+func (s *cryptoSigner) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.signer.Sign(rand.Reader, digest, crypto.SHA256)
+}
+
+// Algorithm 实现 Signer
+func (s *cryptoSigner) Algorithm() string {
+	return AlgorithmRSA2
+}
+
+// Public 返回对应的公钥
+func (s *cryptoSigner) Public() crypto.PublicKey {
+	return s.signer.Public()
+}
+
+// RSAVerifier 使用RSA公钥验签
+type RSAVerifier struct {
+	key *rsa.PublicKey
+}
+
+// NewRSAVerifier 创建RSA验签器
+func NewRSAVerifier(key *rsa.PublicKey) *RSAVerifier {
+	return &RSAVerifier{key: key}
+}
+
+// Verify 实现 Verifier
+func (v *RSAVerifier) Verify(ctx context.Context, digest, signature []byte) error {
+	return rsa.VerifyPKCS1v15(v.key, crypto.SHA256, digest, signature)
+}
+
+// Algorithm 实现 Verifier
+func (v *RSAVerifier) Algorithm() string {
+	return AlgorithmRSA2
+}
+
+// signMessage 对签名串做 SHA-256 摘要后签名，返回 base64 编码的签名
+func signMessage(ctx context.Context, signer Signer, message string) (string, error) {
+	if signer == nil {
+		return "", ErrSignerNotConfigured
+	}
+	hash := sha256.Sum256([]byte(message))
+	signature, err := signer.Sign(ctx, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to generate %s signature: %w", signer.Algorithm(), err)
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// verifyMessage 验证签名串的 base64 编码签名
+func verifyMessage(ctx context.Context, verifier Verifier, message, signatureBase64 string) error {
+	if verifier == nil {
+		return ErrVerifierNotConfigured
+	}
+	signature, err := base64.StdEncoding.DecodeString(signatureBase64)
+	if err != nil {
+		return fmt.Errorf("decode signature failed: %w", err)
+	}
+	hash := sha256.Sum256([]byte(message))
+	return verifier.Verify(ctx, hash[:], signature)
+}
@@ -0,0 +1,102 @@
+package gsalary
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// countingSigner 记录调用次数的 Signer，模拟外部签名服务
+type countingSigner struct {
+	Signer
+	calls int32
+}
+
+func (s *countingSigner) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	atomic.AddInt32(&s.calls, 1)
+	return s.Signer.Sign(ctx, digest)
+}
+
+// countingVerifier 记录调用次数的 Verifier
+type countingVerifier struct {
+	Verifier
+	calls int32
+}
+
+func (v *countingVerifier) Verify(ctx context.Context, digest, signature []byte) error {
+	atomic.AddInt32(&v.calls, 1)
+	return v.Verifier.Verify(ctx, digest, signature)
+}
+
+// TestConfigSigner 测试客户端通过配置的 Signer 和 Verifier 签名、验签
+func TestConfigSigner(t *testing.T) {
+	clientKey, serverKey := testKeys(t)
+	var config *GSalaryConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		authInfo := FromHeaderValue(r.Header.Get("Authorization"))
+		hash := sha256.Sum256(body)
+		signBase := fmt.Sprintf("%s %s\n%s\n%s\n%s\n",
+			r.Method, signPath(t, r), config.AppID, authInfo.Timestamp,
+			base64.StdEncoding.EncodeToString(hash[:]))
+		if err := verifyMessage(r.Context(), NewRSAVerifier(&clientKey.PublicKey), signBase, authInfo.Signature); err != nil {
+			t.Errorf("Request signature invalid: %v", err)
+		}
+		signedHandler(t, config, http.StatusOK, `{"result":{"result":"S"}}`)(w, r)
+	}))
+	defer server.Close()
+
+	adapted, err := NewCryptoSigner(clientKey)
+	if err != nil {
+		t.Fatalf("NewCryptoSigner failed: %v", err)
+	}
+	signer := &countingSigner{Signer: adapted}
+	verifier := &countingVerifier{Verifier: NewRSAVerifier(&serverKey.PublicKey)}
+
+	config = NewConfig()
+	config.AppID = "test_app"
+	config.Endpoint = server.URL
+	config.ConfigSigner(signer)
+	config.ConfigVerifier(verifier)
+
+	request := NewRequest("POST", "/v1/cards/balance_modifies")
+	request.Body["amount"] = "10"
+	if _, err := NewClient(config).Request(request); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if signer.calls != 1 || verifier.calls != 1 {
+		t.Errorf("Expected one sign and one verify, got %d and %d", signer.calls, verifier.calls)
+	}
+	if config.GetClientPrivateKey() != nil {
+		t.Error("Expected ConfigSigner to replace the client private key")
+	}
+}
+
+// TestNewCryptoSignerRejectsNonRSA 测试非RSA密钥会被拒绝
+func TestNewCryptoSignerRejectsNonRSA(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	if _, err := NewCryptoSigner(key); err == nil {
+		t.Error("Expected error for ECDSA key")
+	}
+}
+
+// TestSignerNotConfigured 测试未配置私钥时返回错误而不是 panic
+func TestSignerNotConfigured(t *testing.T) {
+	_, err := NewRequest("GET", "/v1/cards").SignRequest(NewConfig())
+	if !errors.Is(err, ErrSignerNotConfigured) {
+		t.Errorf("Expected ErrSignerNotConfigured, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package gsalary
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DefaultMaxClockSkew 默认允许的签名时间戳偏差（api.md：±5 分钟内有效）
+const DefaultMaxClockSkew = 5 * time.Minute
+
+var (
+	// ErrClockSkew 签名时间戳超出允许的偏差范围
+	ErrClockSkew = errors.New("gsalary: signature timestamp outside allowed clock skew")
+	// ErrInvalidTimestamp 签名时间戳缺失或格式错误
+	ErrInvalidTimestamp = errors.New("gsalary: invalid signature timestamp")
+)
+
+// Now 返回当前时间，优先使用注入的 Clock
+func (c *GSalaryConfig) Now() time.Time {
+	if c.Clock != nil {
+		return c.Clock()
+	}
+	return time.Now()
+}
+
+// ClockSkew 返回生效的最大时间戳偏差，0 表示不检查
+func (c *GSalaryConfig) ClockSkew() time.Duration {
+	switch {
+	case c.MaxClockSkew < 0:
+		return 0
+	case c.MaxClockSkew == 0:
+		return DefaultMaxClockSkew
+	default:
+		return c.MaxClockSkew
+	}
+}
+
+// CheckTimestamp 检查签名时间戳是否在允许的偏差内
+// 时间戳为毫秒级 Unix 时间，小于 1e12 的值按秒处理
+func (c *GSalaryConfig) CheckTimestamp(timestamp string) error {
+	skew := c.ClockSkew()
+	if skew == 0 {
+		return nil
+	}
+	signedAt, err := ParseTimestamp(timestamp)
+	if err != nil {
+		return err
+	}
+	return c.CheckTime(signedAt)
+}
+
+// CheckTime 检查已解析的签名时间是否在允许的偏差内，如Webhook请求体中的 event_time
+func (c *GSalaryConfig) CheckTime(signedAt time.Time) error {
+	skew := c.ClockSkew()
+	if skew == 0 {
+		return nil
+	}
+	now := c.Now()
+	if diff := now.Sub(signedAt); diff > skew || diff < -skew {
+		return fmt.Errorf("%w: signed at %s, now %s",
+			ErrClockSkew, signedAt.UTC().Format(time.RFC3339), now.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// ParseTimestamp 解析签名中的 Unix 时间戳，兼容毫秒和秒
+func ParseTimestamp(timestamp string) (time.Time, error) {
+	value, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || value <= 0 {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTimestamp, timestamp)
+	}
+	if value < 1e12 {
+		return time.Unix(value, 0), nil
+	}
+	return time.UnixMilli(value), nil
+}
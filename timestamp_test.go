@@ -0,0 +1,66 @@
+package gsalary
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestCheckTimestamp 测试时间戳偏差检查
+func TestCheckTimestamp(t *testing.T) {
+	now := time.Date(2024, 5, 28, 12, 0, 0, 0, time.UTC)
+	config := NewConfig()
+	config.Clock = func() time.Time { return now }
+
+	cases := []struct {
+		timestamp string
+		want      error
+	}{
+		{fmt.Sprintf("%d", now.UnixMilli()), nil},
+		{fmt.Sprintf("%d", now.Add(-4*time.Minute).UnixMilli()), nil},
+		{fmt.Sprintf("%d", now.Add(6*time.Minute).UnixMilli()), ErrClockSkew},
+		{fmt.Sprintf("%d", now.Add(-6*time.Minute).UnixMilli()), ErrClockSkew},
+		{fmt.Sprintf("%d", now.Add(time.Minute).Unix()), nil},
+		{fmt.Sprintf("%d", now.Add(-time.Hour).Unix()), ErrClockSkew},
+		{"", ErrInvalidTimestamp},
+		{"abc", ErrInvalidTimestamp},
+	}
+	for _, tc := range cases {
+		if err := config.CheckTimestamp(tc.timestamp); !errors.Is(err, tc.want) {
+			t.Errorf("CheckTimestamp(%q) = %v, want %v", tc.timestamp, err, tc.want)
+		}
+	}
+
+	config.MaxClockSkew = time.Hour
+	if err := config.CheckTimestamp(fmt.Sprintf("%d", now.Add(-30*time.Minute).UnixMilli())); err != nil {
+		t.Errorf("Expected 30 minutes to be allowed with 1h skew, got %v", err)
+	}
+	config.MaxClockSkew = -1
+	if err := config.CheckTimestamp(""); err != nil {
+		t.Errorf("Expected check to be disabled, got %v", err)
+	}
+}
+
+// TestResponseClockSkew 测试过期的响应签名会被拒绝
+func TestResponseClockSkew(t *testing.T) {
+	var config *GSalaryConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signedHandler(t, config, http.StatusOK, `{"result":{"result":"S"}}`)(w, r)
+	}))
+	defer server.Close()
+	config = newTestConfig(t, server.URL)
+	client := NewClient(config)
+
+	if _, err := client.Request(NewRequest("GET", "/v1/cards")); err != nil {
+		t.Fatalf("Expected fresh response to pass, got %v", err)
+	}
+
+	// 客户端时钟比服务端快10分钟，相当于收到了10分钟前的响应
+	config.Clock = func() time.Time { return time.Now().Add(10 * time.Minute) }
+	if _, err := client.Request(NewRequest("GET", "/v1/cards")); !errors.Is(err, ErrClockSkew) {
+		t.Errorf("Expected ErrClockSkew, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package gsalary
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+)
+
+// Validate 检查配置是否可用：AppID、地址，以及用探测串实际签名、验签检查密钥
+//
+// 密钥检查包括：私钥与公钥是否匹配、密钥长度是否至少 2048 位、
+// 服务端公钥是否误配置成了客户端自己的公钥。
+// 存在错误级别的问题时返回 *ConfigError，其 Report 中同时包含警告。
+func (c *GSalaryConfig) Validate() error {
+	report := &ConfigReport{}
+	checkAppID(report, c.AppID)
+	checkEndpoint(report, c.Endpoint)
+
+	signer, verifier := c.GetSigner(), c.GetVerifier()
+	if signer == nil {
+		report.addIssue(keyClientPrivateKey, false, "not configured")
+	}
+	if verifier == nil {
+		report.addIssue(keyServerPublicKey, false, "not configured")
+	}
+	if signer != nil && verifier != nil {
+		checkKeys(report, c)
+	}
+	report.sortIssues()
+	return report.Err()
+}
+
+// checkKeys 检查已配置的签名器和验签器
+func checkKeys(report *ConfigReport, c *GSalaryConfig) {
+	signer, verifier := c.GetSigner(), c.GetVerifier()
+	if signer.Algorithm() != verifier.Algorithm() {
+		report.addIssue(keyServerPublicKey, false, "algorithm %s does not match client signer algorithm %s",
+			verifier.Algorithm(), signer.Algorithm())
+		return
+	}
+
+	clientPublicKey, err := c.ClientPublicKey()
+	if err == nil {
+		checkKeySize(report, keyClientPrivateKey, clientPublicKey)
+	}
+	for _, serverKey := range c.serverPublicKeys() {
+		checkKeySize(report, keyServerPublicKey, serverKey)
+	}
+
+	// 用探测串实际签名一次
+	ctx := context.Background()
+	digest := sha256.Sum256([]byte(fmt.Sprintf("gsalary-config-probe\n%s\n%d\n", c.AppID, c.Now().UnixMilli())))
+	signature, err := signer.Sign(ctx, digest[:])
+	if err != nil {
+		report.addIssue(keyClientPrivateKey, false, "cannot sign a probe: %v", err)
+		return
+	}
+	if clientPublicKey != nil && rsa.VerifyPKCS1v15(clientPublicKey, crypto.SHA256, digest[:], signature) != nil {
+		report.addIssue(keyClientPrivateKey, false, "probe signature does not verify with the client's own public key")
+	}
+	// 服务端公钥能验证客户端签名，说明配置的是客户端自己的公钥
+	if verifier.Verify(ctx, digest[:], signature) == nil {
+		report.addIssue(keyServerPublicKey, false,
+			"is the client's own public key; use the GSalary server public key from the merchant portal")
+	}
+}
+
+// checkKeySize 检查RSA密钥长度
+func checkKeySize(report *ConfigReport, field string, key *rsa.PublicKey) {
+	if bits := key.N.BitLen(); bits < minKeyBits {
+		report.addIssue(field, false, "key is %d bits, at least %d required", bits, minKeyBits)
+	}
+}
+
+// serverPublicKeys 返回已知的服务端公钥，自定义 Verifier 时为空
+func (c *GSalaryConfig) serverPublicKeys() []*rsa.PublicKey {
+	if c.serverPublicKey != nil {
+		return []*rsa.PublicKey{c.serverPublicKey}
+	}
+	ring, ok := c.verifier.(*KeyRing)
+	if !ok {
+		return nil
+	}
+	var keys []*rsa.PublicKey
+	for _, key := range ring.Keys() {
+		keys = append(keys, key.PublicKey)
+	}
+	return keys
+}
@@ -0,0 +1,37 @@
+package gsalary
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestConfigValidate 测试配置自检：缺少密钥、服务端公钥误配为客户端公钥
+func TestConfigValidate(t *testing.T) {
+	config := newTestConfig(t, EndpointTest)
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Expected valid config, got %v", err)
+	}
+
+	var configErr *ConfigError
+	err := NewConfig().Validate()
+	if !errors.As(err, &configErr) {
+		t.Fatalf("Expected *ConfigError, got %v", err)
+	}
+	for _, want := range []string{"app_id", "client_private_key: not configured", "server_public_key: not configured"} {
+		if !strings.Contains(configErr.Report.String(), want) {
+			t.Errorf("Expected report to contain %q, got:\n%s", want, configErr.Report)
+		}
+	}
+
+	clientKey, _ := testKeys(t)
+	ring, err := NewKeyRing(ServerKey{ID: "self", PublicKey: &clientKey.PublicKey})
+	if err != nil {
+		t.Fatalf("NewKeyRing failed: %v", err)
+	}
+	config.ConfigServerKeyRing(ring)
+	err = config.Validate()
+	if err == nil || !strings.Contains(err.Error(), "client's own public key") {
+		t.Errorf("Expected self key error, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package gsalary
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseYAML 解析配置文件使用的YAML子集
+//
+// 只支持缩进表示的嵌套映射和标量值（可带单引号或双引号），以及 # 注释；
+// 不支持列表、锚点、多行字符串和同一行内的 {} 映射，遇到时返回带行号的错误。
+func parseYAML(data []byte) (map[string]interface{}, error) {
+	root := make(map[string]interface{})
+	type frame struct {
+		indent int
+		values map[string]interface{}
+	}
+	stack := []frame{{indent: 0, values: root}}
+	// pending 值为空的键，等待下一行更深的缩进成为它的子映射
+	var pending struct {
+		key    string
+		indent int
+		parent map[string]interface{}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for i, raw := range lines {
+		lineNo := i + 1
+		line := stripYAMLComment(raw)
+		if strings.TrimSpace(line) == "" || strings.TrimSpace(line) == "---" {
+			continue
+		}
+		content := strings.TrimSpace(line)
+		leading := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if strings.Contains(leading, "\t") {
+			return nil, fmt.Errorf("yaml line %d: tabs are not allowed for indentation", lineNo)
+		}
+		indent := len(leading)
+		if strings.HasPrefix(content, "- ") || content == "-" {
+			return nil, fmt.Errorf("yaml line %d: lists are not supported", lineNo)
+		}
+
+		if pending.parent != nil {
+			child := make(map[string]interface{})
+			if indent > pending.indent {
+				pending.parent[pending.key] = child
+				stack = append(stack, frame{indent: indent, values: child})
+			} else {
+				pending.parent[pending.key] = ""
+			}
+			pending.parent = nil
+		}
+		for len(stack) > 1 && indent < stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+		top := stack[len(stack)-1]
+		if indent != top.indent {
+			return nil, fmt.Errorf("yaml line %d: unexpected indentation", lineNo)
+		}
+
+		colon := strings.Index(content, ":")
+		if colon <= 0 || (colon+1 < len(content) && content[colon+1] != ' ') {
+			return nil, fmt.Errorf("yaml line %d: expected \"key: value\"", lineNo)
+		}
+		key, err := unquoteYAML(strings.TrimSpace(content[:colon]))
+		if err != nil {
+			return nil, fmt.Errorf("yaml line %d: %v", lineNo, err)
+		}
+		if _, exists := top.values[key]; exists {
+			return nil, fmt.Errorf("yaml line %d: duplicate key %q", lineNo, key)
+		}
+		value := strings.TrimSpace(content[colon+1:])
+		switch {
+		case value == "":
+			pending.key, pending.indent, pending.parent = key, indent, top.values
+		case strings.HasPrefix(value, "{") || strings.HasPrefix(value, "[") ||
+			strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") ||
+			strings.HasPrefix(value, "&") || strings.HasPrefix(value, "*"):
+			return nil, fmt.Errorf("yaml line %d: only plain scalar values are supported", lineNo)
+		default:
+			scalar, err := unquoteYAML(value)
+			if err != nil {
+				return nil, fmt.Errorf("yaml line %d: %v", lineNo, err)
+			}
+			top.values[key] = scalar
+		}
+	}
+	if pending.parent != nil {
+		pending.parent[pending.key] = ""
+	}
+	return root, nil
+}
+
+// stripYAMLComment 去除行尾注释，引号内的 # 保留
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return strings.TrimRight(line[:i], " \t")
+		}
+	}
+	return strings.TrimRight(line, " \t")
+}
+
+// unquoteYAML 去除标量两侧的引号，双引号支持转义
+func unquoteYAML(s string) (string, error) {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return strconv.Unquote(s)
+	}
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+	if strings.HasPrefix(s, "\"") || strings.HasPrefix(s, "'") {
+		return "", fmt.Errorf("unterminated quoted string %s", s)
+	}
+	return s, nil
+}